@@ -11,12 +11,18 @@ import (
 	"syscall"
 )
 
+// Job is a background process running alongside the HTTP server.
+type Job interface {
+	Run(ctx context.Context) error
+}
+
 type App struct {
 	config *Config
 	server *http.Server
+	jobs   []Job
 }
 
-func NewApp(config *Config, handler http.Handler) *App {
+func NewApp(config *Config, handler http.Handler, jobs ...Job) *App {
 	server := &http.Server{
 		Addr:    ":" + config.Port,
 		Handler: handler,
@@ -24,6 +30,7 @@ func NewApp(config *Config, handler http.Handler) *App {
 	app := &App{
 		config: config,
 		server: server,
+		jobs:   jobs,
 	}
 	return app
 }
@@ -44,6 +51,12 @@ func (a *App) Run() {
 		}
 		return nil
 	})
+	for _, job := range a.jobs {
+		job := job
+		wg.Go(func() error {
+			return job.Run(gCtx)
+		})
+	}
 	wg.Go(func() error {
 		<-gCtx.Done()
 		log.Infof("[APP] Shutting down HTTP server")
@@ -8,6 +8,8 @@ import (
 type Config struct {
 	Port            string `env:"PORT,default=8080"`
 	ShutdownTimeout time.Duration
+	// MigrateOnStart enables applying pending DB migrations on start, otherwise the app refuses to start until they are applied.
+	MigrateOnStart bool `env:"MIGRATE_ON_START,default=true"`
 }
 
 func NewConfig() *Config {
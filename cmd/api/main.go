@@ -1,16 +1,30 @@
 package main
 
 import (
+	"context"
 	"github.com/d-ashesss/mah-moneh/cmd/api/rest"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/auth"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
 	"github.com/d-ashesss/mah-moneh/internal/capital"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/migrations"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
 	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/d-ashesss/mah-moneh/log"
+	"os"
 )
 
 func main() {
@@ -22,39 +36,93 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to the DB: %s", err)
 	}
+	appCfg := NewConfig()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.Run(context.Background(), db, os.Args[2:]); err != nil {
+			log.Fatalf("Failed to migrate the DB: %s", err)
+		}
+		return
+	}
 
+	txManager := datastore.NewGormTxManager(db)
 	authCfg := auth.NewConfig()
-	usersService := users.NewService()
-	authService := auth.NewService(authCfg, usersService)
+	usersStore := users.NewGormStore(db)
+	usersService := users.NewService(usersStore)
+	tokensStore := tokens.NewGormStore(db)
+	tokensService := tokens.NewService(tokensStore)
+	authService := auth.NewService(authCfg, usersService, tokensService)
+	if authCfg.DevMode {
+		dev, err := authService.EnableDevMode()
+		if err != nil {
+			log.Fatalf("Failed to enable development auth mode: %s", err)
+		}
+		log.Warningf("[AUTH] Development mode is enabled, anyone can mint tokens of %s at POST /dev/tokens. Never use it in production!", dev.Issuer())
+	} else if len(authCfg.OpenIDConfigurationUrls) == 0 {
+		log.Fatalf("No identity provider is configured: set AUTH_OPENID_CONFIGURATION_URL, or AUTH_DEV_MODE=true for local development")
+	}
+	workspacesCfg := workspaces.NewConfig()
+	workspacesStore := workspaces.NewGormStore(db)
+	workspacesService := workspaces.NewService(workspacesCfg, workspacesStore)
+	auditStore := audit.NewGormStore(db)
+	auditService := audit.NewService(auditStore)
 	accountsStore := accounts.NewGormStore(db)
-	accountsService := accounts.NewService(accountsStore)
+	accountsService := accounts.NewService(accountsStore, txManager, auditService)
 	categoriesStore := categories.NewGormStore(db)
-	categoriesService := categories.NewService(categoriesStore)
+	categoriesService := categories.NewService(categoriesStore, txManager, auditService)
+	payeesStore := payees.NewGormStore(db)
+	payeesService := payees.NewService(payeesStore)
 	transactionsStore := transactions.NewGormStore(db)
-	transactionsService := transactions.NewService(transactionsStore)
+	transactionsService := transactions.NewService(transactionsStore, txManager, payeesService, auditService)
+	labelsStore := labels.NewGormStore(db)
+	labelsService := labels.NewService(labelsStore)
+	blobsCfg := blobs.NewConfig()
+	blobStorage, err := blobs.Open(blobsCfg)
+	if err != nil {
+		log.Fatalf("Failed to open blob storage: %s", err)
+	}
+	attachmentsCfg := attachments.NewConfig()
+	attachmentsStore := attachments.NewGormStore(db)
+	attachmentsService := attachments.NewService(attachmentsCfg, attachmentsStore, blobStorage)
 	capitalService := capital.NewService(accountsService)
-	spendingsService := spendings.NewService(capitalService, transactionsService, categoriesService)
-
-	if err := db.AutoMigrate(
-		&accounts.Account{},
-		&accounts.Amount{},
-		&categories.Category{},
-		&transactions.Transaction{},
-	); err != nil {
-		log.Fatalf("Failed to run DB migration: %s", err)
+	spendingsService := spendings.NewService(capitalService, transactionsService, categoriesService, labelsService, payeesService)
+	recurringStore := recurring.NewGormStore(db)
+	recurringService := recurring.NewService(recurringStore, txManager, transactionsService)
+	trashStore := trash.NewGormStore(db)
+	trashService := trash.NewService(trashStore, txManager, auditService, blobStorage)
+	currenciesStore := currencies.NewGormStore(db)
+	currenciesService := currencies.NewService(currenciesStore, txManager, auditService)
+	backupService := backup.NewService(txManager, usersService, accountsService, categoriesService, labelsService, payeesService, transactionsService, currenciesService)
+
+	if err := migrateOnStart(context.Background(), appCfg, db); err != nil {
+		log.Fatalf("Failed to migrate the DB: %s", err)
 	}
 
 	handlerCfg := rest.NewConfig()
 	handler := rest.NewHandler(
 		handlerCfg,
 		authService,
+		usersService,
+		tokensService,
+		workspacesService,
 		accountsService,
 		categoriesService,
 		transactionsService,
+		labelsService,
+		attachmentsService,
+		payeesService,
 		spendingsService,
+		recurringService,
+		trashService,
+		auditService,
+		backupService,
 	)
 
-	appCfg := NewConfig()
-	app := NewApp(appCfg, handler)
+	recurringCfg := recurring.NewConfig()
+	recurringScheduler := recurring.NewScheduler(recurringCfg, recurringService)
+	trashCfg := trash.NewConfig()
+	trashPurger := trash.NewPurger(trashCfg, trashService)
+
+	app := NewApp(appCfg, handler, authService, recurringScheduler, trashPurger)
 	app.Run()
 }
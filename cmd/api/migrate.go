@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/migrations"
+	"github.com/d-ashesss/mah-moneh/log"
+	"gorm.io/gorm"
+)
+
+// migrateOnStart brings the database schema to the version of the application before serving requests.
+// It refuses to start if the database was migrated by a newer version of the application.
+func migrateOnStart(ctx context.Context, cfg *Config, db *gorm.DB) error {
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	if adopted, err := migrations.Adopt(ctx, db, m); err != nil {
+		return err
+	} else if adopted {
+		log.Infof("[MIGRATE] Existing database is adopted at version %d", migrations.BaselineVersion)
+	}
+	err = m.Check(ctx)
+	if errors.Is(err, datastore.ErrSchemaOutdated) && cfg.MigrateOnStart {
+		return migrations.Up(ctx, m)
+	}
+	return err
+}
@@ -1,8 +1,10 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 	"net/http"
@@ -33,22 +35,26 @@ func (h *handler) account(c *gin.Context) (*accounts.Account, error) {
 	if err != nil {
 		return nil, err
 	}
-	u := h.user(c)
-	if acc.User.ID != u.ID {
-		return nil, ErrResourceNotFound
+	if err := h.authorize(c, acc.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, acc.Version); err != nil {
+		return nil, err
 	}
 	return acc, nil
 }
 
 type AccountResponse struct {
-	UUID string `json:"uuid"`
-	Name string `json:"name"`
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Version uint   `json:"version"`
 }
 
 func NewAccountResponse(acc *accounts.Account) *AccountResponse {
 	return &AccountResponse{
-		UUID: acc.UUID.String(),
-		Name: acc.Name,
+		UUID:    acc.UUID.String(),
+		Name:    acc.Name,
+		Version: acc.Version,
 	}
 }
 
@@ -66,25 +72,52 @@ func (h *handler) handleAccountsCreate(c *gin.Context) {
 		h.handleError(c, err)
 		return
 	}
-	acc, err := h.accounts.CreateAccount(c, h.user(c), input.Name)
+	acc, err := h.accounts.CreateAccount(c, h.workspace(c), input.Name)
 	if err != nil {
 		h.handleError(c, fmt.Errorf("failed to create account: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusCreated, NewAccountResponse(acc))
+	h.respondVersioned(c, http.StatusCreated, acc.Version, NewAccountResponse(acc))
 }
 
 func (h *handler) handleAccountsList(c *gin.Context) {
-	accs, err := h.accounts.GetUserAccounts(c, h.user(c))
+	var input PageInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if input.Paginated() {
+		page, err := h.accounts.GetWorkspaceAccountsPage(c, h.workspace(c), input.PageRequest())
+		if errors.Is(err, datastore.ErrInvalidCursor) {
+			h.handleError(c, NewErrBadRequest(err))
+			return
+		}
+		if err != nil {
+			h.handleError(c, fmt.Errorf("failed to get workspace accounts: %w", err))
+			return
+		}
+		h.respondPage(c, NewListAccountsResponse(page.Accounts), page.NextCursor)
+		return
+	}
+	accs, err := h.accounts.GetWorkspaceAccounts(c, h.workspace(c))
 	if err != nil {
-		h.handleError(c, fmt.Errorf("failed to get user accounts: %w", err))
+		h.handleError(c, fmt.Errorf("failed to get workspace accounts: %w", err))
 		return
 	}
 
 	c.JSON(http.StatusOK, NewListAccountsResponse(accs))
 }
 
+func (h *handler) handleAccountsGet(c *gin.Context) {
+	acc, err := h.account(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find account: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, acc.Version, NewAccountResponse(acc))
+}
+
 func (h *handler) handleAccountsUpdate(c *gin.Context) {
 	acc, err := h.account(c)
 	if err != nil {
@@ -101,7 +134,7 @@ func (h *handler) handleAccountsUpdate(c *gin.Context) {
 		h.handleError(c, fmt.Errorf("failed to update account: %w", err))
 		return
 	}
-	c.JSON(http.StatusOK, NewAccountResponse(acc))
+	h.respondVersioned(c, http.StatusOK, acc.Version, NewAccountResponse(acc))
 }
 
 func (h *handler) handleAccountsDelete(c *gin.Context) {
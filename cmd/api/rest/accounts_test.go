@@ -14,7 +14,7 @@ func (ts *RESTTestSuite) testAccountsErrors() {
 	auth1 := ts.NewAuth()
 	auth2 := ts.NewAuth()
 
-	user1account, err := ts.accountsService.CreateAccount(context.Background(), auth1.user, "test account")
+	user1account, err := ts.accountsService.CreateAccount(context.Background(), auth1.workspace, "test account")
 	ts.Require().NoErrorf(err, "Failed to create test account")
 
 	tests := []ErrorTest{
@@ -258,8 +258,8 @@ func (ts *RESTTestSuite) testGetAccounts() {
 			Target: "/accounts",
 			Auth:   ts.users.main,
 			Expected: fmt.Sprintf(`[
-				{"uuid": "%s", "name": "bank"},
-				{"uuid": "%s", "name": "cash"}
+				{"uuid": "%s", "name": "bank", "version": 1},
+				{"uuid": "%s", "name": "cash", "version": 1}
 			]`, ts.accounts.bank, ts.accounts.cash),
 		},
 		{
@@ -272,6 +272,8 @@ func (ts *RESTTestSuite) testGetAccounts() {
 	for _, tt := range tests {
 		ts.testJSON(tt)
 	}
+	ts.testPageCount(PageCountTest{Name: "get main accounts/first page", Target: "/accounts?limit=1", Auth: ts.users.main, Count: 1, Next: true})
+	ts.testPageCount(PageCountTest{Name: "get main accounts/single page", Target: "/accounts?limit=2", Auth: ts.users.main, Count: 2})
 
 	ts.testGetAccountAmounts()
 }
@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// multipartOverhead is the allowance for multipart encoding on top of the attachment size.
+const multipartOverhead = 1 << 20
+
+type CreateAttachmentInput struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+func (i *CreateAttachmentInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetAttachmentInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetAttachmentInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+type ListAttachmentsInput struct {
+	TransactionUUID string `form:"transaction_uuid" binding:"required,uuid"`
+}
+
+func (i *ListAttachmentsInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+func (h *handler) attachment(c *gin.Context) (*attachments.Attachment, error) {
+	var input GetAttachmentInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	a, err := h.attachments.GetAttachment(c, uuid.FromStringOrNil(input.UUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, a.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, a.Version); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+type AttachmentResponse struct {
+	UUID        string `json:"uuid"`
+	Transaction string `json:"transaction_uuid"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func NewAttachmentResponse(a *attachments.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		UUID:        a.UUID.String(),
+		Transaction: a.TransactionUUID.String(),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func NewListAttachmentsResponse(atts attachments.AttachmentCollection) []*AttachmentResponse {
+	r := make([]*AttachmentResponse, 0, len(atts))
+	for _, a := range atts {
+		r = append(r, NewAttachmentResponse(a))
+	}
+	return r
+}
+
+func (h *handler) handleAttachmentError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, attachments.ErrTooLarge) || errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse("File is too large"))
+		return
+	}
+	if errors.Is(err, attachments.ErrContentTypeNotAllowed) {
+		c.JSON(http.StatusUnsupportedMediaType, NewErrorResponse("Unsupported file type"))
+		return
+	}
+	h.handleError(c, err)
+}
+
+func (h *handler) handleAttachmentsCreate(c *gin.Context) {
+	tx, err := h.transaction(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find transaction: %w", err))
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachments.MaxSize()+multipartOverhead)
+	var input CreateAttachmentInput
+	if err := input.Bind(c); err != nil {
+		h.handleAttachmentError(c, err)
+		return
+	}
+	if input.File.Size > h.attachments.MaxSize() {
+		h.handleAttachmentError(c, attachments.ErrTooLarge)
+		return
+	}
+	f, err := input.File.Open()
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to open uploaded file: %w", err))
+		return
+	}
+	defer func() { _ = f.Close() }()
+	a, err := h.attachments.CreateAttachment(c, tx, input.File.Filename, f)
+	if err != nil {
+		h.handleAttachmentError(c, fmt.Errorf("failed to create attachment: %w", err))
+		return
+	}
+	c.JSON(http.StatusCreated, NewAttachmentResponse(a))
+}
+
+func (h *handler) handleAttachmentsList(c *gin.Context) {
+	var input ListAttachmentsInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	tx, err := h.findTransaction(c, input.TransactionUUID)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find transaction: %w", err))
+		return
+	}
+	atts, err := h.attachments.GetTransactionAttachments(c, tx)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get transaction attachments: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListAttachmentsResponse(atts))
+}
+
+func (h *handler) handleAttachmentsDownload(c *gin.Context) {
+	a, err := h.attachment(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find attachment: %w", err))
+		return
+	}
+	r, err := h.attachments.OpenAttachment(c, a)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to open attachment: %w", err))
+		return
+	}
+	defer func() { _ = r.Close() }()
+	c.DataFromReader(http.StatusOK, a.Size, a.ContentType, r, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": a.Name}),
+	})
+}
+
+func (h *handler) handleAttachmentsDelete(c *gin.Context) {
+	a, err := h.attachment(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find attachment: %w", err))
+		return
+	}
+	if err := h.attachments.DeleteAttachment(c, a); err != nil {
+		h.handleError(c, fmt.Errorf("failed to delete attachment: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+)
+
+// pngContent is a content recognized as a PNG image.
+var pngContent = []byte("\x89PNG\x0D\x0A\x1A\x0Aimage data")
+
+func NewUploadRequest(target, filename string, content []byte) *Request {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	fw, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := fw.Write(content); err != nil {
+		panic(err)
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+	r := &Request{
+		Request: httptest.NewRequest("POST", target, body),
+	}
+	r.Header.Add("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func (ts *RESTTestSuite) testAttachments() {
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+
+	tx, err := ts.transactionsService.CreateTransaction(context.Background(), auth1.workspace, "2010-01", "USD", -100, "headphones", nil)
+	ts.Require().NoErrorf(err, "Failed to create test transaction")
+	target := "/transactions/" + tx.UUID.String() + "/attachments"
+	listTarget := "/attachments?transaction_uuid=" + tx.UUID.String()
+
+	var attachment CreationTestResponse
+	ts.Run("upload", func() {
+		code := ts.ServeJSON(NewUploadRequest(target, "receipt.png", pngContent).WithAuth(auth1), &attachment)
+		ts.Equal(http.StatusCreated, code)
+		ts.Require().NotEmpty(attachment.UUID)
+	})
+
+	errorTests := []struct {
+		Name    string
+		Request *Request
+		Code    int
+		Error   string
+	}{
+		{
+			Name:    "upload/not owner",
+			Request: NewUploadRequest(target, "receipt.png", pngContent).WithAuth(auth2),
+			Code:    http.StatusNotFound,
+			Error:   "Not found",
+		},
+		{
+			Name:    "upload/unsupported type",
+			Request: NewUploadRequest(target, "receipt.txt", []byte("plain text")).WithAuth(auth1),
+			Code:    http.StatusUnsupportedMediaType,
+			Error:   "Unsupported file type",
+		},
+		{
+			Name:    "upload/too large",
+			Request: NewUploadRequest(target, "receipt.png", append(pngContent, make([]byte, 1024)...)).WithAuth(auth1),
+			Code:    http.StatusRequestEntityTooLarge,
+			Error:   "File is too large",
+		},
+		{
+			Name:    "download/not owner",
+			Request: NewRequest("GET", "/attachments/"+attachment.UUID, nil).WithAuth(auth2),
+			Code:    http.StatusNotFound,
+			Error:   "Not found",
+		},
+	}
+	for _, tt := range errorTests {
+		ts.Run(tt.Name, func() {
+			response := new(ErrorTestResponse)
+			code := ts.ServeJSON(tt.Request, response)
+			ts.Equal(tt.Code, code)
+			ts.Equal(tt.Error, response.Error)
+		})
+	}
+
+	ts.testCount(CountTest{Name: "list", Target: listTarget, Auth: auth1, Count: 1})
+
+	ts.Run("download", func() {
+		rr := httptest.NewRecorder()
+		ts.handler.ServeHTTP(rr, NewRequest("GET", "/attachments/"+attachment.UUID, nil).WithAuth(auth1).Request)
+		ts.Equal(http.StatusOK, rr.Code)
+		ts.Equal("image/png", rr.Header().Get("Content-Type"))
+		ts.Equal(`attachment; filename=receipt.png`, rr.Header().Get("Content-Disposition"))
+		ts.Equal(pngContent, rr.Body.Bytes())
+	})
+
+	ts.testRequest(RequestTest{
+		Name:   "delete",
+		Method: "DELETE",
+		Target: "/attachments/" + attachment.UUID,
+		Auth:   auth1,
+		Code:   http.StatusNoContent,
+	})
+	ts.testCount(CountTest{Name: "list after delete", Target: listTarget, Auth: auth1, Count: 0})
+
+	ts.Run("delete and restore transaction", func() {
+		code := ts.ServeJSON(NewUploadRequest(target, "receipt.png", pngContent).WithAuth(auth1), &attachment)
+		ts.Require().Equal(http.StatusCreated, code)
+
+		code = ts.Serve(NewRequest("DELETE", "/transactions/"+tx.UUID.String(), nil).WithAuth(auth1))
+		ts.Require().Equal(http.StatusNoContent, code)
+		code = ts.Serve(NewRequest("POST", "/trash/transaction/"+tx.UUID.String()+"/restore", nil).WithAuth(auth1))
+		ts.Require().Equal(http.StatusNoContent, code)
+
+		atts, err := ts.attachmentsService.GetTransactionAttachments(context.Background(), tx)
+		ts.Require().NoError(err, "Failed to get transaction attachments.")
+		ts.Require().Len(atts, 1, "Attachments must be kept while the transaction is in the trash.")
+		ts.Equal(attachment.UUID, atts[0].UUID.String())
+		code = ts.Serve(NewRequest("GET", "/attachments/"+attachment.UUID, nil).WithAuth(auth1))
+		ts.Equal(http.StatusOK, code, "Attachment content must be kept while the transaction is in the trash.")
+	})
+}
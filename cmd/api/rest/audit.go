@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ListAuditInput struct {
+	Entity string `form:"entity" binding:"omitempty,oneof=account amount category transaction rate"`
+	From   string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To     string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+func (i *ListAuditInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+func (i *ListAuditInput) Filter() *audit.Filter {
+	f := &audit.Filter{
+		Entity: audit.Entity(i.Entity),
+		Limit:  i.Limit,
+	}
+	if t, err := time.Parse(time.RFC3339, i.From); err == nil {
+		f.From = &t
+	}
+	if t, err := time.Parse(time.RFC3339, i.To); err == nil {
+		f.To = &t
+	}
+	return f
+}
+
+type AuditEventResponse struct {
+	UUID      string          `json:"uuid"`
+	UserID    string          `json:"user_id"`
+	Action    audit.Action    `json:"action"`
+	Entity    audit.Entity    `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt string          `json:"created_at"`
+}
+
+func NewAuditEventResponse(e *audit.Event) *AuditEventResponse {
+	return &AuditEventResponse{
+		UUID:      e.UUID.String(),
+		UserID:    e.UserID,
+		Action:    e.Action,
+		Entity:    e.Entity,
+		EntityID:  e.EntityID,
+		Before:    auditSnapshot(e.Before),
+		After:     auditSnapshot(e.After),
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// auditSnapshot provides the snapshot of the record as is, or null if the record is missing.
+func auditSnapshot(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
+
+func NewListAuditResponse(events audit.EventCollection) []*AuditEventResponse {
+	r := make([]*AuditEventResponse, 0, len(events))
+	for _, e := range events {
+		r = append(r, NewAuditEventResponse(e))
+	}
+	return r
+}
+
+func (h *handler) handleAuditList(c *gin.Context) {
+	var input ListAuditInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	events, err := h.audit.GetWorkspaceEvents(c, h.workspace(c), input.Filter())
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get audit events: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListAuditResponse(events))
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (ts *RESTTestSuite) testAudit() {
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+	since := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	ts.testRequest(RequestTest{Name: "create category", Method: "POST", Target: "/categories", Body: bytes.NewBufferString(`{"name": "food"}`), Auth: auth1, Code: http.StatusCreated})
+	ts.testRequest(RequestTest{Name: "create account", Method: "POST", Target: "/accounts", Body: bytes.NewBufferString(`{"name": "cash"}`), Auth: auth1, Code: http.StatusCreated})
+
+	ts.testCount(CountTest{Name: "list", Target: "/audit", Auth: auth1, Count: 2})
+	ts.testCount(CountTest{Name: "list/entity", Target: "/audit?entity=category", Auth: auth1, Count: 1})
+	ts.testCount(CountTest{Name: "list/from", Target: "/audit?from=" + url.QueryEscape(since), Auth: auth1, Count: 2})
+	ts.testCount(CountTest{Name: "list/to", Target: "/audit?to=" + url.QueryEscape(since), Auth: auth1, Count: 0})
+	ts.testCount(CountTest{Name: "list/limit", Target: "/audit?limit=1", Auth: auth1, Count: 1})
+	ts.testCount(CountTest{Name: "list/other workspace", Target: "/audit", Auth: auth2, Count: 0})
+
+	ts.Run("event", func() {
+		response := make([]map[string]any, 0)
+		code := ts.ServeJSON(NewRequest("GET", "/audit?entity=category", nil).WithAuth(auth1), &response)
+		ts.Require().Equal(http.StatusOK, code)
+		ts.Require().Len(response, 1)
+		ts.Equal(auth1.user.ID, response[0]["user_id"])
+		ts.Equal("create", response[0]["action"])
+		ts.Nil(response[0]["before"])
+		ts.Equal("food", response[0]["after"].(map[string]any)["Name"])
+	})
+
+	for _, tt := range []ErrorTest{
+		{
+			Name:   "list/invalid entity",
+			Method: "GET",
+			Target: "/audit?entity=label",
+			Auth:   auth1,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Entity'",
+		},
+		{
+			Name:   "list/invalid time",
+			Method: "GET",
+			Target: "/audit?from=2010-01-01",
+			Auth:   auth1,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'From'",
+		},
+	} {
+		ts.testError(tt)
+	}
+}
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ImportInput struct {
+	Strategy string `form:"strategy" binding:"omitempty,oneof=skip overwrite duplicate"`
+}
+
+func (i *ImportInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+type ImportCountResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+func NewImportCountResponse(c backup.Count) *ImportCountResponse {
+	return &ImportCountResponse{Created: c.Created, Updated: c.Updated, Skipped: c.Skipped}
+}
+
+type ImportResponse struct {
+	Settings     *ImportCountResponse `json:"settings"`
+	Accounts     *ImportCountResponse `json:"accounts"`
+	Amounts      *ImportCountResponse `json:"amounts"`
+	Categories   *ImportCountResponse `json:"categories"`
+	Labels       *ImportCountResponse `json:"labels"`
+	Payees       *ImportCountResponse `json:"payees"`
+	Transactions *ImportCountResponse `json:"transactions"`
+	Rates        *ImportCountResponse `json:"rates"`
+}
+
+func NewImportResponse(r *backup.Report) *ImportResponse {
+	return &ImportResponse{
+		Settings:     NewImportCountResponse(r.Settings),
+		Accounts:     NewImportCountResponse(r.Accounts),
+		Amounts:      NewImportCountResponse(r.Amounts),
+		Categories:   NewImportCountResponse(r.Categories),
+		Labels:       NewImportCountResponse(r.Labels),
+		Payees:       NewImportCountResponse(r.Payees),
+		Transactions: NewImportCountResponse(r.Transactions),
+		Rates:        NewImportCountResponse(r.Rates),
+	}
+}
+
+func (h *handler) handleExport(c *gin.Context) {
+	a, err := h.backup.Export(c, h.user(c), h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to export workspace: %w", err))
+		return
+	}
+	filename := fmt.Sprintf("mah-moneh-%s.json", a.ExportedAt.Format(time.DateOnly))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.JSON(http.StatusOK, a)
+}
+
+func (h *handler) handleImport(c *gin.Context) {
+	var input ImportInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.importMaxSize)
+	var a backup.Archive
+	if err := c.ShouldBindJSON(&a); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse("Archive is too large"))
+			return
+		}
+		h.handleError(c, NewErrBadRequest(err))
+		return
+	}
+	r, err := h.backup.Import(c, h.user(c), h.workspace(c), &a, backup.Strategy(input.Strategy))
+	if errors.Is(err, backup.ErrUnsupportedVersion) || errors.Is(err, backup.ErrInvalidArchive) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err))
+		return
+	}
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to import workspace: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewImportResponse(r))
+}
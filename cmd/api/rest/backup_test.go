@@ -0,0 +1,155 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"net/http"
+	"strings"
+)
+
+type BackupTestArchive struct {
+	Version      int              `json:"version"`
+	Accounts     []map[string]any `json:"accounts"`
+	Categories   []map[string]any `json:"categories"`
+	Labels       []map[string]any `json:"labels"`
+	Payees       []map[string]any `json:"payees"`
+	Transactions []map[string]any `json:"transactions"`
+}
+
+type BackupTestCount struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+type BackupTestReport struct {
+	Accounts     BackupTestCount `json:"accounts"`
+	Amounts      BackupTestCount `json:"amounts"`
+	Categories   BackupTestCount `json:"categories"`
+	Labels       BackupTestCount `json:"labels"`
+	Payees       BackupTestCount `json:"payees"`
+	Transactions BackupTestCount `json:"transactions"`
+}
+
+func (ts *RESTTestSuite) testBackup() {
+	ctx := context.Background()
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+
+	acc, err := ts.accountsService.CreateAccount(ctx, auth1.workspace, "cash")
+	ts.Require().NoError(err, "Failed to create test account")
+	ts.Require().NoError(ts.accountsService.SetAccountAmount(ctx, acc, "2010-10", "USD", 100), "Failed to set test account amount")
+	cat, err := ts.categoriesService.CreateCategory(ctx, auth1.workspace, "food")
+	ts.Require().NoError(err, "Failed to create test category")
+	lbl, err := ts.labelsService.CreateLabel(ctx, auth1.workspace, "trip")
+	ts.Require().NoError(err, "Failed to create test label")
+	_, err = ts.payeesService.CreatePayee(ctx, auth1.workspace, "shop", []string{"the shop"})
+	ts.Require().NoError(err, "Failed to create test payee")
+	tx, err := ts.transactionsService.CreateTransaction(ctx, auth1.workspace, "2010-10", "USD", -10, "lunch", cat)
+	ts.Require().NoError(err, "Failed to create test transaction")
+	ts.Require().NoError(ts.transactionsService.SetTransactionLabels(ctx, tx, labels.LabelCollection{lbl}), "Failed to label test transaction")
+
+	var archive []byte
+	ts.Run("export", func() {
+		rr := NewRequest("GET", "/export", nil).WithAuth(auth1)
+		code, body := ts.ServeString(rr)
+		ts.Require().Equal(http.StatusOK, code)
+		archive = []byte(body)
+
+		a := new(BackupTestArchive)
+		ts.Require().NoError(json.Unmarshal(archive, a), "Failed to decode the archive")
+		ts.Equal(1, a.Version)
+		ts.Len(a.Accounts, 1)
+		ts.Len(a.Categories, 1)
+		ts.Len(a.Labels, 1)
+		ts.Len(a.Payees, 1)
+		ts.Require().Len(a.Transactions, 1)
+		ts.Equal(cat.UUID.String(), a.Transactions[0]["category_uuid"])
+	})
+
+	ts.Run("import", func() {
+		rr := NewRequest("POST", "/import", bytes.NewReader(archive)).WithAuth(auth2)
+		report := new(BackupTestReport)
+		code := ts.ServeJSON(rr, report)
+		ts.Require().Equal(http.StatusOK, code)
+		ts.Equal(BackupTestCount{Created: 1}, report.Accounts)
+		ts.Equal(BackupTestCount{Created: 1}, report.Amounts)
+		ts.Equal(BackupTestCount{Created: 1}, report.Categories)
+		ts.Equal(BackupTestCount{Created: 1}, report.Labels)
+		ts.Equal(BackupTestCount{Created: 1}, report.Payees)
+		ts.Equal(BackupTestCount{Created: 1}, report.Transactions)
+
+		txs, err := ts.transactionsService.GetWorkspaceTransactions(ctx, auth2.workspace, "2010-10")
+		ts.Require().NoError(err, "Failed to get imported transactions")
+		ts.Require().Len(txs, 1)
+		ts.NotEqual(tx.UUID, txs[0].UUID)
+		ts.Require().NotNil(txs[0].Category)
+		ts.NotEqual(cat.UUID, txs[0].Category.UUID)
+		ts.Equal("food", txs[0].Category.Name)
+		ts.Require().Len(txs[0].Labels, 1)
+		ts.Equal("trip", txs[0].Labels[0].Name)
+	})
+
+	ts.Run("import again", func() {
+		rr := NewRequest("POST", "/import?strategy=skip", bytes.NewReader(archive)).WithAuth(auth2)
+		report := new(BackupTestReport)
+		code := ts.ServeJSON(rr, report)
+		ts.Require().Equal(http.StatusOK, code)
+		ts.Equal(BackupTestCount{Skipped: 1}, report.Accounts)
+		ts.Equal(BackupTestCount{Skipped: 1}, report.Transactions)
+	})
+
+	ts.Run("import duplicate", func() {
+		rr := NewRequest("POST", "/import?strategy=duplicate", bytes.NewReader(archive)).WithAuth(auth2)
+		report := new(BackupTestReport)
+		code := ts.ServeJSON(rr, report)
+		ts.Require().Equal(http.StatusOK, code)
+		ts.Equal(BackupTestCount{Created: 1}, report.Transactions)
+	})
+	ts.testCount(CountTest{Name: "duplicated transactions", Target: "/transactions/2010-10", Auth: auth2, Count: 2})
+
+	for _, tt := range []ErrorTest{
+		{
+			Name:   "import/invalid strategy",
+			Method: "POST",
+			Target: "/import?strategy=merge",
+			Auth:   auth2,
+			Body:   strings.NewReader(`{"version": 1}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Strategy'",
+		},
+		{
+			Name:   "import/unsupported version",
+			Method: "POST",
+			Target: "/import",
+			Auth:   auth2,
+			Body:   strings.NewReader(`{"version": 2}`),
+			Code:   http.StatusBadRequest,
+			Error:  "unsupported archive version",
+		},
+		{
+			Name:   "import/unknown reference",
+			Method: "POST",
+			Target: "/import",
+			Auth:   auth2,
+			Body:   strings.NewReader(`{"version": 1, "transactions": [{"month": "2010-10", "currency": "USD", "amount": 1, "account_uuid": "` + cat.UUID.String() + `"}]}`),
+			Code:   http.StatusBadRequest,
+			Error:  "invalid archive: unknown account " + cat.UUID.String(),
+		},
+		{
+			Name:   "import/too large",
+			Method: "POST",
+			Target: "/import",
+			Auth:   auth2,
+			Body:   strings.NewReader(`{"version": 1, "payees": [{"name": "` + strings.Repeat("a", 16384) + `"}]}`),
+			Code:   http.StatusRequestEntityTooLarge,
+			Error:  "Archive is too large",
+		},
+	} {
+		ts.testError(tt)
+	}
+}
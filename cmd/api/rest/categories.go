@@ -1,8 +1,10 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 	"net/http"
@@ -34,15 +36,19 @@ func (h *handler) category(c *gin.Context) (*categories.Category, error) {
 	if err != nil {
 		return nil, err
 	}
-	if cat.User.ID != h.user(c).ID {
-		return nil, ErrResourceNotFound
+	if err := h.authorize(c, cat.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, cat.Version); err != nil {
+		return nil, err
 	}
-	return cat, err
+	return cat, nil
 }
 
 type CategoryResponse struct {
 	UUID      string `json:"uuid"`
 	Name      string `json:"name"`
+	Version   uint   `json:"version"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -50,6 +56,7 @@ func NewCategoryResponse(cat *categories.Category) *CategoryResponse {
 	return &CategoryResponse{
 		UUID:      cat.UUID.String(),
 		Name:      cat.Name,
+		Version:   cat.Version,
 		CreatedAt: cat.CreatedAt.Format(time.DateTime),
 	}
 }
@@ -68,23 +75,50 @@ func (h *handler) handleCategoriesCreate(c *gin.Context) {
 		h.handleError(c, err)
 		return
 	}
-	cat, err := h.categories.CreateCategory(c, h.user(c), input.Name)
+	cat, err := h.categories.CreateCategory(c, h.workspace(c), input.Name)
 	if err != nil {
 		h.handleError(c, fmt.Errorf("failed to create category: %w", err))
 		return
 	}
-	c.JSON(http.StatusCreated, NewCategoryResponse(cat))
+	h.respondVersioned(c, http.StatusCreated, cat.Version, NewCategoryResponse(cat))
 }
 
 func (h *handler) handleCategoriesList(c *gin.Context) {
-	cats, err := h.categories.GetUserCategories(c, h.user(c))
+	var input PageInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if input.Paginated() {
+		page, err := h.categories.GetWorkspaceCategoriesPage(c, h.workspace(c), input.PageRequest())
+		if errors.Is(err, datastore.ErrInvalidCursor) {
+			h.handleError(c, NewErrBadRequest(err))
+			return
+		}
+		if err != nil {
+			h.handleError(c, fmt.Errorf("failed to get workspace categories: %w", err))
+			return
+		}
+		h.respondPage(c, NewListCategoriesResponse(page.Categories), page.NextCursor)
+		return
+	}
+	cats, err := h.categories.GetWorkspaceCategories(c, h.workspace(c))
 	if err != nil {
-		h.handleError(c, fmt.Errorf("failed to get user categories: %w", err))
+		h.handleError(c, fmt.Errorf("failed to get workspace categories: %w", err))
 		return
 	}
 	c.JSON(http.StatusOK, NewListCategoriesResponse(cats))
 }
 
+func (h *handler) handleCategoriesGet(c *gin.Context) {
+	cat, err := h.category(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find category: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, cat.Version, NewCategoryResponse(cat))
+}
+
 func (h *handler) handleCategoriesDelete(c *gin.Context) {
 	cat, err := h.category(c)
 	if err != nil {
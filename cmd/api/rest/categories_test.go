@@ -13,7 +13,7 @@ func (ts *RESTTestSuite) testCategoriesErrors() {
 	auth1 := ts.NewAuth()
 	auth2 := ts.NewAuth()
 
-	user1category, err := ts.categoriesService.CreateCategory(context.Background(), auth1.user, "test category")
+	user1category, err := ts.categoriesService.CreateCategory(context.Background(), auth1.workspace, "test category")
 	ts.Require().NoErrorf(err, "Failed to create test category")
 
 	tests := []ErrorTest{
@@ -114,4 +114,6 @@ func (ts *RESTTestSuite) testGetCategories() {
 	for _, tt := range tests {
 		ts.testCount(tt)
 	}
+	ts.testPageCount(PageCountTest{Name: "get main categories/first page", Target: "/categories?limit=1", Auth: ts.users.main, Count: 1, Next: true})
+	ts.testPageCount(PageCountTest{Name: "get control categories/page", Target: "/categories?limit=1", Auth: ts.users.control, Count: 0})
 }
@@ -4,6 +4,7 @@ import "github.com/joeshaw/envdecode"
 
 type Config struct {
 	AllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
+	ImportMaxSize  int64    `env:"IMPORT_MAX_SIZE,default=33554432"`
 }
 
 func NewConfig() *Config {
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type DevTokenInput struct {
+	Subject string `json:"subject" binding:"required"`
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+}
+
+func (i *DevTokenInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type DevTokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// handleDevDiscovery serves the discovery document of the development issuer.
+func (h *handler) handleDevDiscovery(c *gin.Context) {
+	dev := h.auth.DevIssuer()
+	c.JSON(http.StatusOK, gin.H{
+		"issuer":                                dev.Issuer(),
+		"jwks_uri":                              dev.JWKSURI(),
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+// handleDevJWKS serves the public keys of the development issuer.
+func (h *handler) handleDevJWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, h.auth.DevIssuer().PublicKeys())
+}
+
+// handleDevTokensCreate mints a token for an arbitrary subject.
+func (h *handler) handleDevTokensCreate(c *gin.Context) {
+	var input DevTokenInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	token, expiresAt, err := h.auth.DevIssuer().Mint(input.Subject, input.Email, input.Name)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to mint token: %w", err))
+		return
+	}
+	c.JSON(http.StatusCreated, &DevTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.DateTime),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/d-ashesss/mah-moneh/log"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -53,10 +54,18 @@ func NewErrorResponse(err any) gin.H {
 }
 
 func (h *handler) handleError(c *gin.Context, err error) {
-	if errors.Is(err, ErrResourceNotFound) {
+	if errors.Is(err, ErrResourceNotFound) || errors.Is(err, workspaces.ErrNotMember) {
 		c.JSON(http.StatusNotFound, NewErrorResponse("Not found"))
 		return
 	}
+	if errors.Is(err, workspaces.ErrForbidden) {
+		c.JSON(http.StatusForbidden, NewErrorResponse("Forbidden"))
+		return
+	}
+	if errors.Is(err, ErrPreconditionFailed) || errors.Is(err, datastore.ErrStaleRecord) {
+		c.JSON(http.StatusPreconditionFailed, NewErrorResponse("Precondition failed"))
+		return
+	}
 	var validationErr validator.ValidationErrors
 	if errors.As(err, &validationErr) {
 		c.JSON(http.StatusBadRequest, NewErrorResponse(fmt.Sprintf("Invalid value of '%s'", validationErr[0].Field())))
@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPreconditionFailed = fmt.Errorf("precondition failed")
+)
+
+// etag provides the entity tag of the record version.
+func etag(version uint) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+// precondition verifies that the record matches the If-Match header of the request, if there is one.
+func (h *handler) precondition(c *gin.Context, version uint) error {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil
+	}
+	tag := etag(version)
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || t == tag {
+			return nil
+		}
+	}
+	return ErrPreconditionFailed
+}
+
+// respondVersioned responds with the record tagged with its version.
+func (h *handler) respondVersioned(c *gin.Context, code int, version uint, obj any) {
+	c.Header("ETag", etag(version))
+	c.JSON(code, obj)
+}
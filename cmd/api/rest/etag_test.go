@@ -0,0 +1,119 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+// versionedRecord is a record created by the test which can then be changed only by its current version.
+type versionedRecord struct {
+	UUID    string `json:"uuid"`
+	Token   string `json:"token"`
+	Version uint   `json:"version"`
+}
+
+func (ts *RESTTestSuite) testVersions() {
+	auth := ts.NewAuth()
+	acc, err := ts.accountsService.CreateAccount(context.Background(), auth.workspace, "cash")
+	ts.Require().NoError(err, "Failed to create test account")
+	target := "/accounts/" + acc.UUID.String()
+
+	ts.Run("get", func() {
+		rr := httptest.NewRecorder()
+		ts.handler.ServeHTTP(rr, NewRequest("GET", target, nil).WithAuth(auth).Request)
+		ts.Equal(http.StatusOK, rr.Code)
+		ts.Equal(`"1"`, rr.Header().Get("ETag"))
+		ts.JSONEq(`{"uuid": "`+acc.UUID.String()+`", "name": "cash", "version": 1}`, rr.Body.String())
+	})
+
+	ts.Run("update", func() {
+		rr := httptest.NewRecorder()
+		request := NewRequest("PUT", target, bytes.NewBufferString(`{"name": "wallet"}`)).WithAuth(auth).WithHeader("If-Match", `"1"`)
+		ts.handler.ServeHTTP(rr, request.Request)
+		ts.Equal(http.StatusOK, rr.Code)
+		ts.Equal(`"2"`, rr.Header().Get("ETag"))
+	})
+
+	ts.Run("update/error", func() {
+		request := NewRequest("PUT", target, bytes.NewBufferString(`{"name": "savings"}`)).WithAuth(auth).WithHeader("If-Match", `"1"`)
+		response := new(ErrorTestResponse)
+		ts.Equal(http.StatusPreconditionFailed, ts.ServeJSON(request, response))
+		ts.Equal("Precondition failed", response.Error)
+	})
+
+	for _, tt := range []struct {
+		Name    string
+		Method  string
+		IfMatch string
+		Body    io.Reader
+		Code    int
+	}{
+		{Name: "delete/stale", Method: "DELETE", IfMatch: `"1"`, Code: http.StatusPreconditionFailed},
+		{Name: "get/stale", Method: "GET", IfMatch: `"1"`, Code: http.StatusPreconditionFailed},
+		{Name: "update/any", Method: "PUT", IfMatch: `*`, Body: bytes.NewBufferString(`{"name": "savings"}`), Code: http.StatusOK},
+		{Name: "delete/list", Method: "DELETE", IfMatch: `"1", "3"`, Code: http.StatusNoContent},
+	} {
+		ts.Run(tt.Name, func() {
+			request := NewRequest(tt.Method, target, tt.Body).WithAuth(auth).WithHeader("If-Match", tt.IfMatch)
+			ts.Equal(tt.Code, ts.Serve(request))
+		})
+	}
+}
+
+func (ts *RESTTestSuite) testVersionedDeletions() {
+	owner := ts.NewAuth()
+	member := ts.NewAuth()
+	ctx := context.Background()
+	ws, err := ts.workspacesService.CreateWorkspace(ctx, owner.user, "shared")
+	ts.Require().NoError(err, "Failed to create test workspace")
+	tx, err := ts.transactionsService.CreateTransaction(ctx, owner.workspace, "2010-01", "USD", -10, "", nil)
+	ts.Require().NoError(err, "Failed to create test transaction")
+
+	create := func(request *Request) versionedRecord {
+		var r versionedRecord
+		ts.Require().Equal(http.StatusCreated, ts.ServeJSON(request, &r))
+		return r
+	}
+	att := create(NewUploadRequest("/transactions/"+tx.UUID.String()+"/attachments", "receipt.png", pngContent).WithAuth(owner))
+	token := create(NewRequest("POST", "/tokens", bytes.NewBufferString(`{"name": "cron", "scope": "read-only"}`)).WithAuth(owner))
+	inv := create(NewRequest("POST", "/workspaces/"+ws.UUID.String()+"/invitations", bytes.NewBufferString(`{"role": "viewer"}`)).WithAuth(owner))
+	joining := create(NewRequest("POST", "/workspaces/"+ws.UUID.String()+"/invitations", bytes.NewBufferString(`{"role": "viewer"}`)).WithAuth(owner))
+	_, err = ts.workspacesService.AcceptInvitation(ctx, member.user, joining.Token)
+	ts.Require().NoError(err, "Failed to join test workspace")
+	memberTarget := "/workspaces/" + ws.UUID.String() + "/members/" + member.user.ID
+
+	ts.Run("update member", func() {
+		request := NewRequest("PUT", memberTarget, bytes.NewBufferString(`{"role": "editor"}`)).WithAuth(owner).WithHeader("If-Match", `"2"`)
+		ts.Equal(http.StatusPreconditionFailed, ts.Serve(request))
+
+		rr := httptest.NewRecorder()
+		request = NewRequest("PUT", memberTarget, bytes.NewBufferString(`{"role": "editor"}`)).WithAuth(owner).WithHeader("If-Match", `"1"`)
+		ts.handler.ServeHTTP(rr, request.Request)
+		ts.Equal(http.StatusOK, rr.Code)
+		ts.Equal(`"2"`, rr.Header().Get("ETag"))
+	})
+
+	for _, tt := range []struct {
+		Name   string
+		Target string
+		Auth   Auth
+		Stale  string
+	}{
+		{Name: "attachment", Target: "/attachments/" + att.UUID, Auth: owner, Stale: `"2"`},
+		{Name: "token", Target: "/tokens/" + token.UUID, Auth: owner, Stale: `"2"`},
+		{Name: "invitation", Target: "/workspaces/" + ws.UUID.String() + "/invitations/" + inv.UUID, Auth: owner, Stale: `"2"`},
+		{Name: "member", Target: memberTarget, Auth: member, Stale: `"1"`},
+	} {
+		ts.Run("delete "+tt.Name, func() {
+			request := NewRequest("DELETE", tt.Target, nil).WithAuth(tt.Auth).WithHeader("If-Match", tt.Stale)
+			ts.Equal(http.StatusPreconditionFailed, ts.Serve(request), "Stale version must be rejected.")
+			request = NewRequest("DELETE", tt.Target, nil).WithAuth(tt.Auth).WithHeader("If-Match", "*")
+			ts.Equal(http.StatusNoContent, ts.Serve(request))
+		})
+	}
+}
@@ -1,12 +1,22 @@
 package rest
 
 import (
+	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/auth"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
 	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/d-ashesss/mah-moneh/log"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -15,34 +25,71 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type handler struct {
 	auth         *auth.Service
+	users        *users.Service
+	tokens       *tokens.Service
+	workspaces   *workspaces.Service
 	accounts     *accounts.Service
 	categories   *categories.Service
 	transactions *transactions.Service
+	labels       *labels.Service
+	attachments  *attachments.Service
+	payees       *payees.Service
 	spendings    *spendings.Service
+	recurring    *recurring.Service
+	trash        *trash.Service
+	audit        *audit.Service
+	backup       *backup.Service
+
+	importMaxSize int64
 }
 
 func NewHandler(
 	cfg *Config,
 	auth *auth.Service,
+	users *users.Service,
+	tokens *tokens.Service,
+	workspaces *workspaces.Service,
 	accounts *accounts.Service,
 	categories *categories.Service,
 	transactions *transactions.Service,
+	labels *labels.Service,
+	attachments *attachments.Service,
+	payees *payees.Service,
 	spendings *spendings.Service,
+	recurring *recurring.Service,
+	trash *trash.Service,
+	audit *audit.Service,
+	backup *backup.Service,
 ) http.Handler {
 	h := &handler{
 		auth:         auth,
+		users:        users,
+		tokens:       tokens,
+		workspaces:   workspaces,
 		accounts:     accounts,
 		categories:   categories,
 		transactions: transactions,
+		labels:       labels,
+		attachments:  attachments,
+		payees:       payees,
 		spendings:    spendings,
+		recurring:    recurring,
+		trash:        trash,
+		audit:        audit,
+		backup:       backup,
+
+		importMaxSize: cfg.ImportMaxSize,
 	}
 
 	r := gin.New()
 	r.HandleMethodNotAllowed = true
+	// Services look up the values of the request context, like the acting user, through the gin context.
+	r.ContextWithFallback = true
 	r.Use(gin.Logger(), gin.CustomRecoveryWithWriter(nil, h.handleRecovery))
 
 	corsCfg := cors.DefaultConfig()
@@ -62,29 +109,95 @@ func NewHandler(
 	}
 
 	r.GET("/", h.handleIndex)
+	r.GET("/ready", h.handleReady)
+	r.GET("/openapi.json", h.handleOpenAPI)
+
+	if h.auth.DevIssuer() != nil {
+		r.GET("/.well-known/openid-configuration", h.handleDevDiscovery)
+		r.GET("/.well-known/jwks.json", h.handleDevJWKS)
+		r.POST("/dev/tokens", h.handleDevTokensCreate)
+	}
 
 	r.Use(h.authenticate)
 	r.GET("/deep-vaults", h.handleIndex)
 
-	r.POST("/accounts", h.handleAccountsCreate)
-	r.GET("/accounts", h.handleAccountsList)
-	r.PUT("/accounts/:uuid", h.handleAccountsUpdate)
-	r.DELETE("/accounts/:uuid", h.handleAccountsDelete)
+	r.GET("/me", h.handleMeGet)
+	r.PATCH("/me", h.handleMeUpdate)
+
+	r.POST("/tokens", h.handleTokensCreate)
+	r.GET("/tokens", h.handleTokensList)
+	r.DELETE("/tokens/:uuid", h.handleTokensDelete)
+
+	r.POST("/workspaces", h.handleWorkspacesCreate)
+	r.GET("/workspaces", h.handleWorkspacesList)
+	r.PUT("/workspaces/:uuid", h.handleWorkspacesUpdate)
+	r.GET("/workspaces/:uuid/members", h.handleMembersList)
+	r.PUT("/workspaces/:uuid/members/:user_id", h.handleMembersUpdate)
+	r.DELETE("/workspaces/:uuid/members/:user_id", h.handleMembersDelete)
+	r.POST("/workspaces/:uuid/invitations", h.handleInvitationsCreate)
+	r.GET("/workspaces/:uuid/invitations", h.handleInvitationsList)
+	r.DELETE("/workspaces/:uuid/invitations/:invitation_uuid", h.handleInvitationsDelete)
+	r.POST("/invitations/accept", h.handleInvitationsAccept)
+
+	// Routes below operate on the data of the workspace selected by the request.
+	w := r.Group("", h.authorizeWorkspace)
+
+	w.POST("/accounts", h.handleAccountsCreate)
+	w.GET("/accounts", h.handleAccountsList)
+	w.GET("/accounts/:uuid", h.handleAccountsGet)
+	w.PUT("/accounts/:uuid", h.handleAccountsUpdate)
+	w.DELETE("/accounts/:uuid", h.handleAccountsDelete)
 
-	r.PUT("/accounts/:uuid/amounts", h.handleAccountAmountSet)
-	r.PUT("/accounts/:uuid/amounts/:month", h.handleAccountAmountSet)
-	r.GET("/accounts/:uuid/amounts", h.handleAccountAmountGet)
-	r.GET("/accounts/:uuid/amounts/:month", h.handleAccountAmountGet)
+	w.PUT("/accounts/:uuid/amounts", h.handleAccountAmountSet)
+	w.PUT("/accounts/:uuid/amounts/:month", h.handleAccountAmountSet)
+	w.GET("/accounts/:uuid/amounts", h.handleAccountAmountGet)
+	w.GET("/accounts/:uuid/amounts/:month", h.handleAccountAmountGet)
 
-	r.POST("/categories", h.handleCategoriesCreate)
-	r.GET("/categories", h.handleCategoriesList)
-	r.DELETE("/categories/:uuid", h.handleCategoriesDelete)
+	w.POST("/categories", h.handleCategoriesCreate)
+	w.GET("/categories", h.handleCategoriesList)
+	w.GET("/categories/:uuid", h.handleCategoriesGet)
+	w.DELETE("/categories/:uuid", h.handleCategoriesDelete)
 
-	r.POST("/transactions", h.handleTransactionsCreate)
-	r.GET("/transactions/:month", h.handleTransactionsList)
-	r.DELETE("/transactions/:uuid", h.handleTransactionsDelete)
+	w.POST("/transactions", h.handleTransactionsCreate)
+	w.GET("/transactions", h.handleTransactionsSearch)
+	w.GET("/transactions/:month", h.handleTransactionsList)
+	w.DELETE("/transactions/:uuid", h.handleTransactionsDelete)
+	w.PUT("/transactions/:uuid/labels", h.handleTransactionLabelsSet)
+	w.POST("/transactions/:uuid/attachments", h.handleAttachmentsCreate)
 
-	r.GET("/spendings/:month", h.handleSpendingsGet)
+	w.GET("/attachments", h.handleAttachmentsList)
+	w.GET("/attachments/:uuid", h.handleAttachmentsDownload)
+	w.DELETE("/attachments/:uuid", h.handleAttachmentsDelete)
+
+	w.POST("/payees", h.handlePayeesCreate)
+	w.GET("/payees", h.handlePayeesList)
+	w.GET("/payees/:uuid", h.handlePayeesGet)
+	w.PUT("/payees/:uuid", h.handlePayeesUpdate)
+	w.DELETE("/payees/:uuid", h.handlePayeesDelete)
+
+	w.POST("/labels", h.handleLabelsCreate)
+	w.GET("/labels", h.handleLabelsList)
+	w.GET("/labels/:uuid", h.handleLabelsGet)
+	w.PUT("/labels/:uuid", h.handleLabelsUpdate)
+	w.DELETE("/labels/:uuid", h.handleLabelsDelete)
+
+	w.GET("/spendings/:month", h.handleSpendingsGet)
+	w.GET("/spendings/labels", h.handleLabelSpendingsGet)
+	w.GET("/spendings/payees", h.handlePayeeSpendingsGet)
+
+	w.POST("/recurring", h.handleRecurringCreate)
+	w.GET("/recurring", h.handleRecurringList)
+	w.GET("/recurring/preview", h.handleRecurringPreview)
+	w.GET("/recurring/:uuid", h.handleRecurringGet)
+	w.DELETE("/recurring/:uuid", h.handleRecurringDelete)
+
+	w.GET("/trash", h.handleTrashList)
+	w.POST("/trash/:type/:uuid/restore", h.handleTrashRestore)
+
+	w.GET("/audit", h.handleAuditList)
+
+	w.GET("/export", h.handleExport)
+	w.POST("/import", h.handleImport)
 
 	return r
 }
@@ -93,20 +206,48 @@ func (h *handler) handleIndex(c *gin.Context) {
 	c.String(http.StatusOK, "ok")
 }
 
+// handleReady reports whether the app is able to serve authenticated requests.
+func (h *handler) handleReady(c *gin.Context) {
+	if !h.auth.Ready() {
+		c.JSON(http.StatusServiceUnavailable, NewErrorResponse("Not ready"))
+		return
+	}
+	c.String(http.StatusOK, "ok")
+}
+
 func (h *handler) authenticate(c *gin.Context) {
 	token := c.GetHeader("Authorization")
 	token, _ = strings.CutPrefix(token, "Bearer ")
 
-	user, err := h.auth.AuthenticateUser(c, token)
+	identity, err := h.auth.Authenticate(c, token)
+	if errors.Is(err, auth.ErrKeysNotLoaded) {
+		log.Warningf("[HTTP] Unable to authenticate request: %s", err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, NewErrorResponse(http.StatusText(http.StatusServiceUnavailable)))
+		return
+	}
 	if err != nil {
 		log.Warningf("[HTTP] Unauthorized request: %s", err)
 		c.AbortWithStatusJSON(http.StatusUnauthorized, NewErrorResponse(http.StatusText(http.StatusUnauthorized)))
 		return
 	}
-	c.Set("user", user)
+	if !identity.CanWrite() && c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.AbortWithStatusJSON(http.StatusForbidden, NewErrorResponse("Forbidden"))
+		return
+	}
+	c.Set("identity", identity)
+	c.Set("user", identity.User)
+	c.Request = c.Request.WithContext(users.NewContext(c.Request.Context(), identity.User))
 	c.Next()
 }
 
+func (h *handler) identity(c *gin.Context) *auth.Identity {
+	identity, ok := c.Get("identity")
+	if !ok {
+		return nil
+	}
+	return identity.(*auth.Identity)
+}
+
 func (h *handler) user(c *gin.Context) *users.User {
 	user, ok := c.Get("user")
 	if !ok {
@@ -120,6 +261,13 @@ func validateYearMonth(fl validator.FieldLevel) bool {
 	if !ok {
 		return false
 	}
+	if month == "" {
+		return true
+	}
 	rx := regexp.MustCompile("^\\d{4}-\\d{2}$")
-	return month == "" || rx.MatchString(month)
+	if !rx.MatchString(month) {
+		return false
+	}
+	_, err := time.Parse(accounts.FmtYearMonth, month)
+	return err == nil
 }
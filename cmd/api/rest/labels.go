@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+type LabelInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (i *LabelInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetLabelInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetLabelInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) label(c *gin.Context) (*labels.Label, error) {
+	var input GetLabelInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	lbl, err := h.workspaceLabel(c, input.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, lbl.Version); err != nil {
+		return nil, err
+	}
+	return lbl, nil
+}
+
+func (h *handler) workspaceLabel(c *gin.Context, labelUUID string) (*labels.Label, error) {
+	lbl, err := h.labels.GetLabel(c, uuid.FromStringOrNil(labelUUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, lbl.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	return lbl, nil
+}
+
+func (h *handler) workspaceLabels(c *gin.Context, labelUUIDs []string) (labels.LabelCollection, error) {
+	lbls := make(labels.LabelCollection, 0, len(labelUUIDs))
+	for _, labelUUID := range labelUUIDs {
+		lbl, err := h.workspaceLabel(c, labelUUID)
+		if err != nil {
+			return nil, err
+		}
+		lbls = append(lbls, lbl)
+	}
+	return lbls, nil
+}
+
+type LabelResponse struct {
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Version   uint   `json:"version"`
+	CreatedAt string `json:"created_at"`
+}
+
+func NewLabelResponse(lbl *labels.Label) *LabelResponse {
+	return &LabelResponse{
+		UUID:      lbl.UUID.String(),
+		Name:      lbl.Name,
+		Version:   lbl.Version,
+		CreatedAt: lbl.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func NewListLabelsResponse(lbls labels.LabelCollection) []*LabelResponse {
+	r := make([]*LabelResponse, 0, len(lbls))
+	for _, lbl := range lbls {
+		r = append(r, NewLabelResponse(lbl))
+	}
+	return r
+}
+
+func (h *handler) handleLabelsCreate(c *gin.Context) {
+	var input LabelInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	lbl, err := h.labels.CreateLabel(c, h.workspace(c), input.Name)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create label: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusCreated, lbl.Version, NewLabelResponse(lbl))
+}
+
+func (h *handler) handleLabelsList(c *gin.Context) {
+	lbls, err := h.labels.GetWorkspaceLabels(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace labels: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListLabelsResponse(lbls))
+}
+
+func (h *handler) handleLabelsGet(c *gin.Context) {
+	lbl, err := h.label(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find label: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, lbl.Version, NewLabelResponse(lbl))
+}
+
+func (h *handler) handleLabelsUpdate(c *gin.Context) {
+	lbl, err := h.label(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find label: %w", err))
+		return
+	}
+	var input LabelInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	lbl.Name = input.Name
+	if err := h.labels.UpdateLabel(c, lbl); err != nil {
+		h.handleError(c, fmt.Errorf("failed to update label: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, lbl.Version, NewLabelResponse(lbl))
+}
+
+func (h *handler) handleLabelsDelete(c *gin.Context) {
+	lbl, err := h.label(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find label: %w", err))
+		return
+	}
+	if err := h.labels.DeleteLabel(c, lbl); err != nil {
+		h.handleError(c, fmt.Errorf("failed to delete label: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,236 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+func (ts *RESTTestSuite) testLabelsErrors() {
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+
+	user1label, err := ts.labelsService.CreateLabel(context.Background(), auth1.workspace, "test label")
+	ts.Require().NoErrorf(err, "Failed to create test label")
+	user1transaction, err := ts.transactionsService.CreateTransaction(context.Background(), auth1.workspace, "2010-01", "USD", 100, "", nil)
+	ts.Require().NoErrorf(err, "Failed to create test transaction")
+
+	tests := []ErrorTest{
+		{
+			Name:   "create label/invalid name",
+			Method: "POST",
+			Target: "/labels",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Name'",
+		},
+		{
+			Name:   "update label/not owner",
+			Method: "PUT",
+			Target: "/labels/" + user1label.UUID.String(),
+			Auth:   auth2,
+			Body:   bytes.NewBufferString(`{"name": "renamed"}`),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "delete label/invalid id",
+			Method: "DELETE",
+			Target: "/labels/outsource",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'UUID'",
+		},
+		{
+			Name:   "delete label/id not exists",
+			Method: "DELETE",
+			Target: "/labels/" + uuid.Must(uuid.NewV4()).String(),
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "delete label/not owner",
+			Method: "DELETE",
+			Target: "/labels/" + user1label.UUID.String(),
+			Auth:   auth2,
+			Body:   nil,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "create transaction/invalid label",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{"month": "2010-01","currency": "USD","amount": 100,"labels": ["outsource"]}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Labels[0]'",
+		},
+		{
+			Name:   "create transaction/label not owner",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth2,
+			Body:   bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-01","currency": "USD","amount": 100,"labels": ["%s"]}`, user1label.UUID)),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "set transaction labels/transaction not owner",
+			Method: "PUT",
+			Target: "/transactions/" + user1transaction.UUID.String() + "/labels",
+			Auth:   auth2,
+			Body:   bytes.NewBufferString(`{"labels": []}`),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "get label spendings/invalid period",
+			Method: "GET",
+			Target: "/spendings/labels?from=2010",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'FromMonth'",
+		},
+		{
+			Name:   "get label spendings/invalid month",
+			Method: "GET",
+			Target: "/spendings/labels?from=2010-01&to=2010-13",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'ToMonth'",
+		},
+		{
+			Name:   "get label spendings/reversed period",
+			Method: "GET",
+			Target: "/spendings/labels?from=2010-04&to=2010-01",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+		{
+			Name:   "get label spendings/period too long",
+			Method: "GET",
+			Target: "/spendings/labels?from=0001-01&to=9999-12",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.testError(tt)
+	}
+}
+
+func (ts *RESTTestSuite) testCreateLabels() {
+	tests := []CreationTest{
+		{
+			Name: "trip",
+			Body: bytes.NewBufferString(`{"name": "trip"}`),
+			Ref:  &ts.labels.trip,
+		},
+		{
+			Name: "temp",
+			Body: bytes.NewBufferString(`{"name": "temp"}`),
+			Ref:  &ts.labels.temp,
+		},
+	}
+
+	for _, tt := range tests {
+		ts.testCreate(tt, "/labels")
+	}
+}
+
+func (ts *RESTTestSuite) testUpdateLabels() {
+	tt := RequestTest{
+		Name:   "rename label",
+		Method: "PUT",
+		Target: "/labels/" + ts.labels.trip.String(),
+		Body:   bytes.NewBufferString(`{"name": "vacation"}`),
+		Auth:   ts.users.main,
+		Code:   http.StatusOK,
+	}
+	ts.testRequest(tt)
+}
+
+func (ts *RESTTestSuite) testSetTransactionLabels() {
+	tt := RequestTest{
+		Name:   "set transaction labels",
+		Method: "PUT",
+		Target: "/transactions/" + ts.transactions.labeled.String() + "/labels",
+		Body:   bytes.NewBufferString(fmt.Sprintf(`{"labels": ["%s"]}`, ts.labels.trip)),
+		Auth:   ts.users.main,
+		Code:   http.StatusOK,
+	}
+	ts.testRequest(tt)
+}
+
+func (ts *RESTTestSuite) testDeleteLabels() {
+	tt := RequestTest{
+		Name:   "delete label",
+		Method: "DELETE",
+		Target: "/labels/" + ts.labels.temp.String(),
+		Body:   nil,
+		Auth:   ts.users.main,
+		Code:   http.StatusNoContent,
+	}
+	ts.testRequest(tt)
+}
+
+func (ts *RESTTestSuite) testGetLabels() {
+	tests := []CountTest{
+		{
+			Name:   "get main labels",
+			Target: "/labels",
+			Auth:   ts.users.main,
+			Count:  1,
+		},
+		{
+			Name:   "get control labels",
+			Target: "/labels",
+			Auth:   ts.users.control,
+			Count:  0,
+		},
+	}
+	for _, tt := range tests {
+		ts.testCount(tt)
+	}
+}
+
+func (ts *RESTTestSuite) testGetLabelSpendings() {
+	tests := []JSONTest{
+		{
+			Name:   "get main 2010-01..2010-04 label spendings",
+			Target: "/spendings/labels?from=2010-01&to=2010-04",
+			Auth:   ts.users.main,
+			Expected: fmt.Sprintf(`{
+				"%s":        {"USD": 800},
+				"unlabeled": {"USD": 2700, "EUR": 500}
+			}`, ts.labels.trip),
+		},
+		{
+			Name:   "get control 2010-01..2010-04 label spendings",
+			Target: "/spendings/labels?from=2010-01&to=2010-04",
+			Auth:   ts.users.control,
+			Expected: `{
+				"unlabeled": {}
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		ts.testJSON(tt)
+	}
+}
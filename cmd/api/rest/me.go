@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type UpdateProfileInput struct {
+	Name     *string             `json:"name"`
+	Settings UpdateSettingsInput `json:"settings"`
+}
+
+type UpdateSettingsInput struct {
+	DefaultCurrency *string `json:"default_currency" binding:"omitempty,iso4217"`
+	Locale          *string `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	FiscalYearStart *string `json:"fiscal_year_start" binding:"omitempty,datetime=01-02"`
+}
+
+func (i *UpdateProfileInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+// Apply updates the profile with the provided values.
+func (i *UpdateProfileInput) Apply(p *users.Profile) {
+	if i.Name != nil {
+		p.Name = *i.Name
+	}
+	if i.Settings.DefaultCurrency != nil {
+		p.Settings.DefaultCurrency = *i.Settings.DefaultCurrency
+	}
+	if i.Settings.Locale != nil {
+		p.Settings.Locale = *i.Settings.Locale
+	}
+	if i.Settings.FiscalYearStart != nil {
+		p.Settings.FiscalYearStart = *i.Settings.FiscalYearStart
+	}
+}
+
+type ProfileResponse struct {
+	ID        string            `json:"id"`
+	Issuer    string            `json:"issuer"`
+	Email     string            `json:"email"`
+	Name      string            `json:"name"`
+	Settings  *SettingsResponse `json:"settings"`
+	CreatedAt string            `json:"created_at"`
+}
+
+type SettingsResponse struct {
+	DefaultCurrency string `json:"default_currency"`
+	Locale          string `json:"locale"`
+	FiscalYearStart string `json:"fiscal_year_start"`
+}
+
+func NewProfileResponse(p *users.Profile) *ProfileResponse {
+	return &ProfileResponse{
+		ID:     p.ID,
+		Issuer: p.Issuer,
+		Email:  p.Email,
+		Name:   p.Name,
+		Settings: &SettingsResponse{
+			DefaultCurrency: p.Settings.DefaultCurrency,
+			Locale:          p.Settings.Locale,
+			FiscalYearStart: p.Settings.FiscalYearStart,
+		},
+		CreatedAt: p.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func (h *handler) handleMeGet(c *gin.Context) {
+	p, err := h.users.GetProfile(c, h.user(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get user profile: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewProfileResponse(p))
+}
+
+func (h *handler) handleMeUpdate(c *gin.Context) {
+	var input UpdateProfileInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	p, err := h.users.GetProfile(c, h.user(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get user profile: %w", err))
+		return
+	}
+	input.Apply(p)
+	if err := h.users.UpdateProfile(c, p); err != nil {
+		h.handleError(c, fmt.Errorf("failed to update user profile: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewProfileResponse(p))
+}
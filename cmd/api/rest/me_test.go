@@ -0,0 +1,85 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"net/http"
+)
+
+func (ts *RESTTestSuite) testMe() {
+	auth := ts.NewAuth()
+
+	ts.Run("Get", func() {
+		response := ts.getProfile(auth)
+		ts.Equal(auth.user.ID, response.ID)
+		ts.Equal("USD", response.Settings.DefaultCurrency)
+		ts.Equal("en", response.Settings.Locale)
+		ts.Equal("01-01", response.Settings.FiscalYearStart)
+	})
+
+	tests := []ErrorTest{
+		{
+			Name:   "update profile/invalid currency",
+			Method: "PATCH",
+			Target: "/me",
+			Auth:   auth,
+			Body:   bytes.NewBufferString(`{"settings": {"default_currency": "dollar"}}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'DefaultCurrency'",
+		},
+		{
+			Name:   "update profile/invalid locale",
+			Method: "PATCH",
+			Target: "/me",
+			Auth:   auth,
+			Body:   bytes.NewBufferString(`{"settings": {"locale": "not a locale"}}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Locale'",
+		},
+		{
+			Name:   "update profile/invalid fiscal year start",
+			Method: "PATCH",
+			Target: "/me",
+			Auth:   auth,
+			Body:   bytes.NewBufferString(`{"settings": {"fiscal_year_start": "13-01"}}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'FiscalYearStart'",
+		},
+	}
+	for _, tt := range tests {
+		ts.testError(tt)
+	}
+
+	ts.Run("Update", func() {
+		request := NewRequest("PATCH", "/me", bytes.NewBufferString(`{"name": "Scrooge", "settings": {"default_currency": "EUR", "fiscal_year_start": "04-01"}}`)).WithAuth(auth)
+		code := ts.Serve(request)
+		ts.Equal(http.StatusOK, code)
+	})
+
+	ts.Run("Get updated", func() {
+		response := ts.getProfile(auth)
+		ts.Equal("Scrooge", response.Name)
+		ts.Equal("EUR", response.Settings.DefaultCurrency)
+		ts.Equal("en", response.Settings.Locale)
+		ts.Equal("04-01", response.Settings.FiscalYearStart)
+	})
+}
+
+type ProfileTestResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Settings struct {
+		DefaultCurrency string `json:"default_currency"`
+		Locale          string `json:"locale"`
+		FiscalYearStart string `json:"fiscal_year_start"`
+	} `json:"settings"`
+}
+
+func (ts *RESTTestSuite) getProfile(auth Auth) *ProfileTestResponse {
+	request := NewRequest("GET", "/me", nil).WithAuth(auth)
+	response := new(ProfileTestResponse)
+	code := ts.ServeJSON(request, response)
+	ts.Require().Equal(http.StatusOK, code)
+	return response
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+	"net/http"
+)
+
+// openapiYAML is the OpenAPI document of the API, it is kept in YAML to be readable in the repository.
+// The document is only served to the clients, requests are validated by the handlers and not against it.
+//
+//go:embed openapi.yml
+var openapiYAML []byte
+
+// openapiJSON is the OpenAPI document converted to JSON once on start.
+var openapiJSON = mustConvertOpenAPI(openapiYAML)
+
+// mustConvertOpenAPI converts the OpenAPI document from YAML to JSON, panicking if the embedded document is invalid.
+func mustConvertOpenAPI(doc []byte) []byte {
+	var v any
+	if err := yaml.Unmarshal(doc, &v); err != nil {
+		panic("invalid OpenAPI document: " + err.Error())
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("invalid OpenAPI document: " + err.Error())
+	}
+	return b
+}
+
+func (h *handler) handleOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openapiJSON)
+}
@@ -0,0 +1,90 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// devModeRoutes are only registered in the development mode of the authentication, which is off in the tests.
+var devModeRoutes = map[string]bool{
+	"GET /.well-known/openid-configuration": true,
+	"GET /.well-known/jwks.json":            true,
+	"POST /dev/tokens":                      true,
+}
+
+var routeParam = regexp.MustCompile(`:([^/]+)`)
+
+func (ts *RESTTestSuite) testOpenAPI() {
+	doc := make(map[string]any)
+	code := ts.ServeJSON(NewRequest("GET", "/openapi.json", nil), &doc)
+	ts.Require().Equal(http.StatusOK, code)
+	ts.Equal("3.1.0", doc["openapi"])
+
+	documented := make(map[string]bool)
+	paths, _ := doc["paths"].(map[string]any)
+	for path, operations := range paths {
+		for method := range operations.(map[string]any) {
+			if method == "parameters" {
+				continue
+			}
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	routed := make(map[string]bool)
+	for _, route := range ts.handler.(*gin.Engine).Routes() {
+		routed[route.Method+" "+routeParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	ts.Run("documented routes", func() {
+		for route := range routed {
+			ts.Truef(documented[route], "Route %s is not documented", route)
+		}
+	})
+	ts.Run("routed documentation", func() {
+		for route := range documented {
+			ts.Truef(routed[route] || devModeRoutes[route], "Documented route %s is not registered", route)
+		}
+	})
+	ts.Run("references", func() {
+		for _, ref := range openAPIRefs(doc) {
+			ts.NotNilf(openAPIResolve(doc, ref), "Reference %s is not defined", ref)
+		}
+	})
+}
+
+// openAPIRefs collects the references of the document node.
+func openAPIRefs(node any) []string {
+	var refs []string
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, openAPIRefs(child)...)
+		}
+	case []any:
+		for _, child := range v {
+			refs = append(refs, openAPIRefs(child)...)
+		}
+	}
+	return refs
+}
+
+// openAPIResolve finds the node of the document the local reference points at.
+func openAPIResolve(doc map[string]any, ref string) any {
+	var node any = doc
+	for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = m[key]
+	}
+	return node
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// PageInput selects a page of a list.
+// Lists that predate pagination respond with all the items at once when neither limit nor cursor is specified.
+type PageInput struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+	Cursor string `form:"cursor"`
+}
+
+func (i *PageInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+// Paginated reports whether a page of the list was requested.
+func (i *PageInput) Paginated() bool {
+	return i.Limit != 0 || i.Cursor != ""
+}
+
+func (i *PageInput) PageRequest() *datastore.PageRequest {
+	return &datastore.PageRequest{Limit: i.Limit, Cursor: i.Cursor}
+}
+
+type PageResponse struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// respondPage responds with a page of items, providing the link to the next page in the Link header.
+func (h *handler) respondPage(c *gin.Context, items any, next string) {
+	if next != "" {
+		u := *c.Request.URL
+		q := u.Query()
+		q.Set("cursor", next)
+		u.RawQuery = q.Encode()
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI()))
+	}
+	c.JSON(http.StatusOK, PageResponse{Items: items, NextCursor: next})
+}
@@ -0,0 +1,95 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+)
+
+var linkNextRegexp = regexp.MustCompile(`^<(.+)>; rel="next"$`)
+
+func (ts *RESTTestSuite) testPagination() {
+	ctx := context.Background()
+	auth := ts.NewAuth()
+	for i := 0; i < 5; i++ {
+		_, err := ts.accountsService.CreateAccount(ctx, auth.workspace, fmt.Sprintf("account %d", i))
+		ts.Require().NoError(err, "Failed to create test account")
+		_, err = ts.categoriesService.CreateCategory(ctx, auth.workspace, fmt.Sprintf("category %d", i))
+		ts.Require().NoError(err, "Failed to create test category")
+		_, err = ts.transactionsService.CreateTransaction(ctx, auth.workspace, "2010-10", "USD", float64(-i), "lunch", nil)
+		ts.Require().NoError(err, "Failed to create test transaction")
+	}
+
+	for _, target := range []string{"/accounts", "/categories", "/transactions/2010-10"} {
+		ts.Run(target, func() {
+			all := make([]map[string]any, 0)
+			code := ts.ServeJSON(NewRequest("GET", target, nil).WithAuth(auth), &all)
+			ts.Require().Equal(http.StatusOK, code)
+			ts.Require().Len(all, 5)
+
+			paged := make([]map[string]any, 0)
+			next := target + "?limit=2"
+			for pages := 1; ; pages++ {
+				rr := httptest.NewRecorder()
+				ts.handler.ServeHTTP(rr, NewRequest("GET", next, nil).WithAuth(auth).Request)
+				ts.Require().Equal(http.StatusOK, rr.Code)
+				page := new(PageCountTestResponse)
+				ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), page), "Failed to decode the page")
+				paged = append(paged, page.Items...)
+
+				link := rr.Header().Get("Link")
+				if page.NextCursor == "" {
+					ts.Empty(link)
+					ts.Equal(3, pages)
+					break
+				}
+				m := linkNextRegexp.FindStringSubmatch(link)
+				ts.Require().Len(m, 2, "Invalid Link header %q", link)
+				next = m[1]
+			}
+			ts.ElementsMatch(uuids(all), uuids(paged))
+		})
+	}
+
+	for _, tt := range []ErrorTest{
+		{
+			Name:   "limit too small",
+			Method: "GET",
+			Target: "/accounts?limit=-1",
+			Auth:   auth,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Limit'",
+		},
+		{
+			Name:   "limit too big",
+			Method: "GET",
+			Target: "/categories?limit=501",
+			Auth:   auth,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Limit'",
+		},
+		{
+			Name:   "invalid cursor",
+			Method: "GET",
+			Target: "/transactions/2010-10?cursor=invalid",
+			Auth:   auth,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+	} {
+		ts.testError(tt)
+	}
+}
+
+func uuids(items []map[string]any) []any {
+	UUIDs := make([]any, len(items))
+	for i, item := range items {
+		UUIDs[i] = item["uuid"]
+	}
+	return UUIDs
+}
@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+type PayeeInput struct {
+	Name    string   `json:"name" binding:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+func (i *PayeeInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetPayeeInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetPayeeInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) payee(c *gin.Context) (*payees.Payee, error) {
+	var input GetPayeeInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	p, err := h.workspacePayee(c, input.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, p.Version); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (h *handler) workspacePayee(c *gin.Context, payeeUUID string) (*payees.Payee, error) {
+	p, err := h.payees.GetPayee(c, uuid.FromStringOrNil(payeeUUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, p.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type PayeeResponse struct {
+	UUID      string   `json:"uuid"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	Version   uint     `json:"version"`
+	CreatedAt string   `json:"created_at"`
+}
+
+func NewPayeeResponse(p *payees.Payee) *PayeeResponse {
+	return &PayeeResponse{
+		UUID:      p.UUID.String(),
+		Name:      p.Name,
+		Aliases:   p.Aliases.Names(),
+		Version:   p.Version,
+		CreatedAt: p.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func NewListPayeesResponse(ps payees.PayeeCollection) []*PayeeResponse {
+	r := make([]*PayeeResponse, 0, len(ps))
+	for _, p := range ps {
+		r = append(r, NewPayeeResponse(p))
+	}
+	return r
+}
+
+func (h *handler) handlePayeesCreate(c *gin.Context) {
+	var input PayeeInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	p, err := h.payees.CreatePayee(c, h.workspace(c), input.Name, input.Aliases)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create payee: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusCreated, p.Version, NewPayeeResponse(p))
+}
+
+func (h *handler) handlePayeesList(c *gin.Context) {
+	ps, err := h.payees.GetWorkspacePayees(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace payees: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListPayeesResponse(ps))
+}
+
+func (h *handler) handlePayeesGet(c *gin.Context) {
+	p, err := h.payee(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find payee: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, p.Version, NewPayeeResponse(p))
+}
+
+func (h *handler) handlePayeesUpdate(c *gin.Context) {
+	p, err := h.payee(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find payee: %w", err))
+		return
+	}
+	var input PayeeInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	p.Name = input.Name
+	p.SetAliases(input.Aliases)
+	if err := h.payees.UpdatePayee(c, p); err != nil {
+		h.handleError(c, fmt.Errorf("failed to update payee: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, p.Version, NewPayeeResponse(p))
+}
+
+func (h *handler) handlePayeesDelete(c *gin.Context) {
+	p, err := h.payee(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find payee: %w", err))
+		return
+	}
+	if err := h.payees.DeletePayee(c, p); err != nil {
+		h.handleError(c, fmt.Errorf("failed to delete payee: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,170 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+func (ts *RESTTestSuite) testPayeesErrors() {
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+
+	user1payee, err := ts.payeesService.CreatePayee(context.Background(), auth1.workspace, "Amazon", []string{"AMZN"})
+	ts.Require().NoErrorf(err, "Failed to create test payee")
+
+	tests := []ErrorTest{
+		{
+			Name:   "create payee/invalid name",
+			Method: "POST",
+			Target: "/payees",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{"aliases": ["AMZN"]}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Name'",
+		},
+		{
+			Name:   "update payee/not owner",
+			Method: "PUT",
+			Target: "/payees/" + user1payee.UUID.String(),
+			Auth:   auth2,
+			Body:   bytes.NewBufferString(`{"name": "Amazon.com"}`),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "delete payee/invalid id",
+			Method: "DELETE",
+			Target: "/payees/outsource",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'UUID'",
+		},
+		{
+			Name:   "delete payee/id not exists",
+			Method: "DELETE",
+			Target: "/payees/" + uuid.Must(uuid.NewV4()).String(),
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "delete payee/not owner",
+			Method: "DELETE",
+			Target: "/payees/" + user1payee.UUID.String(),
+			Auth:   auth2,
+			Body:   nil,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "create transaction/payee not owner",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth2,
+			Body:   bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-01","currency": "USD","amount": -10,"payee_uuid": "%s"}`, user1payee.UUID)),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "get payee spendings/missing period",
+			Method: "GET",
+			Target: "/spendings/payees?from=2010-01",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'ToMonth'",
+		},
+		{
+			Name:   "get payee spendings/period too long",
+			Method: "GET",
+			Target: "/spendings/payees?from=2000-01&to=2010-01",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+	}
+
+	for _, tt := range tests {
+		ts.testError(tt)
+	}
+}
+
+func (ts *RESTTestSuite) testPayees() {
+	auth := ts.NewAuth()
+
+	var amazon, shop, temp CreationTestResponse
+	for _, tt := range []struct {
+		Name string
+		Body string
+		Ref  *CreationTestResponse
+	}{
+		{Name: "create amazon", Body: `{"name": "Amazon", "aliases": ["AMZN", "AMAZON MKTPLACE"]}`, Ref: &amazon},
+		{Name: "create shop", Body: `{"name": "Corner shop"}`, Ref: &shop},
+		{Name: "create temp", Body: `{"name": "Temp"}`, Ref: &temp},
+	} {
+		ts.Run(tt.Name, func() {
+			code := ts.ServeJSON(NewRequest("POST", "/payees", bytes.NewBufferString(tt.Body)).WithAuth(auth), tt.Ref)
+			ts.Equal(http.StatusCreated, code)
+			ts.Require().NotEmpty(tt.Ref.UUID)
+		})
+	}
+
+	ts.testRequest(RequestTest{
+		Name:   "update shop",
+		Method: "PUT",
+		Target: "/payees/" + shop.UUID,
+		Body:   bytes.NewBufferString(`{"name": "Corner shop", "aliases": ["CORNER SHOP LTD"]}`),
+		Auth:   auth,
+		Code:   http.StatusOK,
+	})
+	ts.testRequest(RequestTest{
+		Name:   "delete temp",
+		Method: "DELETE",
+		Target: "/payees/" + temp.UUID,
+		Auth:   auth,
+		Code:   http.StatusNoContent,
+	})
+	ts.testCount(CountTest{Name: "list", Target: "/payees", Auth: auth, Count: 2})
+
+	for _, tt := range []struct {
+		Name  string
+		Body  string
+		Payee string
+	}{
+		{Name: "resolved by alias", Body: `{"month": "2010-01","currency": "USD","amount": -30,"description": "AMAZON MKTPLACE PMTS"}`, Payee: amazon.UUID},
+		{Name: "resolved by name", Body: `{"month": "2010-02","currency": "USD","amount": -20,"description": "amazon prime"}`, Payee: amazon.UUID},
+		{Name: "resolved by updated alias", Body: `{"month": "2010-02","currency": "USD","amount": -5,"description": "CORNER SHOP LTD"}`, Payee: shop.UUID},
+		{Name: "explicit payee", Body: fmt.Sprintf(`{"month": "2010-02","currency": "USD","amount": -7,"description": "AMZN","payee_uuid": "%s"}`, shop.UUID), Payee: shop.UUID},
+		{Name: "not resolved", Body: `{"month": "2010-02","currency": "USD","amount": -1,"description": "parking"}`, Payee: ""},
+	} {
+		ts.Run("create transaction/"+tt.Name, func() {
+			response := new(struct {
+				Payee string `json:"payee_uuid"`
+			})
+			code := ts.ServeJSON(NewRequest("POST", "/transactions", bytes.NewBufferString(tt.Body)).WithAuth(auth), response)
+			ts.Equal(http.StatusCreated, code)
+			ts.Equal(tt.Payee, response.Payee)
+		})
+	}
+
+	ts.testPageCount(PageCountTest{Name: "search by payee", Target: "/transactions?payee=" + amazon.UUID, Auth: auth, Count: 2})
+
+	ts.testJSON(JSONTest{
+		Name:   "payee spendings",
+		Target: "/spendings/payees?from=2010-01&to=2010-02",
+		Auth:   auth,
+		Expected: fmt.Sprintf(`{
+			"%s":      {"USD": -50},
+			"%s":      {"USD": -12},
+			"unknown": {"USD": -1}
+		}`, amazon.UUID, shop.UUID),
+	})
+}
@@ -0,0 +1,199 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+type CreateRecurringInput struct {
+	Cadence      recurring.Cadence `json:"cadence" binding:"required,oneof=monthly quarterly yearly"`
+	StartMonth   string            `json:"start_month" binding:"required,yearmonth"`
+	EndMonth     string            `json:"end_month" binding:"yearmonth"`
+	Currency     accounts.Currency `json:"currency" binding:"required"`
+	Amount       float64           `json:"amount" binding:"required"`
+	Description  string            `json:"description"`
+	CategoryUUID *string           `json:"category_uuid"`
+	AccountUUID  *string           `json:"account_uuid"`
+}
+
+func (i *CreateRecurringInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetRecurringInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetRecurringInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+type PreviewRecurringInput struct {
+	Month string `form:"month" binding:"yearmonth"`
+}
+
+func (i *PreviewRecurringInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+func (h *handler) recurringTemplate(c *gin.Context) (*recurring.Template, error) {
+	var input GetRecurringInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	tpl, err := h.recurring.GetTemplate(c, uuid.FromStringOrNil(input.UUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, tpl.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, tpl.Version); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+type RecurringResponse struct {
+	UUID        string            `json:"uuid"`
+	Cadence     recurring.Cadence `json:"cadence"`
+	StartMonth  string            `json:"start_month"`
+	EndMonth    string            `json:"end_month"`
+	Currency    accounts.Currency `json:"currency"`
+	Amount      float64           `json:"amount"`
+	Description string            `json:"description"`
+	Category    string            `json:"category_uuid"`
+	Account     string            `json:"account_uuid"`
+	Version     uint              `json:"version"`
+}
+
+func NewRecurringResponse(tpl *recurring.Template) *RecurringResponse {
+	cat := ""
+	if tpl.Category != nil {
+		cat = tpl.Category.UUID.String()
+	}
+	acc := ""
+	if tpl.Account != nil {
+		acc = tpl.Account.UUID.String()
+	}
+	return &RecurringResponse{
+		UUID:        tpl.UUID.String(),
+		Cadence:     tpl.Cadence,
+		StartMonth:  tpl.StartMonth,
+		EndMonth:    tpl.EndMonth,
+		Currency:    tpl.Currency,
+		Amount:      tpl.Amount,
+		Description: tpl.Description,
+		Category:    cat,
+		Account:     acc,
+		Version:     tpl.Version,
+	}
+}
+
+func NewListRecurringResponse(tpls []*recurring.Template) []*RecurringResponse {
+	r := make([]*RecurringResponse, 0, len(tpls))
+	for _, tpl := range tpls {
+		r = append(r, NewRecurringResponse(tpl))
+	}
+	return r
+}
+
+type RecurringPreviewResponse struct {
+	Template string `json:"recurring_uuid"`
+	*TransactionResponse
+}
+
+func NewRecurringPreviewResponse(occs recurring.OccurrenceCollection) []*RecurringPreviewResponse {
+	r := make([]*RecurringPreviewResponse, 0, len(occs))
+	for _, occ := range occs {
+		tx := NewTransactionResponse(occ.Transaction())
+		tx.UUID = ""
+		r = append(r, &RecurringPreviewResponse{
+			Template:            occ.TemplateUUID.String(),
+			TransactionResponse: tx,
+		})
+	}
+	return r
+}
+
+func (h *handler) handleRecurringCreate(c *gin.Context) {
+	var input CreateRecurringInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	cat, err := h.transactionCategory(c, input.CategoryUUID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	acc, err := h.transactionAccount(c, input.AccountUUID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	tpl, err := h.recurring.CreateTemplate(c, h.workspace(c), input.Cadence, input.StartMonth, input.EndMonth, input.Currency, input.Amount, input.Description, cat, acc)
+	if errors.Is(err, recurring.ErrInvalidPeriod) || errors.Is(err, recurring.ErrInvalidCadence) {
+		h.handleError(c, NewErrBadRequest(err))
+		return
+	}
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create recurring transaction: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusCreated, tpl.Version, NewRecurringResponse(tpl))
+}
+
+func (h *handler) handleRecurringList(c *gin.Context) {
+	tpls, err := h.recurring.GetWorkspaceTemplates(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace recurring transactions: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListRecurringResponse(tpls))
+}
+
+func (h *handler) handleRecurringGet(c *gin.Context) {
+	tpl, err := h.recurringTemplate(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find recurring transaction: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, tpl.Version, NewRecurringResponse(tpl))
+}
+
+func (h *handler) handleRecurringDelete(c *gin.Context) {
+	tpl, err := h.recurringTemplate(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find recurring transaction: %w", err))
+		return
+	}
+	if err := h.recurring.DeleteTemplate(c, tpl); err != nil {
+		h.handleError(c, fmt.Errorf("failed to delete recurring transaction: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *handler) handleRecurringPreview(c *gin.Context) {
+	var input PreviewRecurringInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if input.Month == "" {
+		input.Month = time.Now().Format(accounts.FmtYearMonth)
+	}
+	occs, err := h.recurring.Preview(c, h.workspace(c), input.Month)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to preview recurring transactions: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewRecurringPreviewResponse(occs))
+}
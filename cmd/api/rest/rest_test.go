@@ -3,18 +3,30 @@
 package rest_test
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
 	"github.com/d-ashesss/mah-moneh/cmd/api/rest"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/auth"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
 	"github.com/d-ashesss/mah-moneh/internal/capital"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
 	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -38,6 +50,10 @@ type RESTTestSuite struct {
 	accountsService     *accounts.Service
 	categoriesService   *categories.Service
 	transactionsService *transactions.Service
+	labelsService       *labels.Service
+	payeesService       *payees.Service
+	attachmentsService  *attachments.Service
+	workspacesService   *workspaces.Service
 
 	handler http.Handler
 
@@ -55,9 +71,14 @@ type RESTTestSuite struct {
 		groceries uuid.UUID
 		temp      uuid.UUID
 	}
-	transactions struct {
+	labels struct {
+		trip uuid.UUID
 		temp uuid.UUID
 	}
+	transactions struct {
+		temp    uuid.UUID
+		labeled uuid.UUID
+	}
 }
 
 func (ts *RESTTestSuite) SetupSuite() {
@@ -102,37 +123,89 @@ func (ts *RESTTestSuite) SetupSuite() {
 	}
 
 	authCfg := auth.NewConfig()
-	usersService := users.NewService()
-	authService := auth.NewService(authCfg, usersService)
+	usersStore := users.NewGormStore(db)
+	usersService := users.NewService(usersStore)
+	tokensStore := tokens.NewGormStore(db)
+	tokensService := tokens.NewService(tokensStore)
+	authService := auth.NewService(authCfg, usersService, tokensService)
+	workspacesCfg := workspaces.NewConfig()
+	workspacesStore := workspaces.NewGormStore(db)
+	ts.workspacesService = workspaces.NewService(workspacesCfg, workspacesStore)
 	if err := authService.AddKey(pubKey); err != nil {
 		log.Fatalf("Failed to add test public key: %s", err)
 	}
+	txManager := datastore.NewGormTxManager(db)
+	auditStore := audit.NewGormStore(db)
+	auditService := audit.NewService(auditStore)
 	accountsStore := accounts.NewGormStore(db)
-	ts.accountsService = accounts.NewService(accountsStore)
+	ts.accountsService = accounts.NewService(accountsStore, txManager, auditService)
 	categoriesStore := categories.NewGormStore(db)
-	ts.categoriesService = categories.NewService(categoriesStore)
+	ts.categoriesService = categories.NewService(categoriesStore, txManager, auditService)
+	payeesStore := payees.NewGormStore(db)
+	ts.payeesService = payees.NewService(payeesStore)
 	transactionsStore := transactions.NewGormStore(db)
-	ts.transactionsService = transactions.NewService(transactionsStore)
+	ts.transactionsService = transactions.NewService(transactionsStore, txManager, ts.payeesService, auditService)
+	labelsStore := labels.NewGormStore(db)
+	ts.labelsService = labels.NewService(labelsStore)
+	attachmentsCfg := &attachments.Config{MaxSize: 1024, AllowedTypes: []string{"image/png"}}
+	attachmentsStore := attachments.NewGormStore(db)
+	blobStorage := blobs.NewLocalStorage(ts.T().TempDir())
+	ts.attachmentsService = attachments.NewService(attachmentsCfg, attachmentsStore, blobStorage)
 	capitalService := capital.NewService(ts.accountsService)
-	spendingsService := spendings.NewService(capitalService, ts.transactionsService, ts.categoriesService)
+	spendingsService := spendings.NewService(capitalService, ts.transactionsService, ts.categoriesService, ts.labelsService, ts.payeesService)
+	recurringStore := recurring.NewGormStore(db)
+	recurringService := recurring.NewService(recurringStore, txManager, ts.transactionsService)
+	trashStore := trash.NewGormStore(db)
+	trashService := trash.NewService(trashStore, txManager, auditService, blobStorage)
+	currenciesStore := currencies.NewGormStore(db)
+	currenciesService := currencies.NewService(currenciesStore, txManager, auditService)
+	backupService := backup.NewService(txManager, usersService, ts.accountsService, ts.categoriesService, ts.labelsService, ts.payeesService, ts.transactionsService, currenciesService)
 
 	if err := db.AutoMigrate(
+		&users.Profile{},
+		&tokens.Token{},
+		&workspaces.Workspace{},
+		&workspaces.Member{},
+		&workspaces.Invitation{},
 		&accounts.Account{},
 		&accounts.Amount{},
 		&categories.Category{},
+		&labels.Label{},
+		&payees.Payee{},
+		&payees.Alias{},
 		&transactions.Transaction{},
+		&transactions.Split{},
+		&attachments.Attachment{},
+		&recurring.Template{},
+		&recurring.Occurrence{},
+		&audit.Event{},
+		&currencies.Rate{},
 	); err != nil {
 		log.Fatalf("Failed to run DB migration: %s", err)
 	}
+	if err := transactions.MigrateSearchIndex(db); err != nil {
+		log.Fatalf("Failed to create transactions search index: %s", err)
+	}
 
 	handlerCfg := rest.NewConfig()
+	handlerCfg.ImportMaxSize = 16384
 	ts.handler = rest.NewHandler(
 		handlerCfg,
 		authService,
+		usersService,
+		tokensService,
+		ts.workspacesService,
 		ts.accountsService,
 		ts.categoriesService,
 		ts.transactionsService,
+		ts.labelsService,
+		ts.attachmentsService,
+		ts.payeesService,
 		spendingsService,
+		recurringService,
+		trashService,
+		auditService,
+		backupService,
 	)
 
 	ts.users.main = ts.NewAuth()
@@ -140,9 +213,10 @@ func (ts *RESTTestSuite) SetupSuite() {
 }
 
 type Auth struct {
-	UUID  uuid.UUID
-	user  *users.User
-	token string
+	UUID      uuid.UUID
+	user      *users.User
+	workspace *workspaces.Workspace
+	token     string
 }
 
 func (ts *RESTTestSuite) NewAuth() Auth {
@@ -155,10 +229,17 @@ func (ts *RESTTestSuite) NewAuth() Auth {
 	if err != nil {
 		panic(err)
 	}
+	claims := &users.Claims{Subject: UUID.String()}
+	u := &users.User{ID: claims.UserID()}
+	ws, err := ts.workspacesService.GetPersonalWorkspace(context.Background(), u)
+	if err != nil {
+		panic(err)
+	}
 	return Auth{
-		UUID:  UUID,
-		user:  &users.User{ID: UUID.String()},
-		token: string(token),
+		UUID:      UUID,
+		user:      u,
+		workspace: ws,
+		token:     string(token),
 	}
 }
 
@@ -181,6 +262,16 @@ func (r *Request) WithAuth(a Auth) *Request {
 	return r
 }
 
+func (r *Request) WithWorkspace(UUID uuid.UUID) *Request {
+	r.Header.Add(rest.WorkspaceHeader, UUID.String())
+	return r
+}
+
+func (r *Request) WithHeader(key, value string) *Request {
+	r.Header.Set(key, value)
+	return r
+}
+
 func (ts *RESTTestSuite) Serve(request *Request) int {
 	rr := httptest.NewRecorder()
 	ts.handler.ServeHTTP(rr, request.Request)
@@ -242,6 +333,19 @@ type CountTest struct {
 	Count  int
 }
 
+type PageCountTest struct {
+	Name   string
+	Target string
+	Auth   Auth
+	Count  int
+	Next   bool
+}
+
+type PageCountTestResponse struct {
+	Items      []map[string]any `json:"items"`
+	NextCursor string           `json:"next_cursor"`
+}
+
 type JSONTest struct {
 	Name     string
 	Target   string
@@ -294,6 +398,18 @@ func (ts *RESTTestSuite) testCount(tt CountTest) {
 	})
 }
 
+func (ts *RESTTestSuite) testPageCount(tt PageCountTest) {
+	ts.Run(tt.Name, func() {
+		request := NewRequest("GET", tt.Target, nil).WithAuth(tt.Auth)
+		response := new(PageCountTestResponse)
+		code := ts.ServeJSON(request, response)
+
+		ts.Equal(http.StatusOK, code)
+		ts.Len(response.Items, tt.Count)
+		ts.Equal(tt.Next, response.NextCursor != "")
+	})
+}
+
 func (ts *RESTTestSuite) testJSON(tt JSONTest) {
 	ts.Run(tt.Name, func() {
 		request := NewRequest("GET", tt.Target, nil).WithAuth(tt.Auth)
@@ -306,23 +422,33 @@ func (ts *RESTTestSuite) testJSON(tt JSONTest) {
 
 func (ts *RESTTestSuite) TestREST() {
 	ts.Run("Index", ts.testIndex)
+	ts.Run("Ready", ts.testReady)
 	ts.Run("Authorization", ts.testAuthorization)
 
 	ts.Run("Errors", func() {
 		ts.Run("Accounts", ts.testAccountsErrors)
 		ts.Run("Categories", ts.testCategoriesErrors)
+		ts.Run("Labels", ts.testLabelsErrors)
+		ts.Run("Payees", ts.testPayeesErrors)
 		ts.Run("Transactions", ts.testTransactions)
 	})
 
 	ts.Run("Create", func() {
 		ts.Run("Accounts", ts.testCreateAccounts)
 		ts.Run("Categories", ts.testCreateCategories)
+		ts.Run("Labels", ts.testCreateLabels)
 		ts.Run("Transactions", ts.testCreateTransactions)
 	})
 
+	ts.Run("Update", func() {
+		ts.Run("Labels", ts.testUpdateLabels)
+		ts.Run("TransactionLabels", ts.testSetTransactionLabels)
+	})
+
 	ts.Run("Delete", func() {
 		ts.Run("Accounts", ts.testDeleteAccounts)
 		ts.Run("Categories", ts.testDeleteCategories)
+		ts.Run("Labels", ts.testDeleteLabels)
 		ts.Run("Transactions", ts.testDeleteTransactions)
 	})
 
@@ -330,8 +456,32 @@ func (ts *RESTTestSuite) TestREST() {
 		ts.Run("Accounts", ts.testGetAccounts)
 		ts.Run("Categories", ts.testGetCategories)
 		ts.Run("Transactions", ts.testGetTransactions)
+		ts.Run("TransactionsSearch", ts.testSearchTransactions)
+		ts.Run("Labels", ts.testGetLabels)
 		ts.Run("Spendings", ts.testGetSpendings)
+		ts.Run("LabelSpendings", ts.testGetLabelSpendings)
 	})
+
+	ts.Run("Attachments", ts.testAttachments)
+	ts.Run("Payees", ts.testPayees)
+	ts.Run("Me", ts.testMe)
+	ts.Run("Workspaces", ts.testWorkspaces)
+	ts.Run("Tokens", ts.testTokens)
+	ts.Run("Trash", ts.testTrash)
+	ts.Run("Audit", ts.testAudit)
+	ts.Run("Versions", ts.testVersions)
+	ts.Run("VersionedDeletions", ts.testVersionedDeletions)
+	ts.Run("Backup", ts.testBackup)
+	ts.Run("OpenAPI", ts.testOpenAPI)
+	ts.Run("Pagination", ts.testPagination)
+}
+
+func (ts *RESTTestSuite) testReady() {
+	request := NewRequest("GET", "/ready", nil)
+	code, response := ts.ServeString(request)
+
+	ts.Equal(http.StatusOK, code)
+	ts.Equal("ok", response)
 }
 
 func (ts *RESTTestSuite) testIndex() {
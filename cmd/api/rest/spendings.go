@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type GetSpendingsInput struct {
@@ -17,6 +20,29 @@ func (i *GetSpendingsInput) Bind(c *gin.Context) error {
 	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
 }
 
+// maxSpendingsPeriod is the number of months the spendings can be requested for at once.
+const maxSpendingsPeriod = 120
+
+type GetPeriodSpendingsInput struct {
+	FromMonth string `form:"from" binding:"required,yearmonth"`
+	ToMonth   string `form:"to" binding:"required,yearmonth"`
+}
+
+func (i *GetPeriodSpendingsInput) Bind(c *gin.Context) error {
+	if err := c.ShouldBindQuery(i); err != nil {
+		return NewErrBadRequest(err)
+	}
+	from, _ := time.Parse(accounts.FmtYearMonth, i.FromMonth)
+	to, _ := time.Parse(accounts.FmtYearMonth, i.ToMonth)
+	if to.Before(from) {
+		return NewErrBadRequest(fmt.Errorf("period ends at %s before it starts at %s", i.ToMonth, i.FromMonth))
+	}
+	if months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1; months > maxSpendingsPeriod {
+		return NewErrBadRequest(fmt.Errorf("period of %d months exceeds %d months", months, maxSpendingsPeriod))
+	}
+	return nil
+}
+
 type SpendingsResponse map[string]accounts.CurrencyAmounts
 
 func NewSpendingsResponse(spent spendings.Spendings, cats []*categories.Category) SpendingsResponse {
@@ -29,21 +55,77 @@ func NewSpendingsResponse(spent spendings.Spendings, cats []*categories.Category
 	return r
 }
 
+func NewLabelSpendingsResponse(spent spendings.Spendings, lbls labels.LabelCollection) SpendingsResponse {
+	r := make(SpendingsResponse)
+	for _, lbl := range lbls {
+		r[lbl.UUID.String()] = spent.GetAmounts(spendings.LabelCategory(lbl))
+	}
+	r["unlabeled"] = spent.GetUncategorized()
+	return r
+}
+
+func NewPayeeSpendingsResponse(spent spendings.Spendings, ps payees.PayeeCollection) SpendingsResponse {
+	r := make(SpendingsResponse)
+	for _, p := range ps {
+		r[p.UUID.String()] = spent.GetAmounts(spendings.PayeeCategory(p))
+	}
+	r["unknown"] = spent.GetUncategorized()
+	return r
+}
+
 func (h *handler) handleSpendingsGet(c *gin.Context) {
 	var input GetSpendingsInput
 	if err := input.Bind(c); err != nil {
 		h.handleError(c, err)
 		return
 	}
-	cats, err := h.categories.GetUserCategories(c, h.user(c))
+	cats, err := h.categories.GetWorkspaceCategories(c, h.workspace(c))
 	if err != nil {
-		h.handleError(c, fmt.Errorf("failed to get user categories: %w", err))
+		h.handleError(c, fmt.Errorf("failed to get workspace categories: %w", err))
 		return
 	}
-	spent, err := h.spendings.GetMonthSpendings(c, h.user(c), input.Month)
+	spent, err := h.spendings.GetMonthSpendings(c, h.workspace(c), input.Month)
 	if err != nil {
-		h.handleError(c, fmt.Errorf("failed to get user month spendings: %w", err))
+		h.handleError(c, fmt.Errorf("failed to get workspace month spendings: %w", err))
 		return
 	}
 	c.JSON(http.StatusOK, NewSpendingsResponse(spent, cats))
 }
+
+func (h *handler) handleLabelSpendingsGet(c *gin.Context) {
+	var input GetPeriodSpendingsInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	lbls, err := h.labels.GetWorkspaceLabels(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace labels: %w", err))
+		return
+	}
+	spent, err := h.spendings.GetLabelSpendings(c, h.workspace(c), input.FromMonth, input.ToMonth)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace label spendings: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewLabelSpendingsResponse(spent, lbls))
+}
+
+func (h *handler) handlePayeeSpendingsGet(c *gin.Context) {
+	var input GetPeriodSpendingsInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	ps, err := h.payees.GetWorkspacePayees(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace payees: %w", err))
+		return
+	}
+	spent, err := h.spendings.GetPayeeSpendings(c, h.workspace(c), input.FromMonth, input.ToMonth)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace payee spendings: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewPayeeSpendingsResponse(spent, ps))
+}
@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+type TokenInput struct {
+	Name      string       `json:"name" binding:"required"`
+	Scope     tokens.Scope `json:"scope" binding:"required,oneof=read-only read-write"`
+	ExpiresAt *time.Time   `json:"expires_at"`
+}
+
+func (i *TokenInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetTokenInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetTokenInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) token(c *gin.Context) (*tokens.Token, error) {
+	var input GetTokenInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	t, err := h.tokens.GetToken(c, uuid.FromStringOrNil(input.UUID))
+	if err != nil {
+		return nil, err
+	}
+	if t.User.ID != h.user(c).ID {
+		return nil, ErrResourceNotFound
+	}
+	if err := h.precondition(c, t.Version); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkTokenManagement makes sure access tokens are managed only by the user signed in with the identity provider,
+// so that a leaked token cannot be used to issue new ones.
+func (h *handler) checkTokenManagement(c *gin.Context) bool {
+	if h.identity(c).Token != nil {
+		c.JSON(http.StatusForbidden, NewErrorResponse("Forbidden"))
+		return false
+	}
+	return true
+}
+
+type TokenResponse struct {
+	UUID       string       `json:"uuid"`
+	Name       string       `json:"name"`
+	Scope      tokens.Scope `json:"scope"`
+	Token      string       `json:"token,omitempty"`
+	ExpiresAt  *string      `json:"expires_at"`
+	LastUsedAt *string      `json:"last_used_at"`
+	CreatedAt  string       `json:"created_at"`
+}
+
+func NewTokenResponse(t *tokens.Token) *TokenResponse {
+	r := &TokenResponse{
+		UUID:      t.UUID.String(),
+		Name:      t.Name,
+		Scope:     t.Scope,
+		CreatedAt: t.CreatedAt.Format(time.DateTime),
+	}
+	if t.ExpiresAt != nil {
+		expiresAt := t.ExpiresAt.Format(time.DateTime)
+		r.ExpiresAt = &expiresAt
+	}
+	if t.LastUsedAt != nil {
+		lastUsedAt := t.LastUsedAt.Format(time.DateTime)
+		r.LastUsedAt = &lastUsedAt
+	}
+	return r
+}
+
+func NewListTokensResponse(ts tokens.TokenCollection) []*TokenResponse {
+	r := make([]*TokenResponse, 0, len(ts))
+	for _, t := range ts {
+		r = append(r, NewTokenResponse(t))
+	}
+	return r
+}
+
+func (h *handler) handleTokensCreate(c *gin.Context) {
+	if !h.checkTokenManagement(c) {
+		return
+	}
+	var input TokenInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	t, secret, err := h.tokens.CreateToken(c, h.user(c), input.Name, input.Scope, input.ExpiresAt)
+	if errors.Is(err, tokens.ErrInvalidExpiry) {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("Invalid value of 'ExpiresAt'"))
+		return
+	}
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create token: %w", err))
+		return
+	}
+	r := NewTokenResponse(t)
+	r.Token = secret
+	c.JSON(http.StatusCreated, r)
+}
+
+func (h *handler) handleTokensList(c *gin.Context) {
+	if !h.checkTokenManagement(c) {
+		return
+	}
+	ts, err := h.tokens.GetUserTokens(c, h.user(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get user tokens: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListTokensResponse(ts))
+}
+
+func (h *handler) handleTokensDelete(c *gin.Context) {
+	if !h.checkTokenManagement(c) {
+		return
+	}
+	t, err := h.token(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find token: %w", err))
+		return
+	}
+	if err := h.tokens.RevokeToken(c, t); err != nil {
+		h.handleError(c, fmt.Errorf("failed to revoke token: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
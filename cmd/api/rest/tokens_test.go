@@ -0,0 +1,98 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+type TokenTestResponse struct {
+	UUID  string `json:"uuid"`
+	Scope string `json:"scope"`
+	Token string `json:"token"`
+}
+
+func (ts *RESTTestSuite) createToken(auth Auth, body string) TokenTestResponse {
+	request := NewRequest("POST", "/tokens", bytes.NewBufferString(body)).WithAuth(auth)
+	response := TokenTestResponse{}
+	code := ts.ServeJSON(request, &response)
+	ts.Require().Equal(http.StatusCreated, code)
+	ts.Require().True(strings.HasPrefix(response.Token, "mmpat_"), "Received invalid token in response")
+	return response
+}
+
+func (ts *RESTTestSuite) testTokens() {
+	auth := ts.NewAuth()
+	other := ts.NewAuth()
+
+	readOnly := ts.createToken(auth, `{"name": "report", "scope": "read-only"}`)
+	readWrite := ts.createToken(auth, `{"name": "import", "scope": "read-write", "expires_at": "2999-01-01T00:00:00Z"}`)
+	readOnlyAuth := Auth{UUID: auth.UUID, user: auth.user, workspace: auth.workspace, token: readOnly.Token}
+	readWriteAuth := Auth{UUID: auth.UUID, user: auth.user, workspace: auth.workspace, token: readWrite.Token}
+
+	errorTests := []ErrorTest{
+		{
+			Name:   "create/invalid scope",
+			Method: "POST",
+			Target: "/tokens",
+			Auth:   auth,
+			Body:   bytes.NewBufferString(`{"name": "admin", "scope": "admin"}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Scope'",
+		},
+		{
+			Name:   "create/expired",
+			Method: "POST",
+			Target: "/tokens",
+			Auth:   auth,
+			Body:   bytes.NewBufferString(`{"name": "old", "scope": "read-only", "expires_at": "2000-01-01T00:00:00Z"}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'ExpiresAt'",
+		},
+		{
+			Name:   "read-only/write",
+			Method: "POST",
+			Target: "/accounts",
+			Auth:   readOnlyAuth,
+			Body:   bytes.NewBufferString(`{"name": "Token account"}`),
+			Code:   http.StatusForbidden,
+			Error:  "Forbidden",
+		},
+		{
+			Name:   "manage with token",
+			Method: "GET",
+			Target: "/tokens",
+			Auth:   readWriteAuth,
+			Code:   http.StatusForbidden,
+			Error:  "Forbidden",
+		},
+		{
+			Name:   "revoke/other user",
+			Method: "DELETE",
+			Target: "/tokens/" + readOnly.UUID,
+			Auth:   other,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+	}
+	for _, tt := range errorTests {
+		ts.testError(tt)
+	}
+
+	requestTests := []RequestTest{
+		{Name: "read-only/read", Method: "GET", Target: "/accounts", Auth: readOnlyAuth, Code: http.StatusOK},
+		{Name: "read-write/write", Method: "POST", Target: "/accounts", Body: bytes.NewBufferString(`{"name": "Token account"}`), Auth: readWriteAuth, Code: http.StatusCreated},
+	}
+	for _, tt := range requestTests {
+		ts.testRequest(tt)
+	}
+
+	ts.testCount(CountTest{Name: "list", Target: "/tokens", Auth: auth, Count: 2})
+	ts.testCount(CountTest{Name: "list/other user", Target: "/tokens", Auth: other, Count: 0})
+
+	ts.testRequest(RequestTest{Name: "revoke", Method: "DELETE", Target: "/tokens/" + readOnly.UUID, Auth: auth, Code: http.StatusNoContent})
+	ts.testRequest(RequestTest{Name: "revoked/read", Method: "GET", Target: "/accounts", Auth: readOnlyAuth, Code: http.StatusUnauthorized})
+	ts.testCount(CountTest{Name: "list/revoked", Target: "/tokens", Auth: auth, Count: 1})
+}
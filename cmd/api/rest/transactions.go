@@ -1,25 +1,47 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type CreateTransactionInput struct {
-	Month        string            `json:"month" binding:"required,yearmonth"`
-	Currency     accounts.Currency `json:"currency" binding:"required"`
-	Amount       float64           `json:"amount" binding:"required"`
-	Description  string            `json:"description"`
-	CategoryUUID *string           `json:"category_uuid"`
+	Month        string                  `json:"month" binding:"required_without=Date,yearmonth"`
+	Date         string                  `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Currency     accounts.Currency       `json:"currency" binding:"required"`
+	Amount       float64                 `json:"amount" binding:"required"`
+	Description  string                  `json:"description"`
+	CategoryUUID *string                 `json:"category_uuid" binding:"excluded_with=Splits"`
+	AccountUUID  *string                 `json:"account_uuid"`
+	PayeeUUID    *string                 `json:"payee_uuid" binding:"omitempty,uuid"`
+	Splits       []TransactionSplitInput `json:"splits" binding:"omitempty,dive"`
+	Labels       []string                `json:"labels" binding:"omitempty,dive,uuid"`
+}
+
+type TransactionSplitInput struct {
+	CategoryUUID *string `json:"category_uuid"`
+	Amount       float64 `json:"amount" binding:"required"`
+	Note         string  `json:"note"`
 }
 
 func (i *CreateTransactionInput) Bind(c *gin.Context) error {
-	return NewErrBadRequestOrNil(c.ShouldBind(i))
+	if err := c.ShouldBind(i); err != nil {
+		return NewErrBadRequest(err)
+	}
+	if i.Month != "" && i.Date != "" && !strings.HasPrefix(i.Date, i.Month+"-") {
+		return NewErrBadRequest(fmt.Errorf("month %s does not match date %s", i.Month, i.Date))
+	}
+	return nil
 }
 
 type GetTransactionInput struct {
@@ -30,19 +52,145 @@ func (i *GetTransactionInput) Bind(c *gin.Context) error {
 	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
 }
 
+type SetTransactionLabelsInput struct {
+	Labels []string `json:"labels" binding:"omitempty,dive,uuid"`
+}
+
+func (i *SetTransactionLabelsInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
 type GetMonthTransactionsInput struct {
 	Month string `uri:"month" binding:"required,yearmonth"`
+	Page  PageInput
 }
 
 func (i *GetMonthTransactionsInput) Bind(c *gin.Context) error {
-	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+	if err := c.ShouldBindUri(i); err != nil {
+		return NewErrBadRequest(err)
+	}
+	return i.Page.Bind(c)
+}
+
+// Filter selects the transactions of the month ordered by date, as the whole month is listed.
+func (i *GetMonthTransactionsInput) Filter() *transactions.Filter {
+	return &transactions.Filter{
+		FromMonth: i.Month,
+		ToMonth:   i.Month,
+		Sort:      transactions.SortByDate,
+		Limit:     i.Page.Limit,
+		Cursor:    i.Page.Cursor,
+	}
+}
+
+type SearchTransactionsInput struct {
+	FromMonth    string   `form:"from" binding:"yearmonth"`
+	ToMonth      string   `form:"to" binding:"yearmonth"`
+	FromDate     string   `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate       string   `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+	CategoryUUID string   `form:"category" binding:"omitempty,uuid|eq=uncategorized"`
+	Currency     string   `form:"currency"`
+	MinAmount    *float64 `form:"min_amount"`
+	MaxAmount    *float64 `form:"max_amount"`
+	Query        string   `form:"q"`
+	AccountUUID  string   `form:"account" binding:"omitempty,uuid"`
+	LabelUUID    string   `form:"label" binding:"omitempty,uuid"`
+	PayeeUUID    string   `form:"payee" binding:"omitempty,uuid"`
+	Sort         string   `form:"sort" binding:"omitempty,oneof=month date amount created_at"`
+	Order        string   `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit        int      `form:"limit" binding:"omitempty,min=1,max=500"`
+	Cursor       string   `form:"cursor"`
+}
+
+func (i *SearchTransactionsInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindQuery(i))
+}
+
+func (i *SearchTransactionsInput) Filter() *transactions.Filter {
+	f := &transactions.Filter{
+		FromMonth:  i.FromMonth,
+		ToMonth:    i.ToMonth,
+		Currency:   accounts.Currency(i.Currency),
+		MinAmount:  i.MinAmount,
+		MaxAmount:  i.MaxAmount,
+		Query:      i.Query,
+		Sort:       transactions.SortField(i.Sort),
+		Descending: i.Order == "desc",
+		Limit:      i.Limit,
+		Cursor:     i.Cursor,
+	}
+	if i.CategoryUUID == "uncategorized" {
+		f.Uncategorized = true
+	} else if i.CategoryUUID != "" {
+		UUID := uuid.FromStringOrNil(i.CategoryUUID)
+		f.CategoryUUID = &UUID
+	}
+	if i.AccountUUID != "" {
+		UUID := uuid.FromStringOrNil(i.AccountUUID)
+		f.AccountUUID = &UUID
+	}
+	if i.LabelUUID != "" {
+		UUID := uuid.FromStringOrNil(i.LabelUUID)
+		f.LabelUUID = &UUID
+	}
+	if i.PayeeUUID != "" {
+		UUID := uuid.FromStringOrNil(i.PayeeUUID)
+		f.PayeeUUID = &UUID
+	}
+	if d, err := time.Parse(time.DateOnly, i.FromDate); err == nil {
+		f.FromDate = &d
+	}
+	if d, err := time.Parse(time.DateOnly, i.ToDate); err == nil {
+		f.ToDate = &d
+	}
+	return f
+}
+
+func (h *handler) transactionCategory(c *gin.Context, categoryUUID *string) (*categories.Category, error) {
+	if categoryUUID == nil {
+		return nil, nil
+	}
+	cat, err := h.categories.GetCategory(c, uuid.FromStringOrNil(*categoryUUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, cat.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+func (h *handler) transactionAccount(c *gin.Context, accountUUID *string) (*accounts.Account, error) {
+	if accountUUID == nil {
+		return nil, nil
+	}
+	acc, err := h.accounts.GetAccount(c, uuid.FromStringOrNil(*accountUUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, acc.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	return acc, nil
 }
 
-func (h *handler) transactionCategory(c *gin.Context, i CreateTransactionInput) (*categories.Category, error) {
-	if i.CategoryUUID != nil {
-		return h.categories.GetCategory(c, uuid.FromStringOrNil(*i.CategoryUUID))
+func (h *handler) transactionPayee(c *gin.Context, payeeUUID *string) (*payees.Payee, error) {
+	if payeeUUID == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return h.workspacePayee(c, *payeeUUID)
+}
+
+func (h *handler) transactionSplits(c *gin.Context, i CreateTransactionInput) (transactions.SplitCollection, error) {
+	splits := make(transactions.SplitCollection, 0, len(i.Splits))
+	for _, si := range i.Splits {
+		cat, err := h.transactionCategory(c, si.CategoryUUID)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, transactions.NewSplit(cat, si.Amount, si.Note))
+	}
+	return splits, nil
 }
 
 func (h *handler) transaction(c *gin.Context) (*transactions.Transaction, error) {
@@ -50,23 +198,46 @@ func (h *handler) transaction(c *gin.Context) (*transactions.Transaction, error)
 	if err := input.Bind(c); err != nil {
 		return nil, err
 	}
-	tx, err := h.transactions.GetTransaction(c, uuid.FromStringOrNil(input.UUID))
+	tx, err := h.findTransaction(c, input.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, tx.Version); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (h *handler) findTransaction(c *gin.Context, txUUID string) (*transactions.Transaction, error) {
+	tx, err := h.transactions.GetTransaction(c, uuid.FromStringOrNil(txUUID))
 	if err != nil {
 		return nil, err
 	}
-	if tx.User.ID != h.user(c).ID {
-		return nil, ErrResourceNotFound
+	if err := h.authorize(c, tx.WorkspaceUUID); err != nil {
+		return nil, err
 	}
 	return tx, nil
 }
 
 type TransactionResponse struct {
-	UUID        string            `json:"uuid"`
-	Month       string            `json:"month"`
-	Currency    accounts.Currency `json:"currency"`
-	Amount      float64           `json:"amount"`
-	Description string            `json:"description"`
-	Category    string            `json:"category_uuid"`
+	UUID        string                      `json:"uuid"`
+	Month       string                      `json:"month"`
+	Date        string                      `json:"date,omitempty"`
+	Currency    accounts.Currency           `json:"currency"`
+	Amount      float64                     `json:"amount"`
+	Description string                      `json:"description"`
+	Category    string                      `json:"category_uuid"`
+	Account     string                      `json:"account_uuid,omitempty"`
+	Payee       string                      `json:"payee_uuid,omitempty"`
+	Splits      []*TransactionSplitResponse `json:"splits,omitempty"`
+	Labels      []string                    `json:"labels"`
+	Version     uint                        `json:"version"`
+}
+
+type TransactionSplitResponse struct {
+	Category string  `json:"category_uuid"`
+	Amount   float64 `json:"amount"`
+	Note     string  `json:"note"`
 }
 
 func NewTransactionResponse(tx *transactions.Transaction) *TransactionResponse {
@@ -74,13 +245,51 @@ func NewTransactionResponse(tx *transactions.Transaction) *TransactionResponse {
 	if tx.Category != nil {
 		cat = tx.Category.UUID.String()
 	}
+	date := ""
+	if tx.Date != nil {
+		date = tx.Date.Format(time.DateOnly)
+	}
+	acc := ""
+	if tx.AccountUUID != nil {
+		acc = tx.AccountUUID.String()
+	}
+	payee := ""
+	if tx.Payee != nil {
+		payee = tx.Payee.UUID.String()
+	}
+	var splits []*TransactionSplitResponse
+	for _, split := range tx.Splits {
+		splits = append(splits, NewTransactionSplitResponse(split))
+	}
+	lbls := make([]string, 0, len(tx.Labels))
+	for _, lbl := range tx.Labels {
+		lbls = append(lbls, lbl.UUID.String())
+	}
 	return &TransactionResponse{
 		UUID:        tx.UUID.String(),
 		Month:       tx.YearMonth,
+		Date:        date,
 		Currency:    tx.Currency,
 		Amount:      tx.Amount,
 		Description: tx.Description,
 		Category:    cat,
+		Account:     acc,
+		Payee:       payee,
+		Splits:      splits,
+		Labels:      lbls,
+		Version:     tx.Version,
+	}
+}
+
+func NewTransactionSplitResponse(split *transactions.Split) *TransactionSplitResponse {
+	cat := ""
+	if split.Category != nil {
+		cat = split.Category.UUID.String()
+	}
+	return &TransactionSplitResponse{
+		Category: cat,
+		Amount:   split.Amount,
+		Note:     split.Note,
 	}
 }
 
@@ -98,17 +307,49 @@ func (h *handler) handleTransactionsCreate(c *gin.Context) {
 		h.handleError(c, err)
 		return
 	}
-	cat, err := h.transactionCategory(c, input)
+	cat, err := h.transactionCategory(c, input.CategoryUUID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
-	tx, err := h.transactions.CreateTransaction(c, h.user(c), input.Month, input.Currency, input.Amount, input.Description, cat)
+	acc, err := h.transactionAccount(c, input.AccountUUID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	payee, err := h.transactionPayee(c, input.PayeeUUID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	splits, err := h.transactionSplits(c, input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	lbls, err := h.workspaceLabels(c, input.Labels)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	tx := transactions.NewTransaction(h.workspace(c), input.Month, input.Currency, input.Amount, input.Description, cat)
+	if d, err := time.Parse(time.DateOnly, input.Date); err == nil {
+		tx.SetDate(d)
+	}
+	tx.Account = acc
+	tx.Payee = payee
+	tx.Splits = splits
+	tx.Labels = lbls
+	err = h.transactions.SaveTransaction(c, tx)
+	if errors.Is(err, transactions.ErrSplitsAmountMismatch) {
+		h.handleError(c, NewErrBadRequest(err))
+		return
+	}
 	if err != nil {
 		h.handleError(c, fmt.Errorf("failed to create transaction: %w", err))
 		return
 	}
-	c.JSON(http.StatusCreated, NewTransactionResponse(tx))
+	h.respondVersioned(c, http.StatusCreated, tx.Version, NewTransactionResponse(tx))
 }
 
 func (h *handler) handleTransactionsList(c *gin.Context) {
@@ -117,14 +358,45 @@ func (h *handler) handleTransactionsList(c *gin.Context) {
 		h.handleError(c, err)
 		return
 	}
-	txs, err := h.transactions.GetUserTransactions(c, h.user(c), input.Month)
+	if input.Page.Paginated() {
+		page, err := h.transactions.SearchTransactions(c, h.workspace(c), input.Filter())
+		if errors.Is(err, datastore.ErrInvalidCursor) {
+			h.handleError(c, NewErrBadRequest(err))
+			return
+		}
+		if err != nil {
+			h.handleError(c, fmt.Errorf("failed to get workspace transactions: %w", err))
+			return
+		}
+		h.respondPage(c, NewListTransactionsResponse(page.Transactions), page.NextCursor)
+		return
+	}
+	txs, err := h.transactions.GetWorkspaceTransactions(c, h.workspace(c), input.Month)
 	if err != nil {
-		h.handleError(c, fmt.Errorf("failed to get user transactions: %w", err))
+		h.handleError(c, fmt.Errorf("failed to get workspace transactions: %w", err))
 		return
 	}
 	c.JSON(http.StatusOK, NewListTransactionsResponse(txs))
 }
 
+func (h *handler) handleTransactionsSearch(c *gin.Context) {
+	var input SearchTransactionsInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	page, err := h.transactions.SearchTransactions(c, h.workspace(c), input.Filter())
+	if errors.Is(err, datastore.ErrInvalidCursor) {
+		h.handleError(c, NewErrBadRequest(err))
+		return
+	}
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to search workspace transactions: %w", err))
+		return
+	}
+	h.respondPage(c, NewListTransactionsResponse(page.Transactions), page.NextCursor)
+}
+
 func (h *handler) handleTransactionsDelete(c *gin.Context) {
 	tx, err := h.transaction(c)
 	if err != nil {
@@ -137,3 +409,26 @@ func (h *handler) handleTransactionsDelete(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (h *handler) handleTransactionLabelsSet(c *gin.Context) {
+	tx, err := h.transaction(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find transaction: %w", err))
+		return
+	}
+	var input SetTransactionLabelsInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	lbls, err := h.workspaceLabels(c, input.Labels)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find label: %w", err))
+		return
+	}
+	if err := h.transactions.SetTransactionLabels(c, tx, lbls); err != nil {
+		h.handleError(c, fmt.Errorf("failed to set transaction labels: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, tx.Version, NewTransactionResponse(tx))
+}
@@ -14,7 +14,7 @@ func (ts *RESTTestSuite) testTransactions() {
 	auth1 := ts.NewAuth()
 	auth2 := ts.NewAuth()
 
-	user1transaction, err := ts.transactionsService.CreateTransaction(context.Background(), auth1.user, "2010-01", "USD", 100, "", nil)
+	user1transaction, err := ts.transactionsService.CreateTransaction(context.Background(), auth1.workspace, "2010-01", "USD", 100, "", nil)
 	ts.Require().NoErrorf(err, "Failed to create test transaction")
 
 	tests := []ErrorTest{
@@ -63,6 +63,42 @@ func (ts *RESTTestSuite) testTransactions() {
 			Code:   http.StatusNotFound,
 			Error:  "Not found",
 		},
+		{
+			Name:   "create transaction/invalid date",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{"date": "2010-01", "currency": "USD", "amount": 100}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Date'",
+		},
+		{
+			Name:   "create transaction/month not matching date",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{"month": "2010-01", "date": "2010-03-05", "currency": "USD", "amount": 100}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+		{
+			Name:   "create transaction/splits amount mismatch",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(`{"month": "2010-01", "currency": "USD", "amount": -100, "splits": [{"amount": -60}, {"amount": -30}]}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
+		{
+			Name:   "create transaction/category with splits",
+			Method: "POST",
+			Target: "/transactions",
+			Auth:   auth1,
+			Body:   bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-01", "currency": "USD", "amount": -100, "category_uuid": "%s", "splits": [{"amount": -100}]}`, uuid.Must(uuid.NewV4()))),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'CategoryUUID'",
+		},
 		{
 			Name:   "get transactions/invalid month",
 			Method: "GET",
@@ -72,6 +108,24 @@ func (ts *RESTTestSuite) testTransactions() {
 			Code:   http.StatusBadRequest,
 			Error:  "Invalid value of 'Month'",
 		},
+		{
+			Name:   "search transactions/invalid category",
+			Method: "GET",
+			Target: "/transactions?category=groceries",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'CategoryUUID'",
+		},
+		{
+			Name:   "search transactions/invalid cursor",
+			Method: "GET",
+			Target: "/transactions?cursor=cookies",
+			Auth:   auth1,
+			Body:   nil,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid request input",
+		},
 		{
 			Name:   "delete transaction/invalid id",
 			Method: "DELETE",
@@ -206,8 +260,8 @@ func (ts *RESTTestSuite) testCreateTransactions() {
 			Ref:  nil,
 		},
 		{
-			Name: "2010-03 USD groceries",
-			Body: bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-03","currency": "USD","amount": -200,"category_uuid": "%s"}`, ts.categories.groceries)),
+			Name: "2010-03-15 USD groceries",
+			Body: bytes.NewBufferString(fmt.Sprintf(`{"date": "2010-03-15","currency": "USD","amount": -200,"category_uuid": "%s"}`, ts.categories.groceries)),
 			Ref:  nil,
 		},
 
@@ -215,11 +269,11 @@ func (ts *RESTTestSuite) testCreateTransactions() {
 		{
 			Name: "2010-04 USD income",
 			Body: bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-04","currency": "USD","amount": 1000,"category_uuid": "%s"}`, ts.categories.income)),
-			Ref:  nil,
+			Ref:  &ts.transactions.labeled,
 		},
 		{
 			Name: "2010-04 USD groceries",
-			Body: bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-04","currency": "USD","amount": -200,"category_uuid": "%s"}`, ts.categories.groceries)),
+			Body: bytes.NewBufferString(fmt.Sprintf(`{"month": "2010-04","currency": "USD","amount": -200,"category_uuid": "%s","labels": ["%s"]}`, ts.categories.groceries, ts.labels.trip)),
 			Ref:  nil,
 		},
 	}
@@ -296,4 +350,75 @@ func (ts *RESTTestSuite) testGetTransactions() {
 	for _, tt := range tests {
 		ts.testCount(tt)
 	}
+	ts.testPageCount(PageCountTest{Name: "get main 2010-02 transactions/first page", Target: "/transactions/2010-02?limit=5", Auth: ts.users.main, Count: 5, Next: true})
+	ts.testPageCount(PageCountTest{Name: "get main 2010-02 transactions/single page", Target: "/transactions/2010-02?limit=7", Auth: ts.users.main, Count: 7})
+}
+
+func (ts *RESTTestSuite) testSearchTransactions() {
+	tests := []PageCountTest{
+		{
+			Name:   "search main transactions",
+			Target: "/transactions?limit=500",
+			Auth:   ts.users.main,
+			Count:  20,
+		},
+		{
+			Name:   "search main transactions/month range",
+			Target: "/transactions?from=2010-01&to=2010-02",
+			Auth:   ts.users.main,
+			Count:  12,
+		},
+		{
+			Name:   "search main transactions/date range",
+			Target: "/transactions?from_date=2010-03-10&to_date=2010-03-20",
+			Auth:   ts.users.main,
+			Count:  1,
+		},
+		{
+			Name:   "search main transactions/category",
+			Target: "/transactions?category=" + ts.categories.income.String(),
+			Auth:   ts.users.main,
+			Count:  8,
+		},
+		{
+			Name:   "search main transactions/uncategorized",
+			Target: "/transactions?category=uncategorized",
+			Auth:   ts.users.main,
+			Count:  3,
+		},
+		{
+			Name:   "search main transactions/currency",
+			Target: "/transactions?currency=EUR",
+			Auth:   ts.users.main,
+			Count:  4,
+		},
+		{
+			Name:   "search main transactions/amount range",
+			Target: "/transactions?min_amount=-200&max_amount=-100",
+			Auth:   ts.users.main,
+			Count:  9,
+		},
+		{
+			Name:   "search main transactions/first page",
+			Target: "/transactions?sort=amount&order=desc&limit=5",
+			Auth:   ts.users.main,
+			Count:  5,
+			Next:   true,
+		},
+		{
+			Name:   "search main transactions/label",
+			Target: "/transactions?label=" + ts.labels.trip.String(),
+			Auth:   ts.users.main,
+			Count:  2,
+		},
+		{
+			Name:   "search control transactions",
+			Target: "/transactions",
+			Auth:   ts.users.control,
+			Count:  0,
+		},
+	}
+	for _, tt := range tests {
+		ts.testPageCount(tt)
+	}
 }
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+type GetTrashItemInput struct {
+	Type trash.Type `uri:"type" binding:"required,oneof=account category transaction"`
+	UUID string     `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetTrashItemInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) trashItem(c *gin.Context) (*trash.Item, error) {
+	var input GetTrashItemInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	item, err := h.trash.GetItem(c, input.Type, uuid.FromStringOrNil(input.UUID))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.authorize(c, item.WorkspaceUUID); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+type TrashItemResponse struct {
+	Type      trash.Type `json:"type"`
+	UUID      string     `json:"uuid"`
+	Name      string     `json:"name"`
+	DeletedAt string     `json:"deleted_at"`
+}
+
+func NewTrashItemResponse(item *trash.Item) *TrashItemResponse {
+	return &TrashItemResponse{
+		Type:      item.Type,
+		UUID:      item.UUID.String(),
+		Name:      item.Name,
+		DeletedAt: item.DeletedAt.Format(time.DateTime),
+	}
+}
+
+func NewListTrashResponse(items trash.ItemCollection) []*TrashItemResponse {
+	r := make([]*TrashItemResponse, 0, len(items))
+	for _, item := range items {
+		r = append(r, NewTrashItemResponse(item))
+	}
+	return r
+}
+
+func (h *handler) handleTrashList(c *gin.Context) {
+	items, err := h.trash.GetWorkspaceTrash(c, h.workspace(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace trash: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListTrashResponse(items))
+}
+
+func (h *handler) handleTrashRestore(c *gin.Context) {
+	item, err := h.trashItem(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find deleted record: %w", err))
+		return
+	}
+	if err := h.trash.RestoreItem(c, item); err != nil {
+		h.handleError(c, fmt.Errorf("failed to restore deleted record: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"context"
+	"net/http"
+)
+
+func (ts *RESTTestSuite) testTrash() {
+	auth1 := ts.NewAuth()
+	auth2 := ts.NewAuth()
+
+	cat, err := ts.categoriesService.CreateCategory(context.Background(), auth1.workspace, "food")
+	ts.Require().NoErrorf(err, "Failed to create test category")
+	tx, err := ts.transactionsService.CreateTransaction(context.Background(), auth1.workspace, "2010-01", "USD", -100, "groceries", cat)
+	ts.Require().NoErrorf(err, "Failed to create test transaction")
+
+	ts.testRequest(RequestTest{Name: "delete category", Method: "DELETE", Target: "/categories/" + cat.UUID.String(), Auth: auth1, Code: http.StatusNoContent})
+	ts.testRequest(RequestTest{Name: "delete transaction", Method: "DELETE", Target: "/transactions/" + tx.UUID.String(), Auth: auth1, Code: http.StatusNoContent})
+
+	ts.testCount(CountTest{Name: "list", Target: "/trash", Auth: auth1, Count: 2})
+	ts.testCount(CountTest{Name: "list/other workspace", Target: "/trash", Auth: auth2, Count: 0})
+
+	for _, tt := range []ErrorTest{
+		{
+			Name:   "restore/invalid type",
+			Method: "POST",
+			Target: "/trash/label/" + cat.UUID.String() + "/restore",
+			Auth:   auth1,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Type'",
+		},
+		{
+			Name:   "restore/invalid id",
+			Method: "POST",
+			Target: "/trash/category/food/restore",
+			Auth:   auth1,
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'UUID'",
+		},
+		{
+			Name:   "restore/type mismatch",
+			Method: "POST",
+			Target: "/trash/account/" + cat.UUID.String() + "/restore",
+			Auth:   auth1,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "restore/not owner",
+			Method: "POST",
+			Target: "/trash/category/" + cat.UUID.String() + "/restore",
+			Auth:   auth2,
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+	} {
+		ts.testError(tt)
+	}
+
+	ts.testRequest(RequestTest{Name: "restore category", Method: "POST", Target: "/trash/category/" + cat.UUID.String() + "/restore", Auth: auth1, Code: http.StatusNoContent})
+	ts.testRequest(RequestTest{Name: "restore transaction", Method: "POST", Target: "/trash/transaction/" + tx.UUID.String() + "/restore", Auth: auth1, Code: http.StatusNoContent})
+	ts.testError(ErrorTest{
+		Name:   "restore/not deleted",
+		Method: "POST",
+		Target: "/trash/category/" + cat.UUID.String() + "/restore",
+		Auth:   auth1,
+		Code:   http.StatusNotFound,
+		Error:  "Not found",
+	})
+
+	ts.testCount(CountTest{Name: "list after restore", Target: "/trash", Auth: auth1, Count: 0})
+	ts.Run("restored transaction", func() {
+		restored, err := ts.transactionsService.GetTransaction(context.Background(), tx.UUID)
+		ts.Require().NoError(err, "Failed to find restored transaction.")
+		ts.Require().NotNil(restored.Category)
+		ts.Equal(cat.UUID, restored.Category.UUID)
+	})
+}
@@ -0,0 +1,462 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"net/http"
+	"time"
+)
+
+// WorkspaceHeader is the header selecting the workspace the request is performed in.
+// The personal workspace of the user is used when it is omitted.
+const WorkspaceHeader = "X-Workspace-UUID"
+
+type WorkspaceHeaderInput struct {
+	UUID string `header:"X-Workspace-UUID" binding:"omitempty,uuid"`
+}
+
+func (i *WorkspaceHeaderInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindHeader(i))
+}
+
+// authorizeWorkspace resolves the workspace of the request and checks that the user may access it.
+// Reading requires any role, while other methods require a role allowed to modify the data.
+func (h *handler) authorizeWorkspace(c *gin.Context) {
+	ws, err := h.requestWorkspace(c)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		c.Abort()
+		return
+	}
+	action := workspaces.ActionWrite
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		action = workspaces.ActionRead
+	}
+	m, err := h.workspaces.Authorize(c, h.user(c), ws.UUID, action)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to authorize workspace access: %w", err))
+		c.Abort()
+		return
+	}
+	c.Set("workspace", ws)
+	c.Set("member", m)
+	c.Next()
+}
+
+func (h *handler) requestWorkspace(c *gin.Context) (*workspaces.Workspace, error) {
+	var input WorkspaceHeaderInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	if input.UUID == "" {
+		return h.workspaces.GetPersonalWorkspace(c, h.user(c))
+	}
+	return workspaces.Ref(uuid.FromStringOrNil(input.UUID)), nil
+}
+
+func (h *handler) workspace(c *gin.Context) *workspaces.Workspace {
+	ws, ok := c.Get("workspace")
+	if !ok {
+		return nil
+	}
+	return ws.(*workspaces.Workspace)
+}
+
+// authorize checks that the resource belongs to the workspace the request is performed in.
+func (h *handler) authorize(c *gin.Context, wsUUID uuid.UUID) error {
+	if ws := h.workspace(c); ws == nil || ws.UUID != wsUUID {
+		return ErrResourceNotFound
+	}
+	return nil
+}
+
+type WorkspaceInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (i *WorkspaceInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetWorkspaceInput struct {
+	UUID string `uri:"uuid" binding:"required,uuid"`
+}
+
+func (i *GetWorkspaceInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+// managedWorkspace provides the workspace from the request path if the user is allowed to perform the action in it.
+func (h *handler) managedWorkspace(c *gin.Context, a workspaces.Action) (*workspaces.Workspace, *workspaces.Member, error) {
+	var input GetWorkspaceInput
+	if err := input.Bind(c); err != nil {
+		return nil, nil, err
+	}
+	m, err := h.workspaces.Authorize(c, h.user(c), uuid.FromStringOrNil(input.UUID), a)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := h.workspaces.GetWorkspace(c, m.WorkspaceUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ws, m, nil
+}
+
+type MemberInput struct {
+	Role workspaces.Role `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+func (i *MemberInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetMemberInput struct {
+	UserID string `uri:"user_id" binding:"required"`
+}
+
+func (i *GetMemberInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) member(c *gin.Context, ws *workspaces.Workspace) (*workspaces.Member, error) {
+	var input GetMemberInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	m, err := h.workspaces.GetMember(c, ws.UUID, &users.User{ID: input.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if err := h.precondition(c, m.Version); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type InvitationInput struct {
+	Role workspaces.Role `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+func (i *InvitationInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type GetInvitationInput struct {
+	UUID string `uri:"invitation_uuid" binding:"required,uuid"`
+}
+
+func (i *GetInvitationInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBindUri(i))
+}
+
+func (h *handler) invitation(c *gin.Context, ws *workspaces.Workspace) (*workspaces.Invitation, error) {
+	var input GetInvitationInput
+	if err := input.Bind(c); err != nil {
+		return nil, err
+	}
+	inv, err := h.workspaces.GetInvitation(c, uuid.FromStringOrNil(input.UUID))
+	if err != nil {
+		return nil, err
+	}
+	if inv.WorkspaceUUID != ws.UUID {
+		return nil, ErrResourceNotFound
+	}
+	if err := h.precondition(c, inv.Version); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+type AcceptInvitationInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (i *AcceptInvitationInput) Bind(c *gin.Context) error {
+	return NewErrBadRequestOrNil(c.ShouldBind(i))
+}
+
+type WorkspaceResponse struct {
+	UUID      string          `json:"uuid"`
+	Name      string          `json:"name"`
+	Personal  bool            `json:"personal"`
+	Role      workspaces.Role `json:"role"`
+	Version   uint            `json:"version"`
+	CreatedAt string          `json:"created_at"`
+}
+
+func NewWorkspaceResponse(ws *workspaces.Workspace, m *workspaces.Member) *WorkspaceResponse {
+	return &WorkspaceResponse{
+		UUID:      ws.UUID.String(),
+		Name:      ws.Name,
+		Personal:  ws.IsPersonalOf(m.UserID),
+		Role:      m.Role,
+		Version:   ws.Version,
+		CreatedAt: ws.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func NewListWorkspacesResponse(ms workspaces.MemberCollection) []*WorkspaceResponse {
+	r := make([]*WorkspaceResponse, 0, len(ms))
+	for _, m := range ms {
+		r = append(r, NewWorkspaceResponse(m.Workspace, m))
+	}
+	return r
+}
+
+type MemberResponse struct {
+	UserID    string          `json:"user_id"`
+	Role      workspaces.Role `json:"role"`
+	CreatedAt string          `json:"created_at"`
+	Version   uint            `json:"version"`
+}
+
+func NewMemberResponse(m *workspaces.Member) *MemberResponse {
+	return &MemberResponse{
+		UserID:    m.UserID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+		Version:   m.Version,
+	}
+}
+
+func NewListMembersResponse(ms workspaces.MemberCollection) []*MemberResponse {
+	r := make([]*MemberResponse, 0, len(ms))
+	for _, m := range ms {
+		r = append(r, NewMemberResponse(m))
+	}
+	return r
+}
+
+type InvitationResponse struct {
+	UUID       string          `json:"uuid"`
+	Role       workspaces.Role `json:"role"`
+	Token      string          `json:"token,omitempty"`
+	ExpiresAt  string          `json:"expires_at"`
+	AcceptedBy *string         `json:"accepted_by,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+func NewInvitationResponse(inv *workspaces.Invitation) *InvitationResponse {
+	return &InvitationResponse{
+		UUID:       inv.UUID.String(),
+		Role:       inv.Role,
+		ExpiresAt:  inv.ExpiresAt.Format(time.DateTime),
+		AcceptedBy: inv.AcceptedBy,
+		CreatedAt:  inv.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func NewListInvitationsResponse(invs workspaces.InvitationCollection) []*InvitationResponse {
+	r := make([]*InvitationResponse, 0, len(invs))
+	for _, inv := range invs {
+		r = append(r, NewInvitationResponse(inv))
+	}
+	return r
+}
+
+func (h *handler) handleWorkspaceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, workspaces.ErrLastOwner):
+		c.JSON(http.StatusConflict, NewErrorResponse("Workspace must have an owner"))
+	case errors.Is(err, workspaces.ErrPersonalOwner):
+		c.JSON(http.StatusConflict, NewErrorResponse("Owner of a personal workspace cannot be changed"))
+	case errors.Is(err, workspaces.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, NewErrorResponse("Already a member"))
+	case errors.Is(err, workspaces.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("Not found"))
+	default:
+		h.handleError(c, err)
+	}
+}
+
+func (h *handler) handleWorkspacesCreate(c *gin.Context) {
+	var input WorkspaceInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	ws, err := h.workspaces.CreateWorkspace(c, h.user(c), input.Name)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create workspace: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusCreated, ws.Version, NewWorkspaceResponse(ws, ws.Members[0]))
+}
+
+func (h *handler) handleWorkspacesList(c *gin.Context) {
+	if _, err := h.workspaces.GetPersonalWorkspace(c, h.user(c)); err != nil {
+		h.handleError(c, fmt.Errorf("failed to get personal workspace: %w", err))
+		return
+	}
+	ms, err := h.workspaces.GetUserMemberships(c, h.user(c))
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get user workspaces: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListWorkspacesResponse(ms))
+}
+
+func (h *handler) handleWorkspacesUpdate(c *gin.Context) {
+	ws, m, err := h.managedWorkspace(c, workspaces.ActionManage)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	if err := h.precondition(c, ws.Version); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	var input WorkspaceInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	ws.Name = input.Name
+	if err := h.workspaces.UpdateWorkspace(c, ws); err != nil {
+		h.handleError(c, fmt.Errorf("failed to update workspace: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, ws.Version, NewWorkspaceResponse(ws, m))
+}
+
+func (h *handler) handleMembersList(c *gin.Context) {
+	ws, _, err := h.managedWorkspace(c, workspaces.ActionRead)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	ms, err := h.workspaces.GetWorkspaceMembers(c, ws)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace members: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListMembersResponse(ms))
+}
+
+func (h *handler) handleMembersUpdate(c *gin.Context) {
+	ws, _, err := h.managedWorkspace(c, workspaces.ActionManage)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	m, err := h.member(c, ws)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find member: %w", err))
+		return
+	}
+	var input MemberInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	if err := h.workspaces.SetMemberRole(c, ws, m, input.Role); err != nil {
+		h.handleWorkspaceError(c, fmt.Errorf("failed to update member: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, m.Version, NewMemberResponse(m))
+}
+
+// handleMembersDelete removes a member from the workspace. Besides owners, any member can leave on their own.
+func (h *handler) handleMembersDelete(c *gin.Context) {
+	var memberInput GetMemberInput
+	if err := memberInput.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	action := workspaces.ActionManage
+	if memberInput.UserID == h.user(c).ID {
+		action = workspaces.ActionRead
+	}
+	ws, _, err := h.managedWorkspace(c, action)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	m, err := h.member(c, ws)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find member: %w", err))
+		return
+	}
+	if err := h.workspaces.RemoveMember(c, ws, m); err != nil {
+		h.handleWorkspaceError(c, fmt.Errorf("failed to remove member: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *handler) handleInvitationsCreate(c *gin.Context) {
+	ws, _, err := h.managedWorkspace(c, workspaces.ActionManage)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	var input InvitationInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	inv, token, err := h.workspaces.CreateInvitation(c, ws, h.user(c), input.Role)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to create invitation: %w", err))
+		return
+	}
+	r := NewInvitationResponse(inv)
+	r.Token = token
+	c.JSON(http.StatusCreated, r)
+}
+
+func (h *handler) handleInvitationsList(c *gin.Context) {
+	ws, _, err := h.managedWorkspace(c, workspaces.ActionManage)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	invs, err := h.workspaces.GetWorkspaceInvitations(c, ws)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to get workspace invitations: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, NewListInvitationsResponse(invs))
+}
+
+func (h *handler) handleInvitationsDelete(c *gin.Context) {
+	ws, _, err := h.managedWorkspace(c, workspaces.ActionManage)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	inv, err := h.invitation(c, ws)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find invitation: %w", err))
+		return
+	}
+	if err := h.workspaces.DeleteInvitation(c, inv); err != nil {
+		h.handleError(c, fmt.Errorf("failed to delete invitation: %w", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *handler) handleInvitationsAccept(c *gin.Context) {
+	var input AcceptInvitationInput
+	if err := input.Bind(c); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	m, err := h.workspaces.AcceptInvitation(c, h.user(c), input.Token)
+	if err != nil {
+		h.handleWorkspaceError(c, fmt.Errorf("failed to accept invitation: %w", err))
+		return
+	}
+	ws, err := h.workspaces.GetWorkspace(c, m.WorkspaceUUID)
+	if err != nil {
+		h.handleError(c, fmt.Errorf("failed to find workspace: %w", err))
+		return
+	}
+	h.respondVersioned(c, http.StatusOK, ws.Version, NewWorkspaceResponse(ws, m))
+}
@@ -0,0 +1,170 @@
+//go:build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/cmd/api/rest"
+	"github.com/gofrs/uuid"
+	"net/http"
+)
+
+func (ts *RESTTestSuite) testWorkspaces() {
+	owner := ts.NewAuth()
+	member := ts.NewAuth()
+
+	var household CreationTestResponse
+	ts.Run("create workspace", func() {
+		code := ts.ServeJSON(NewRequest("POST", "/workspaces", bytes.NewBufferString(`{"name": "Household"}`)).WithAuth(owner), &household)
+		ts.Equal(http.StatusCreated, code)
+		ts.Require().NotEmpty(household.UUID)
+	})
+	householdUUID := uuid.FromStringOrNil(household.UUID)
+	ts.testCount(CountTest{Name: "list owner workspaces", Target: "/workspaces", Auth: owner, Count: 2})
+
+	ts.Run("create shared account", func() {
+		request := NewRequest("POST", "/accounts", bytes.NewBufferString(`{"name": "joint"}`)).WithAuth(owner).WithWorkspace(householdUUID)
+		ts.Equal(http.StatusCreated, ts.Serve(request))
+	})
+	ts.testCount(CountTest{Name: "personal accounts are separate", Target: "/accounts", Auth: owner, Count: 0})
+
+	ts.Run("not a member", func() {
+		request := NewRequest("GET", "/accounts", nil).WithAuth(member).WithWorkspace(householdUUID)
+		ts.Equal(http.StatusNotFound, ts.Serve(request))
+	})
+
+	var invitation struct {
+		UUID  string `json:"uuid"`
+		Token string `json:"token"`
+	}
+	ts.Run("invite viewer", func() {
+		request := NewRequest("POST", "/workspaces/"+household.UUID+"/invitations", bytes.NewBufferString(`{"role": "viewer"}`)).WithAuth(owner)
+		code := ts.ServeJSON(request, &invitation)
+		ts.Equal(http.StatusCreated, code)
+		ts.Require().NotEmpty(invitation.Token)
+	})
+
+	tests := []ErrorTest{
+		{
+			Name:   "invite/invalid role",
+			Method: "POST",
+			Target: "/workspaces/" + household.UUID + "/invitations",
+			Auth:   owner,
+			Body:   bytes.NewBufferString(`{"role": "admin"}`),
+			Code:   http.StatusBadRequest,
+			Error:  "Invalid value of 'Role'",
+		},
+		{
+			Name:   "invite/not a member",
+			Method: "POST",
+			Target: "/workspaces/" + household.UUID + "/invitations",
+			Auth:   member,
+			Body:   bytes.NewBufferString(`{"role": "owner"}`),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "accept/invalid token",
+			Method: "POST",
+			Target: "/invitations/accept",
+			Auth:   member,
+			Body:   bytes.NewBufferString(`{"token": "forged"}`),
+			Code:   http.StatusNotFound,
+			Error:  "Not found",
+		},
+		{
+			Name:   "accept/already a member",
+			Method: "POST",
+			Target: "/invitations/accept",
+			Auth:   owner,
+			Body:   bytes.NewBufferString(fmt.Sprintf(`{"token": "%s"}`, invitation.Token)),
+			Code:   http.StatusConflict,
+			Error:  "Already a member",
+		},
+	}
+	for _, tt := range tests {
+		ts.testError(tt)
+	}
+
+	ts.testRequest(RequestTest{
+		Name:   "accept invitation",
+		Method: "POST",
+		Target: "/invitations/accept",
+		Body:   bytes.NewBufferString(fmt.Sprintf(`{"token": "%s"}`, invitation.Token)),
+		Auth:   member,
+		Code:   http.StatusOK,
+	})
+	ts.testRequest(RequestTest{
+		Name:   "accept used invitation",
+		Method: "POST",
+		Target: "/invitations/accept",
+		Body:   bytes.NewBufferString(fmt.Sprintf(`{"token": "%s"}`, invitation.Token)),
+		Auth:   ts.NewAuth(),
+		Code:   http.StatusNotFound,
+	})
+	ts.testCount(CountTest{Name: "list member workspaces", Target: "/workspaces", Auth: member, Count: 2})
+	ts.testCount(CountTest{Name: "list members", Target: "/workspaces/" + household.UUID + "/members", Auth: member, Count: 2})
+
+	ts.Run("viewer reads", func() {
+		request := NewRequest("GET", "/accounts", nil).WithAuth(member).WithWorkspace(householdUUID)
+		response := make([]map[string]any, 0)
+		ts.Equal(http.StatusOK, ts.ServeJSON(request, &response))
+		ts.Len(response, 1)
+	})
+	ts.Run("viewer cannot write", func() {
+		request := NewRequest("POST", "/accounts", bytes.NewBufferString(`{"name": "secret"}`)).WithAuth(member).WithWorkspace(householdUUID)
+		response := new(ErrorTestResponse)
+		ts.Equal(http.StatusForbidden, ts.ServeJSON(request, response))
+		ts.Equal("Forbidden", response.Error)
+	})
+	ts.testRequest(RequestTest{
+		Name:   "viewer cannot manage members",
+		Method: "PUT",
+		Target: "/workspaces/" + household.UUID + "/members/" + member.user.ID,
+		Body:   bytes.NewBufferString(`{"role": "owner"}`),
+		Auth:   member,
+		Code:   http.StatusForbidden,
+	})
+
+	ts.testRequest(RequestTest{
+		Name:   "promote to editor",
+		Method: "PUT",
+		Target: "/workspaces/" + household.UUID + "/members/" + member.user.ID,
+		Body:   bytes.NewBufferString(`{"role": "editor"}`),
+		Auth:   owner,
+		Code:   http.StatusOK,
+	})
+	ts.Run("editor writes", func() {
+		request := NewRequest("POST", "/accounts", bytes.NewBufferString(`{"name": "groceries fund"}`)).WithAuth(member).WithWorkspace(householdUUID)
+		ts.Equal(http.StatusCreated, ts.Serve(request))
+	})
+	ts.testError(ErrorTest{
+		Name:   "demote last owner",
+		Method: "PUT",
+		Target: "/workspaces/" + household.UUID + "/members/" + owner.user.ID,
+		Auth:   owner,
+		Body:   bytes.NewBufferString(`{"role": "editor"}`),
+		Code:   http.StatusConflict,
+		Error:  "Workspace must have an owner",
+	})
+	ts.Run("invalid workspace header", func() {
+		request := NewRequest("GET", "/accounts", nil).WithAuth(owner)
+		request.Header.Add(rest.WorkspaceHeader, "household")
+		response := new(ErrorTestResponse)
+		ts.Equal(http.StatusBadRequest, ts.ServeJSON(request, response))
+		ts.Equal("Invalid value of 'UUID'", response.Error)
+	})
+
+	ts.testRequest(RequestTest{
+		Name:   "leave workspace",
+		Method: "DELETE",
+		Target: "/workspaces/" + household.UUID + "/members/" + member.user.ID,
+		Auth:   member,
+		Code:   http.StatusNoContent,
+	})
+	ts.Run("former member", func() {
+		request := NewRequest("GET", "/accounts", nil).WithAuth(member).WithWorkspace(householdUUID)
+		ts.Equal(http.StatusNotFound, ts.Serve(request))
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"io"
+	"os"
+)
+
+func (cli *CLI) runExport(ctx context.Context, args []string) error {
+	fs := flags("export")
+	wf := newWorkspaceFlags(fs)
+	output := fs.String("o", "", "file to write the archive to, the standard output by default")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	u, ws, err := wf.resolve(ctx, cli)
+	if err != nil {
+		return err
+	}
+	a, err := cli.backup.Export(ctx, u, ws)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		return writeArchive(cli.out, a)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := writeArchive(f, a); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeArchive(w io.Writer, a *backup.Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+func (cli *CLI) runImport(ctx context.Context, args []string) error {
+	fs := flags("import")
+	wf := newWorkspaceFlags(fs)
+	strategy := fs.String("strategy", string(backup.StrategySkip), "what happens to the archived records matching the existing ones")
+	rates := fs.Bool("rates", false, "import the archived currency rates missing in the DB, they are shared by all workspaces")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+	u, ws, err := wf.resolve(ctx, cli)
+	if err != nil {
+		return err
+	}
+	a, err := readArchive(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	importArchive := cli.backup.Import
+	if *rates {
+		importArchive = cli.backup.ImportWithRates
+	}
+	r, err := importArchive(ctx, u, ws, a, backup.Strategy(*strategy))
+	if err != nil {
+		return err
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "RECORDS\tCREATED\tUPDATED\tSKIPPED")
+	for _, c := range []struct {
+		name  string
+		count backup.Count
+	}{
+		{"settings", r.Settings},
+		{"accounts", r.Accounts},
+		{"amounts", r.Amounts},
+		{"categories", r.Categories},
+		{"labels", r.Labels},
+		{"payees", r.Payees},
+		{"transactions", r.Transactions},
+		{"rates", r.Rates},
+	} {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", c.name, c.count.Created, c.count.Updated, c.count.Skipped)
+	}
+	return w.Flush()
+}
+
+// readArchive reads the archive from the file, "-" reads it from the standard input.
+func readArchive(name string) (*backup.Archive, error) {
+	var in io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	a := &backup.Archive{}
+	if err := json.NewDecoder(in).Decode(a); err != nil {
+		return nil, fmt.Errorf("%w: %s", backup.ErrInvalidArchive, err)
+	}
+	return a, nil
+}
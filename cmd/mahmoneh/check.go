@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// runCheck lists the inconsistencies of the data, failing if there are any.
+func (cli *CLI) runCheck(ctx context.Context, args []string) error {
+	if err := parse(flags("check"), args, 0); err != nil {
+		return err
+	}
+	issues, err := cli.consistency.FindIssues(ctx)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(cli.out, "No issues found")
+		return nil
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "CHECK\tENTITY\tUUID\tWORKSPACE\tDETAIL")
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", issue.Check, issue.Entity, issue.UUID, issue.WorkspaceUUID, issue.Detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%d issues found", len(issues))
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/d-ashesss/mah-moneh/internal/capital"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/consistency"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"io"
+	"text/tabwriter"
+)
+
+// CLI runs the commands with the same services as the API server.
+type CLI struct {
+	out         io.Writer
+	users       *users.Service
+	workspaces  *workspaces.Service
+	categories  *categories.Service
+	labels      *labels.Service
+	payees      *payees.Service
+	spendings   *spendings.Service
+	currencies  *currencies.Service
+	backup      *backup.Service
+	consistency *consistency.Service
+}
+
+// NewCLI initializes the services of the commands, the commands write their results to out.
+func NewCLI(db *gorm.DB, out io.Writer) *CLI {
+	txManager := datastore.NewGormTxManager(db)
+	usersService := users.NewService(users.NewGormStore(db))
+	workspacesService := workspaces.NewService(workspaces.NewConfig(), workspaces.NewGormStore(db))
+	auditService := audit.NewService(audit.NewGormStore(db))
+	accountsService := accounts.NewService(accounts.NewGormStore(db), txManager, auditService)
+	categoriesService := categories.NewService(categories.NewGormStore(db), txManager, auditService)
+	payeesService := payees.NewService(payees.NewGormStore(db))
+	transactionsService := transactions.NewService(transactions.NewGormStore(db), txManager, payeesService, auditService)
+	labelsService := labels.NewService(labels.NewGormStore(db))
+	capitalService := capital.NewService(accountsService)
+	spendingsService := spendings.NewService(capitalService, transactionsService, categoriesService, labelsService, payeesService)
+	currenciesService := currencies.NewService(currencies.NewGormStore(db), txManager, auditService)
+	backupService := backup.NewService(txManager, usersService, accountsService, categoriesService, labelsService, payeesService, transactionsService, currenciesService)
+	consistencyService := consistency.NewService(consistency.NewGormStore(db))
+	return &CLI{
+		out:         out,
+		users:       usersService,
+		workspaces:  workspacesService,
+		categories:  categoriesService,
+		labels:      labelsService,
+		payees:      payeesService,
+		spendings:   spendingsService,
+		currencies:  currenciesService,
+		backup:      backupService,
+		consistency: consistencyService,
+	}
+}
+
+// Run runs the command with its arguments.
+func (cli *CLI) Run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "users":
+		return cli.runUsers(ctx, args)
+	case "export":
+		return cli.runExport(ctx, args)
+	case "import":
+		return cli.runImport(ctx, args)
+	case "rates":
+		return cli.runRates(ctx, args)
+	case "report":
+		return cli.runReport(ctx, args)
+	case "check":
+		return cli.runCheck(ctx, args)
+	}
+	return errUsage
+}
+
+// flags initializes the flags of the command, which report errors instead of exiting.
+func flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parse parses the flags of the command, expecting the specified number of positional arguments after them.
+func parse(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil || fs.NArg() != positional {
+		return errUsage
+	}
+	return nil
+}
+
+// workspaceFlags defines the flags selecting the workspace of the user to operate on.
+type workspaceFlags struct {
+	user      *string
+	workspace *string
+}
+
+func newWorkspaceFlags(fs *flag.FlagSet) *workspaceFlags {
+	return &workspaceFlags{
+		user:      fs.String("user", "", "ID of the user"),
+		workspace: fs.String("workspace", "", "UUID of the workspace, the personal workspace of the user by default"),
+	}
+}
+
+// resolve finds the user and the workspace selected by the flags, the user must be a member of the workspace.
+func (f *workspaceFlags) resolve(ctx context.Context, cli *CLI) (*users.User, *workspaces.Workspace, error) {
+	if *f.user == "" {
+		return nil, nil, errUsage
+	}
+	p, err := cli.users.GetProfile(ctx, &users.User{ID: *f.user})
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("unknown user %q", *f.user)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	u := p.User()
+	if *f.workspace == "" {
+		ws, err := cli.workspaces.GetPersonalWorkspace(ctx, u)
+		return u, ws, err
+	}
+	wsUUID, err := uuid.FromString(*f.workspace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid workspace UUID %q", *f.workspace)
+	}
+	m, err := cli.workspaces.GetMember(ctx, wsUUID, u)
+	if err != nil {
+		return nil, nil, fmt.Errorf("workspace %s: %w", wsUUID, err)
+	}
+	return u, m.Workspace, nil
+}
+
+// table initializes the writer aligning the tab-separated columns of the output.
+func (cli *CLI) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(cli.out, 0, 0, 2, ' ', 0)
+}
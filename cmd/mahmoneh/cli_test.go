@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/migrations"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testArchive = `{
+  "version": 1,
+  "accounts": [{"uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c01", "name": "cash", "amounts": [{"month": "2010-10", "currency": "USD", "amount": 100}]}],
+  "categories": [{"uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c02", "name": "food"}],
+  "labels": [{"uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c03", "name": "trip"}],
+  "payees": [{"uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c04", "name": "market", "aliases": ["MARKET LLC"]}],
+  "transactions": [{
+    "uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c05",
+    "month": "2010-10",
+    "date": "2010-10-05",
+    "currency": "USD",
+    "amount": -20,
+    "description": "groceries",
+    "category_uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c02",
+    "account_uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c01",
+    "payee_uuid": "8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c04",
+    "labels": ["8bb2b1a8-9e6f-4a8a-8f6b-0a4d0f1e2c03"]
+  }],
+  "rates": []
+}`
+
+// newTestCLI initializes the CLI with a migrated in-memory DB, the output of the commands is collected in the buffer.
+func newTestCLI(t *testing.T) (*CLI, *bytes.Buffer, *gorm.DB) {
+	t.Helper()
+	db, err := datastore.Open(&datastore.Config{Driver: datastore.DriverSQLite, SQLitePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to open the DB: %s", err)
+	}
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %s", err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Failed to migrate the DB: %s", err)
+	}
+	out := new(bytes.Buffer)
+	return NewCLI(db, out), out, db
+}
+
+// run runs the command expecting it to succeed and returns its output.
+func run(t *testing.T, cli *CLI, out *bytes.Buffer, command string, args ...string) string {
+	t.Helper()
+	out.Reset()
+	if err := cli.Run(context.Background(), command, args); err != nil {
+		t.Fatalf("Run(%s %v) error = %v", command, args, err)
+	}
+	return out.String()
+}
+
+// createUser registers the user with the CLI and returns its ID.
+func createUser(t *testing.T, cli *CLI, out *bytes.Buffer, subject string) string {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(run(t, cli, out, "users", "create", "-issuer", "https://id.example.com", "-subject", subject)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("users create output = %q", out.String())
+	}
+	return strings.Fields(lines[1])[0]
+}
+
+func TestCLI_Usage(t *testing.T) {
+	cli, _, _ := newTestCLI(t)
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+	}{
+		{name: "unknown command", command: "purge"},
+		{name: "users without subcommand", command: "users"},
+		{name: "unknown users subcommand", command: "users", args: []string{"delete"}},
+		{name: "users list with arguments", command: "users", args: []string{"list", "extra"}},
+		{name: "users create without subject", command: "users", args: []string{"create", "-issuer", "https://id.example.com"}},
+		{name: "users create with unknown flag", command: "users", args: []string{"create", "-issuer", "https://id.example.com", "-subject", "1", "-admin"}},
+		{name: "export without user", command: "export"},
+		{name: "import without file", command: "import", args: []string{"-user", "1"}},
+		{name: "rates set without rate", command: "rates", args: []string{"set", "USD", "EUR", "2010-10"}},
+		{name: "report without user", command: "report", args: []string{"-month", "2010-10"}},
+		{name: "check with arguments", command: "check", args: []string{"all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cli.Run(context.Background(), tt.command, tt.args); !errors.Is(err, errUsage) {
+				t.Errorf("Run() error = %v, want usage", err)
+			}
+		})
+	}
+}
+
+func TestCLI_Users(t *testing.T) {
+	cli, out, _ := newTestCLI(t)
+	id := createUser(t, cli, out, "subject-1")
+
+	run(t, cli, out, "users", "create", "-issuer", "https://id.example.com", "-subject", "subject-1", "-email", "user@example.com", "-name", "User")
+	if got := strings.Fields(strings.Split(strings.TrimSpace(out.String()), "\n")[1])[0]; got != id {
+		t.Errorf("users create of the same identity = %s, want %s", got, id)
+	}
+
+	lines := strings.Split(strings.TrimSpace(run(t, cli, out, "users", "list")), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("users list output = %q", out.String())
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) < 5 || fields[0] != id || fields[1] != "https://id.example.com" || fields[2] != "subject-1" || fields[3] != "user@example.com" || fields[4] != "User" {
+		t.Errorf("users list = %q", lines[1])
+	}
+
+	if err := cli.Run(context.Background(), "export", []string{"-user", "unknown"}); err == nil || !strings.Contains(err.Error(), `unknown user "unknown"`) {
+		t.Errorf("export of unknown user error = %v", err)
+	}
+}
+
+func TestCLI_ExportImport(t *testing.T) {
+	cli, out, _ := newTestCLI(t)
+	user1 := createUser(t, cli, out, "subject-1")
+	user2 := createUser(t, cli, out, "subject-2")
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.json")
+	if err := os.WriteFile(input, []byte(testArchive), 0o600); err != nil {
+		t.Fatalf("Failed to write the archive: %s", err)
+	}
+
+	report := run(t, cli, out, "import", "-user", user1, input)
+	if !strings.Contains(report, "transactions  1") {
+		t.Errorf("import report = %q", report)
+	}
+
+	exported := filepath.Join(dir, "exported.json")
+	run(t, cli, out, "export", "-user", user1, "-o", exported)
+	if out.Len() != 0 {
+		t.Errorf("export to the file wrote to the output: %q", out.String())
+	}
+	run(t, cli, out, "import", "-user", user2, exported)
+	run(t, cli, out, "export", "-user", user2)
+
+	a1 := readTestArchive(t, exported)
+	a2 := &backup.Archive{}
+	if err := json.Unmarshal(out.Bytes(), a2); err != nil {
+		t.Fatalf("Failed to decode the exported archive: %s", err)
+	}
+	if len(a2.Accounts) != 1 || len(a2.Categories) != 1 || len(a2.Labels) != 1 || len(a2.Payees) != 1 || len(a2.Transactions) != 1 {
+		t.Fatalf("round-trip archive = %+v", a2)
+	}
+	tx1, tx2 := a1.Transactions[0], a2.Transactions[0]
+	if tx2.Month != tx1.Month || tx2.Date != tx1.Date || tx2.Amount != tx1.Amount || tx2.Description != tx1.Description {
+		t.Errorf("round-trip transaction = %+v, want %+v", tx2, tx1)
+	}
+	if tx2.CategoryUUID == nil || *tx2.CategoryUUID != a2.Categories[0].UUID || tx2.AccountUUID == nil || *tx2.AccountUUID != a2.Accounts[0].UUID {
+		t.Errorf("round-trip transaction references = %+v", tx2)
+	}
+	if len(a2.Accounts[0].Amounts) != 1 || a2.Accounts[0].Amounts[0].Amount != 100 {
+		t.Errorf("round-trip account amounts = %+v", a2.Accounts[0].Amounts)
+	}
+	if a2.Accounts[0].UUID == a1.Accounts[0].UUID {
+		t.Errorf("imported account kept its UUID %s", a2.Accounts[0].UUID)
+	}
+
+	report = run(t, cli, out, "import", "-user", user2, exported)
+	if !strings.Contains(report, "transactions  0        0        1") {
+		t.Errorf("repeated import report = %q", report)
+	}
+
+	if err := cli.Run(context.Background(), "import", []string{"-user", user2, filepath.Join(dir, "missing.json")}); err == nil {
+		t.Errorf("import of a missing file expected error")
+	}
+}
+
+func readTestArchive(t *testing.T, name string) *backup.Archive {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Failed to read the archive: %s", err)
+	}
+	a := &backup.Archive{}
+	if err := json.Unmarshal(data, a); err != nil {
+		t.Fatalf("Failed to decode the archive: %s", err)
+	}
+	return a
+}
+
+func TestCLI_Check(t *testing.T) {
+	cli, out, db := newTestCLI(t)
+	if got := run(t, cli, out, "check"); got != "No issues found\n" {
+		t.Errorf("check of an empty DB = %q", got)
+	}
+
+	user := createUser(t, cli, out, "subject-1")
+	input := filepath.Join(t.TempDir(), "input.json")
+	if err := os.WriteFile(input, []byte(testArchive), 0o600); err != nil {
+		t.Fatalf("Failed to write the archive: %s", err)
+	}
+	run(t, cli, out, "import", "-user", user, input)
+	if got := run(t, cli, out, "check"); got != "No issues found\n" {
+		t.Errorf("check of consistent data = %q", got)
+	}
+
+	if err := db.Exec("UPDATE transactions SET year_month = ?", "2010-09").Error; err != nil {
+		t.Fatalf("Failed to break the transaction: %s", err)
+	}
+	out.Reset()
+	err := cli.Run(context.Background(), "check", nil)
+	if err == nil || err.Error() != "1 issues found" {
+		t.Errorf("check error = %v", err)
+	}
+	if !strings.Contains(out.String(), "transaction_month") || !strings.Contains(out.String(), "month 2010-09 does not match date 2010-10-05") {
+		t.Errorf("check output = %q", out.String())
+	}
+}
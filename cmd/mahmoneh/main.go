@@ -0,0 +1,69 @@
+// Command mahmoneh runs the administrative tasks against the database of the application.
+// It is configured with the same environment variables as the API server.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/migrations"
+	"github.com/d-ashesss/mah-moneh/log"
+	"os"
+)
+
+const usage = `usage: mahmoneh <command> [arguments]
+
+commands:
+  migrate [up | down [steps] | status]
+  users list
+  users create -issuer ISSUER -subject SUBJECT [-email EMAIL] [-name NAME]
+  export -user ID [-workspace UUID] [-o FILE]
+  import -user ID [-workspace UUID] [-strategy skip | overwrite | duplicate] [-rates] FILE
+  rates list
+  rates set BASE TARGET MONTH RATE
+  report -user ID [-workspace UUID] -month MONTH
+  report -user ID [-workspace UUID] -by label | payee -from MONTH -to MONTH
+  check`
+
+var (
+	errUsage = errors.New(usage)
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		log.Fatalf("Invalid database config: %s", err)
+	}
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to the DB: %s", err)
+	}
+	cli := NewCLI(db, os.Stdout)
+	ctx := context.Background()
+
+	command, args := os.Args[1], os.Args[2:]
+	if command == "migrate" {
+		if err := migrations.Run(ctx, db, args); err != nil {
+			log.Fatalf("Failed to migrate the DB: %s", err)
+		}
+		return
+	}
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %s", err)
+	}
+	if err := m.Check(ctx); err != nil {
+		log.Fatalf("The DB is not ready, run migrate first: %s", err)
+	}
+	if err := cli.Run(ctx, command, args); errors.Is(err, errUsage) {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	} else if err != nil {
+		log.Fatalf("Failed to run %s: %s", command, err)
+	}
+}
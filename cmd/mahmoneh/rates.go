@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"strconv"
+	"time"
+)
+
+func (cli *CLI) runRates(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		return cli.runRatesList(ctx, args[1:])
+	case "set":
+		return cli.runRatesSet(ctx, args[1:])
+	}
+	return errUsage
+}
+
+func (cli *CLI) runRatesList(ctx context.Context, args []string) error {
+	if err := parse(flags("rates list"), args, 0); err != nil {
+		return err
+	}
+	rates, err := cli.currencies.GetRates(ctx)
+	if err != nil {
+		return err
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "BASE\tTARGET\tMONTH\tRATE")
+	for _, r := range rates {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%g\n", r.Base, r.Target, r.YearMonth, r.Rate)
+	}
+	return w.Flush()
+}
+
+func (cli *CLI) runRatesSet(ctx context.Context, args []string) error {
+	fs := flags("rates set")
+	if err := parse(fs, args, 4); err != nil {
+		return err
+	}
+	base, target, month := accounts.Currency(fs.Arg(0)), accounts.Currency(fs.Arg(1)), fs.Arg(2)
+	if base == "" || target == "" || base == target {
+		return fmt.Errorf("invalid currencies %q and %q", base, target)
+	}
+	if _, err := time.Parse(accounts.FmtYearMonth, month); err != nil {
+		return fmt.Errorf("invalid month %q", month)
+	}
+	rate, err := strconv.ParseFloat(fs.Arg(3), 64)
+	if err != nil || rate <= 0 {
+		return fmt.Errorf("invalid rate %q", fs.Arg(3))
+	}
+	return cli.currencies.SetRate(ctx, base, target, month, rate)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"io"
+	"sort"
+	"time"
+)
+
+// runReport recomputes the spendings of the workspace the same way the API does.
+// Spendings are computed from the current data on every request, so nothing is stored.
+func (cli *CLI) runReport(ctx context.Context, args []string) error {
+	fs := flags("report")
+	wf := newWorkspaceFlags(fs)
+	month := fs.String("month", "", "month to report spendings by category of")
+	by := fs.String("by", "", "label or payee to report spendings of the period by")
+	from := fs.String("from", "", "first month of the period")
+	to := fs.String("to", "", "last month of the period")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	var months []string
+	switch {
+	case *by == "" && *month != "" && *from == "" && *to == "":
+		months = []string{*month}
+	case (*by == "label" || *by == "payee") && *month == "" && *from != "" && *to != "":
+		months = []string{*from, *to}
+	default:
+		return errUsage
+	}
+	for _, m := range months {
+		if _, err := time.Parse(accounts.FmtYearMonth, m); err != nil {
+			return fmt.Errorf("invalid month %q", m)
+		}
+	}
+	if *to < *from {
+		return fmt.Errorf("period ends at %s before it starts at %s", *to, *from)
+	}
+	_, ws, err := wf.resolve(ctx, cli)
+	if err != nil {
+		return err
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "NAME\tCURRENCY\tAMOUNT")
+	switch *by {
+	case "":
+		cats, err := cli.categories.GetWorkspaceCategories(ctx, ws)
+		if err != nil {
+			return err
+		}
+		spent, err := cli.spendings.GetMonthSpendings(ctx, ws, *month)
+		if err != nil {
+			return err
+		}
+		for _, cat := range cats {
+			writeAmounts(w, cat.Name, spent.GetAmounts(cat))
+		}
+		writeAmounts(w, "(uncategorized)", spent.GetUncategorized())
+		writeAmounts(w, "(unaccounted)", spent.GetUnaccounted())
+	case "label":
+		lbls, err := cli.labels.GetWorkspaceLabels(ctx, ws)
+		if err != nil {
+			return err
+		}
+		spent, err := cli.spendings.GetLabelSpendings(ctx, ws, *from, *to)
+		if err != nil {
+			return err
+		}
+		for _, lbl := range lbls {
+			writeAmounts(w, lbl.Name, spent.GetAmounts(spendings.LabelCategory(lbl)))
+		}
+		writeAmounts(w, "(unlabeled)", spent.GetUncategorized())
+	case "payee":
+		ps, err := cli.payees.GetWorkspacePayees(ctx, ws)
+		if err != nil {
+			return err
+		}
+		spent, err := cli.spendings.GetPayeeSpendings(ctx, ws, *from, *to)
+		if err != nil {
+			return err
+		}
+		for _, p := range ps {
+			writeAmounts(w, p.Name, spent.GetAmounts(spendings.PayeeCategory(p)))
+		}
+		writeAmounts(w, "(unknown)", spent.GetUncategorized())
+	}
+	return w.Flush()
+}
+
+// writeAmounts writes a row per currency of the amounts, in the order of currency codes.
+func writeAmounts(w io.Writer, name string, amounts accounts.CurrencyAmounts) {
+	currencies := make([]string, 0, len(amounts))
+	for c := range amounts {
+		currencies = append(currencies, string(c))
+	}
+	sort.Strings(currencies)
+	for _, c := range currencies {
+		fmt.Fprintf(w, "%s\t%s\t%.2f\n", name, c, amounts[accounts.Currency(c)])
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"time"
+)
+
+func (cli *CLI) runUsers(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		return cli.runUsersList(ctx, args[1:])
+	case "create":
+		return cli.runUsersCreate(ctx, args[1:])
+	}
+	return errUsage
+}
+
+func (cli *CLI) runUsersList(ctx context.Context, args []string) error {
+	if err := parse(flags("users list"), args, 0); err != nil {
+		return err
+	}
+	profiles, err := cli.users.GetProfiles(ctx)
+	if err != nil {
+		return err
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "ID\tISSUER\tSUBJECT\tEMAIL\tNAME\tREGISTERED")
+	for _, p := range profiles {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.ID, p.Issuer, p.Subject, p.Email, p.Name, p.CreatedAt.Format(time.DateOnly))
+	}
+	return w.Flush()
+}
+
+// runUsersCreate registers the user the way the first authentication with the identity provider does,
+// so that the user can sign in later. The existing user with the same identity is updated.
+func (cli *CLI) runUsersCreate(ctx context.Context, args []string) error {
+	fs := flags("users create")
+	claims := &users.Claims{}
+	fs.StringVar(&claims.Issuer, "issuer", "", "issuer of the identity")
+	fs.StringVar(&claims.Subject, "subject", "", "subject of the identity")
+	fs.StringVar(&claims.Email, "email", "", "email of the user")
+	fs.StringVar(&claims.Name, "name", "", "name of the user")
+	if err := parse(fs, args, 0); err != nil || claims.Issuer == "" || claims.Subject == "" {
+		return errUsage
+	}
+	u, err := cli.users.EnsureUser(ctx, claims)
+	if err != nil {
+		return err
+	}
+	ws, err := cli.workspaces.GetPersonalWorkspace(ctx, u)
+	if err != nil {
+		return err
+	}
+	w := cli.table()
+	fmt.Fprintln(w, "ID\tWORKSPACE")
+	fmt.Fprintf(w, "%s\t%s\n", u.ID, ws.UUID)
+	return w.Flush()
+}
@@ -2,25 +2,32 @@ package accounts
 
 import (
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 )
 
 // Account represents an account entity.
 type Account struct {
 	datastore.Model
-	User *users.User `gorm:"embedded;embeddedPrefix:user_"`
-	Name string      `gorm:"notNull"`
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string                `gorm:"notNull"`
 }
 
 // NewAccount initializes a new account.
-func NewAccount(u *users.User, name string) *Account {
-	return &Account{User: u, Name: name}
+func NewAccount(ws *workspaces.Workspace, name string) *Account {
+	return &Account{WorkspaceUUID: ws.UUID, Name: name}
 }
 
 // AccountCollection represents a collection of account entities.
 type AccountCollection []*Account
 
+// Page represents a page of workspace accounts.
+type Page struct {
+	Accounts   AccountCollection
+	NextCursor string
+}
+
 type Currency string
 
 // Amount represents account's amount entity.
@@ -5,8 +5,9 @@ package accounts_test
 import (
 	"context"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -33,7 +34,7 @@ func (ts *AccountsIntegrationTestSuite) SetupSuite() {
 
 	ts.db = db.Session(&gorm.Session{NewDB: true})
 	store := accounts.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
-	ts.srv = accounts.NewService(store)
+	ts.srv = accounts.NewService(store, datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true})), audit.Discard)
 
 	err = db.Migrator().AutoMigrate(&accounts.Amount{}, &accounts.Account{})
 	if err != nil {
@@ -42,48 +43,48 @@ func (ts *AccountsIntegrationTestSuite) SetupSuite() {
 }
 
 func (ts *AccountsIntegrationTestSuite) TestCreateAccount() {
-	u := ts.createTestingUser()
+	ws := ts.createTestingWorkspace()
 
-	acc, err := ts.srv.CreateAccount(context.Background(), u, "test-create-account")
+	acc, err := ts.srv.CreateAccount(context.Background(), ws, "test-create-account")
 	ts.Require().NoError(err, "Failed to create account.")
 
 	foundAcc := &accounts.Account{}
-	err = ts.db.First(foundAcc, "user_id = ? AND name = ?", u.ID, acc.Name).Error
+	err = ts.db.First(foundAcc, "workspace_uuid = ? AND name = ?", ws.UUID, acc.Name).Error
 	ts.Require().NoError(err, "Failed to find the created account.")
 	ts.Equal(acc.UUID, foundAcc.UUID)
 }
 
 func (ts *AccountsIntegrationTestSuite) TestUpdateAccount() {
 	ts.Run("Exists", func() {
-		u := ts.createTestingUser()
-		acc := ts.createTestingAccount(u, "test-create-account")
+		ws := ts.createTestingWorkspace()
+		acc := ts.createTestingAccount(ws, "test-create-account")
 
 		acc.Name = "test-update-account"
 		err := ts.srv.UpdateAccount(context.Background(), acc)
 		ts.Require().NoError(err, "Failed to update account.")
 
 		foundAcc := &accounts.Account{}
-		err = ts.db.First(foundAcc, "user_id = ? AND name = ?", u.ID, acc.Name).Error
+		err = ts.db.First(foundAcc, "workspace_uuid = ? AND name = ?", ws.UUID, acc.Name).Error
 		ts.Require().NoError(err, "Failed to find the updated account.")
 		ts.Equal(acc.UUID, foundAcc.UUID)
 	})
 
 	ts.Run("NotExists", func() {
-		u := ts.createTestingUser()
+		ws := ts.createTestingWorkspace()
 		UUID, _ := uuid.NewV4()
-		acc := &accounts.Account{Model: datastore.Model{UUID: UUID}, User: u, Name: "test-update-account"}
+		acc := &accounts.Account{Model: datastore.Model{UUID: UUID}, WorkspaceUUID: ws.UUID, Name: "test-update-account"}
 		err := ts.srv.UpdateAccount(context.Background(), acc)
 		ts.Require().NoError(err, "Failed to update account.")
 
 		foundAcc := &accounts.Account{}
-		err = ts.db.First(foundAcc, "user_id = ? AND name = ?", u.ID, acc.Name).Error
+		err = ts.db.First(foundAcc, "workspace_uuid = ? AND name = ?", ws.UUID, acc.Name).Error
 		ts.Require().ErrorIs(err, gorm.ErrRecordNotFound, "Non existing account was saved during the update.")
 	})
 }
 
 func (ts *AccountsIntegrationTestSuite) TestDeleteAccount() {
-	u := ts.createTestingUser()
-	protoAcc := ts.createTestingAccount(u, "test-delete-account")
+	ws := ts.createTestingWorkspace()
+	protoAcc := ts.createTestingAccount(ws, "test-delete-account")
 
 	err := ts.srv.DeleteAccount(context.Background(), protoAcc)
 	ts.Require().NoError(err, "Failed to delete account.")
@@ -95,29 +96,29 @@ func (ts *AccountsIntegrationTestSuite) TestDeleteAccount() {
 }
 
 func (ts *AccountsIntegrationTestSuite) TestGetAccount() {
-	u := ts.createTestingUser()
-	protoAcc := ts.createTestingAccount(u, "test-get-account")
+	ws := ts.createTestingWorkspace()
+	protoAcc := ts.createTestingAccount(ws, "test-get-account")
 
 	foundAcc, err := ts.srv.GetAccount(context.Background(), protoAcc.UUID)
 	ts.Require().NoError(err, "Failed to get the account.")
 	ts.Equal(protoAcc.UUID, foundAcc.UUID)
 }
 
-func (ts *AccountsIntegrationTestSuite) TestGetUserAccounts() {
-	u1 := ts.createTestingUser()
-	ts.createTestingAccount(u1, "test-get-user-accounts-1-1")
-	ts.createTestingAccount(u1, "test-get-user-accounts-1-2")
-	u2 := ts.createTestingUser()
-	ts.createTestingAccount(u2, "test-get-user-accounts-2-1")
+func (ts *AccountsIntegrationTestSuite) TestGetWorkspaceAccounts() {
+	ws1 := ts.createTestingWorkspace()
+	ts.createTestingAccount(ws1, "test-get-user-accounts-1-1")
+	ts.createTestingAccount(ws1, "test-get-user-accounts-1-2")
+	ws2 := ts.createTestingWorkspace()
+	ts.createTestingAccount(ws2, "test-get-user-accounts-2-1")
 
-	accs, err := ts.srv.GetUserAccounts(context.Background(), u1)
+	accs, err := ts.srv.GetWorkspaceAccounts(context.Background(), ws1)
 	ts.Require().NoError(err, "Failed to get accounts.")
 	ts.Len(accs, 2, "Invalid set of found accounts.")
 }
 
 func (ts *AccountsIntegrationTestSuite) TestSetAccountAmount() {
-	u := ts.createTestingUser()
-	acc := ts.createTestingAccount(u, "test-set-account-amount")
+	ws := ts.createTestingWorkspace()
+	acc := ts.createTestingAccount(ws, "test-set-account-amount")
 
 	err := ts.srv.SetAccountCurrentAmount(context.Background(), acc, "usd", 10.99)
 	ts.Require().NoError(err, "Failed to set USD amount on the account.")
@@ -147,8 +148,8 @@ func (ts *AccountsIntegrationTestSuite) TestSetAccountAmount() {
 }
 
 func (ts *AccountsIntegrationTestSuite) TestGetAccountAmounts() {
-	u := ts.createTestingUser()
-	acc := ts.createTestingAccount(u, "test-get-account-amounts")
+	ws := ts.createTestingWorkspace()
+	acc := ts.createTestingAccount(ws, "test-get-account-amounts")
 	var (
 		amount  *accounts.Amount
 		amounts accounts.CurrencyAmounts
@@ -205,8 +206,8 @@ func (ts *AccountsIntegrationTestSuite) TestGetAccountAmounts() {
 }
 
 func (ts *AccountsIntegrationTestSuite) TestGetAccountCurrentAmounts() {
-	u := ts.createTestingUser()
-	acc := ts.createTestingAccount(u, "test-set-account-amount")
+	ws := ts.createTestingWorkspace()
+	acc := ts.createTestingAccount(ws, "test-set-account-amount")
 	var (
 		amount  *accounts.Amount
 		amounts accounts.CurrencyAmounts
@@ -243,15 +244,18 @@ func (ts *AccountsIntegrationTestSuite) TestGetAccountCurrentAmounts() {
 	ts.InDelta(27.3, amounts["eur"], 0.001, "Invalid amount on account.")
 }
 
-func (ts *AccountsIntegrationTestSuite) createTestingUser() *users.User {
+func (ts *AccountsIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
 	ts.T().Helper()
-	UUID, _ := uuid.NewV4()
-	return &users.User{ID: UUID.String()}
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
 }
 
-func (ts *AccountsIntegrationTestSuite) createTestingAccount(u *users.User, name string) *accounts.Account {
+func (ts *AccountsIntegrationTestSuite) createTestingAccount(ws *workspaces.Workspace, name string) *accounts.Account {
 	ts.T().Helper()
-	acc := accounts.NewAccount(u, name)
+	acc := accounts.NewAccount(ws, name)
 	err := ts.db.Create(acc).Error
 	ts.Require().NoError(err, "Failed to create testing account.")
 	return acc
@@ -260,3 +264,29 @@ func (ts *AccountsIntegrationTestSuite) createTestingAccount(u *users.User, name
 func TestAccountsIntegration(t *testing.T) {
 	suite.Run(t, new(AccountsIntegrationTestSuite))
 }
+
+func TestGormStore(t *testing.T) {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		t.Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "acc_store_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to the DB: %s", err)
+	}
+	if err := db.Migrator().AutoMigrate(&accounts.Amount{}, &accounts.Account{}); err != nil {
+		t.Fatalf("Failed to migrate required tables: %s", err)
+	}
+	suite.Run(t, &StoreContractSuite{
+		Store: accounts.NewGormStore(db.Session(&gorm.Session{NewDB: true})),
+		Persist: func(records ...any) error {
+			for _, r := range records {
+				if err := db.Create(r).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,191 @@
+package accounts
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryStore is in-memory implementation of AccountStore.
+type memoryStore struct {
+	mu       sync.RWMutex
+	accounts []*Account
+	amounts  []*Amount
+}
+
+// NewMemoryStore initializes in-memory implementation of AccountStore.
+func NewMemoryStore() AccountStore {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) CreateAccount(_ context.Context, acc *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !acc.UUID.IsNil() && s.find(acc.UUID, true) != nil {
+		return datastore.ErrRecordExists
+	}
+	if err := acc.Stamp(time.Now()); err != nil {
+		return err
+	}
+	stored := *acc
+	s.accounts = append(s.accounts, &stored)
+	return nil
+}
+
+func (s *memoryStore) UpdateAccount(_ context.Context, acc *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.find(acc.UUID, false)
+	if stored == nil {
+		return nil
+	}
+	if err := acc.Advance(&stored.Model); err != nil {
+		return err
+	}
+	acc.UpdatedAt = time.Now()
+	stored.UpdatedAt = acc.UpdatedAt
+	stored.Version = acc.Version
+	if !acc.WorkspaceUUID.IsNil() {
+		stored.WorkspaceUUID = acc.WorkspaceUUID
+	}
+	if acc.Name != "" {
+		stored.Name = acc.Name
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteAccount(_ context.Context, acc *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.find(acc.UUID, false)
+	if stored == nil {
+		return nil
+	}
+	if acc.Version != 0 && acc.Version != stored.Version {
+		return datastore.ErrStaleRecord
+	}
+	acc.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	stored.DeletedAt = acc.DeletedAt
+	return nil
+}
+
+func (s *memoryStore) GetAccount(_ context.Context, UUID uuid.UUID) (*Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.find(UUID, false)
+	if stored == nil {
+		return nil, datastore.ErrRecordNotFound
+	}
+	acc := *stored
+	return &acc, nil
+}
+
+func (s *memoryStore) GetWorkspaceAccounts(_ context.Context, ws *workspaces.Workspace) (AccountCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	accs := make(AccountCollection, 0)
+	for _, stored := range s.accounts {
+		if stored.WorkspaceUUID == ws.UUID && !stored.DeletedAt.Valid {
+			acc := *stored
+			accs = append(accs, &acc)
+		}
+	}
+	return accs, nil
+}
+
+func (s *memoryStore) GetWorkspaceAccountsPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	after, err := p.After()
+	if err != nil {
+		return nil, err
+	}
+	accs, err := s.GetWorkspaceAccounts(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(accs, func(i, j int) bool {
+		return accs[i].Precedes(&accs[j].Model)
+	})
+	limit := p.GetLimit()
+	page := &Page{Accounts: make(AccountCollection, 0, limit)}
+	for _, acc := range accs {
+		if after != nil && !after.Precedes(&acc.Model) {
+			continue
+		}
+		if len(page.Accounts) == limit {
+			page.NextCursor = page.Accounts[limit-1].PageCursor()
+			break
+		}
+		page.Accounts = append(page.Accounts, acc)
+	}
+	return page, nil
+}
+
+func (s *memoryStore) SetAccountAmount(_ context.Context, acc *Account, month string, currency Currency, amount float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.amounts {
+		if a.AccountUUID == acc.UUID && a.YearMonth == month && a.CurrencyCode == currency {
+			a.Amount = amount
+			return nil
+		}
+	}
+	s.amounts = append(s.amounts, &Amount{AccountUUID: acc.UUID, YearMonth: month, CurrencyCode: currency, Amount: amount})
+	return nil
+}
+
+func (s *memoryStore) GetAccountAmounts(_ context.Context, acc *Account, month string) (AmountCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	latest := make(map[Currency]*Amount)
+	for _, a := range s.amounts {
+		if a.AccountUUID != acc.UUID || a.YearMonth > month {
+			continue
+		}
+		if l, ok := latest[a.CurrencyCode]; !ok || a.YearMonth > l.YearMonth {
+			latest[a.CurrencyCode] = a
+		}
+	}
+	amounts := make(AmountCollection, 0, len(latest))
+	for _, a := range latest {
+		amount := *a
+		amounts = append(amounts, &amount)
+	}
+	sort.Slice(amounts, func(i, j int) bool {
+		return amounts[i].CurrencyCode < amounts[j].CurrencyCode
+	})
+	return amounts, nil
+}
+
+func (s *memoryStore) GetAccountAmountHistory(_ context.Context, acc *Account) (AmountCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	amounts := make(AmountCollection, 0)
+	for _, a := range s.amounts {
+		if a.AccountUUID == acc.UUID {
+			amount := *a
+			amounts = append(amounts, &amount)
+		}
+	}
+	sort.Slice(amounts, func(i, j int) bool {
+		if amounts[i].YearMonth != amounts[j].YearMonth {
+			return amounts[i].YearMonth < amounts[j].YearMonth
+		}
+		return amounts[i].CurrencyCode < amounts[j].CurrencyCode
+	})
+	return amounts, nil
+}
+
+// find looks up the stored account, the deleted ones are only included when requested.
+func (s *memoryStore) find(UUID uuid.UUID, withDeleted bool) *Account {
+	for _, acc := range s.accounts {
+		if acc.UUID == UUID && (withDeleted || !acc.DeletedAt.Valid) {
+			return acc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package accounts_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	suite.Run(t, &StoreContractSuite{Store: accounts.NewMemoryStore()})
+}
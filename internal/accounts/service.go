@@ -2,7 +2,10 @@ package accounts
 
 import (
 	"context"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"time"
 )
@@ -10,46 +13,99 @@ import (
 const FmtYearMonth = "2006-01"
 
 // Service is a service responsible for managing accounts.
+// Changes of accounts and their amounts are recorded to the audit log along with the changes themselves.
 type Service struct {
-	db AccountStore
+	db    AccountStore
+	txm   datastore.TxManager
+	audit audit.Recorder
 }
 
 // NewService initializes a new accounts service.
-func NewService(db AccountStore) *Service {
-	return &Service{db: db}
+func NewService(db AccountStore, txm datastore.TxManager, rec audit.Recorder) *Service {
+	return &Service{db: db, txm: txm, audit: rec}
 }
 
-func (s *Service) CreateAccount(ctx context.Context, u *users.User, name string) (*Account, error) {
-	acc := NewAccount(u, name)
-	if err := s.db.CreateAccount(ctx, acc); err != nil {
+func (s *Service) CreateAccount(ctx context.Context, ws *workspaces.Workspace, name string) (*Account, error) {
+	acc := NewAccount(ws, name)
+	err := s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.CreateAccount(ctx, acc); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Created(audit.EntityAccount, acc.UUID.String(), &acc.WorkspaceUUID, acc))
+	})
+	if err != nil {
 		return nil, err
 	}
 	return acc, nil
 }
 
+// UpdateAccount saves changes of the account, missing accounts are left as is.
 func (s *Service) UpdateAccount(ctx context.Context, acc *Account) error {
-	return s.db.UpdateAccount(ctx, acc)
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		before, err := s.db.GetAccount(ctx, acc.UUID)
+		if errors.Is(err, datastore.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.db.UpdateAccount(ctx, acc); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Updated(audit.EntityAccount, acc.UUID.String(), &acc.WorkspaceUUID, before, acc))
+	})
 }
 
 func (s *Service) DeleteAccount(ctx context.Context, acc *Account) error {
-	return s.db.DeleteAccount(ctx, acc)
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.DeleteAccount(ctx, acc); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Deleted(audit.EntityAccount, acc.UUID.String(), &acc.WorkspaceUUID, acc))
+	})
 }
 
 func (s *Service) GetAccount(ctx context.Context, UUID uuid.UUID) (*Account, error) {
 	return s.db.GetAccount(ctx, UUID)
 }
 
-func (s *Service) GetUserAccounts(ctx context.Context, u *users.User) (AccountCollection, error) {
-	return s.db.GetUserAccounts(ctx, u)
+func (s *Service) GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (AccountCollection, error) {
+	return s.db.GetWorkspaceAccounts(ctx, ws)
+}
+
+func (s *Service) GetWorkspaceAccountsPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	return s.db.GetWorkspaceAccountsPage(ctx, ws, p)
 }
 
+// SetAccountAmount sets the amount of funds on the account in specified month.
+// The audit log gets the amount the account had in the month before the change, which might be carried from earlier months.
 func (s *Service) SetAccountAmount(ctx context.Context, acc *Account, month string, currency Currency, amount float64) error {
-	return s.db.SetAccountAmount(ctx, acc, month, currency, amount)
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		amounts, err := s.db.GetAccountAmounts(ctx, acc, month)
+		if err != nil {
+			return err
+		}
+		var before *Amount
+		for _, a := range amounts {
+			if a.CurrencyCode == currency {
+				before = &Amount{AccountUUID: acc.UUID, YearMonth: a.YearMonth, CurrencyCode: currency, Amount: a.Amount}
+			}
+		}
+		if err := s.db.SetAccountAmount(ctx, acc, month, currency, amount); err != nil {
+			return err
+		}
+		after := &Amount{AccountUUID: acc.UUID, YearMonth: month, CurrencyCode: currency, Amount: amount}
+		ch := audit.Updated(audit.EntityAmount, acc.UUID.String(), &acc.WorkspaceUUID, before, after)
+		if before == nil {
+			ch = audit.Created(audit.EntityAmount, acc.UUID.String(), &acc.WorkspaceUUID, after)
+		}
+		return s.audit.Record(ctx, ch)
+	})
 }
 
 func (s *Service) SetAccountCurrentAmount(ctx context.Context, acc *Account, currency Currency, amount float64) error {
 	month := time.Now().Format(FmtYearMonth)
-	return s.db.SetAccountAmount(ctx, acc, month, currency, amount)
+	return s.SetAccountAmount(ctx, acc, month, currency, amount)
 }
 
 func (s *Service) GetAccountAmounts(ctx context.Context, acc *Account, month string) (CurrencyAmounts, error) {
@@ -60,6 +116,11 @@ func (s *Service) GetAccountAmounts(ctx context.Context, acc *Account, month str
 	return amounts.GetCurrencyAmounts(), nil
 }
 
+// GetAccountAmountHistory provides all the amounts recorded for the account ordered by month.
+func (s *Service) GetAccountAmountHistory(ctx context.Context, acc *Account) (AmountCollection, error) {
+	return s.db.GetAccountAmountHistory(ctx, acc)
+}
+
 func (s *Service) GetAccountCurrentAmounts(ctx context.Context, acc *Account) (CurrencyAmounts, error) {
 	month := time.Now().Format(FmtYearMonth)
 	return s.GetAccountAmounts(ctx, acc, month)
@@ -2,10 +2,13 @@ package accounts_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/accounts"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -15,30 +18,57 @@ import (
 type AccountsServiceTestSuite struct {
 	suite.Suite
 	store *mocks.AccountStore
+	audit *auditmocks.Recorder
 	srv   *accounts.Service
 }
 
 func (ts *AccountsServiceTestSuite) SetupTest() {
 	ts.store = mocks.NewAccountStore(ts.T())
-	ts.srv = accounts.NewService(ts.store)
+	ts.audit = auditmocks.NewRecorder(ts.T())
+	ts.srv = accounts.NewService(ts.store, datastore.NewNopTxManager(), ts.audit)
+}
+
+// expectChange expects the change of the entity to be recorded to the audit log.
+func (ts *AccountsServiceTestSuite) expectChange(action audit.Action, entity audit.Entity) *mock.Call {
+	return ts.audit.On("Record", mock.Anything, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == action && ch.Entity == entity
+	})).Return(nil).Once()
 }
 
 func (ts *AccountsServiceTestSuite) TestCreateAccount() {
 	ctx := context.Background()
 	ts.store.On("CreateAccount", ctx, mock.AnythingOfType("*accounts.Account")).
 		Return(nil).Once()
+	ts.expectChange(audit.ActionCreate, audit.EntityAccount)
 
-	u := &users.User{}
-	_, err := ts.srv.CreateAccount(ctx, u, "")
+	ws := &workspaces.Workspace{}
+	_, err := ts.srv.CreateAccount(ctx, ws, "")
 	ts.Require().NoError(err, "Failed to create account.")
 }
 
+func (ts *AccountsServiceTestSuite) TestCreateAccount_AuditError() {
+	ctx := context.Background()
+	errFailed := fmt.Errorf("failed")
+	ts.store.On("CreateAccount", ctx, mock.AnythingOfType("*accounts.Account")).
+		Return(nil).Once()
+	ts.audit.On("Record", ctx, mock.AnythingOfType("*audit.Change")).Return(errFailed).Once()
+
+	_, err := ts.srv.CreateAccount(ctx, &workspaces.Workspace{}, "")
+	ts.ErrorIs(err, errFailed)
+}
+
 func (ts *AccountsServiceTestSuite) TestUpdateAccount() {
 	ctx := context.Background()
+	before := &accounts.Account{Name: "cash"}
+	ts.store.On("GetAccount", ctx, uuid.Nil).Return(before, nil).Once()
 	ts.store.On("UpdateAccount", ctx, mock.AnythingOfType("*accounts.Account")).
 		Return(nil).Once()
+	ts.expectChange(audit.ActionUpdate, audit.EntityAccount).Run(func(args mock.Arguments) {
+		ch := args.Get(1).(*audit.Change)
+		ts.Equal(before, ch.Before)
+	})
 
-	acc := &accounts.Account{}
+	acc := &accounts.Account{Name: "wallet"}
 	err := ts.srv.UpdateAccount(ctx, acc)
 	ts.Require().NoError(err, "Failed to update account.")
 }
@@ -47,6 +77,7 @@ func (ts *AccountsServiceTestSuite) TestDeleteAccount() {
 	ctx := context.Background()
 	ts.store.On("DeleteAccount", ctx, mock.AnythingOfType("*accounts.Account")).
 		Return(nil).Once()
+	ts.expectChange(audit.ActionDelete, audit.EntityAccount)
 
 	acc := &accounts.Account{}
 	err := ts.srv.DeleteAccount(ctx, acc)
@@ -65,22 +96,55 @@ func (ts *AccountsServiceTestSuite) TestGetAccount() {
 	ts.Equal(protoAcc, acc)
 }
 
-func (ts *AccountsServiceTestSuite) TestGetUserAccounts() {
+func (ts *AccountsServiceTestSuite) TestGetWorkspaceAccounts() {
 	ctx := context.Background()
-	ts.store.On("GetUserAccounts", ctx, mock.AnythingOfType("*users.User")).
+	ts.store.On("GetWorkspaceAccounts", ctx, mock.AnythingOfType("*workspaces.Workspace")).
 		Return(accounts.AccountCollection{}, nil).Once()
 
-	u := &users.User{}
-	accs, err := ts.srv.GetUserAccounts(ctx, u)
+	ws := &workspaces.Workspace{}
+	accs, err := ts.srv.GetWorkspaceAccounts(ctx, ws)
 	ts.Require().NoError(err, "Failed to get user accounts.")
 	ts.NotNil(accs)
 }
 
+func (ts *AccountsServiceTestSuite) TestGetWorkspaceAccountsPage() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	p := &datastore.PageRequest{Limit: 10}
+	ts.store.On("GetWorkspaceAccountsPage", ctx, ws, p).
+		Return(&accounts.Page{Accounts: accounts.AccountCollection{}, NextCursor: "next"}, nil).Once()
+
+	page, err := ts.srv.GetWorkspaceAccountsPage(ctx, ws, p)
+	ts.Require().NoError(err, "Failed to get page of accounts.")
+	ts.NotNil(page.Accounts)
+	ts.Equal("next", page.NextCursor)
+}
+
+func (ts *AccountsServiceTestSuite) TestSetAccountAmount() {
+	ctx := context.Background()
+	acc := &accounts.Account{}
+	amounts := accounts.AmountCollection{{YearMonth: "2009-12", CurrencyCode: "usd", Amount: 5}}
+	ts.store.On("GetAccountAmounts", ctx, acc, "2010-01").Return(amounts, nil).Once()
+	ts.store.On("SetAccountAmount", ctx, acc, "2010-01", accounts.Currency("usd"), 10.0).
+		Return(nil).Once()
+	ts.expectChange(audit.ActionUpdate, audit.EntityAmount).Run(func(args mock.Arguments) {
+		ch := args.Get(1).(*audit.Change)
+		ts.Equal(5.0, ch.Before.(*accounts.Amount).Amount)
+		ts.Equal(10.0, ch.After.(*accounts.Amount).Amount)
+	})
+
+	err := ts.srv.SetAccountAmount(ctx, acc, "2010-01", "usd", 10)
+	ts.Require().NoError(err, "Failed to set amount on the account.")
+}
+
 func (ts *AccountsServiceTestSuite) TestSetAccountCurrentAmount() {
 	ctx := context.Background()
 	acc := &accounts.Account{}
+	ts.store.On("GetAccountAmounts", ctx, acc, mock.AnythingOfType("string")).
+		Return(accounts.AmountCollection{}, nil).Once()
 	ts.store.On("SetAccountAmount", ctx, acc, mock.AnythingOfType("string"), accounts.Currency("usd"), 10.0).
 		Return(nil).Once()
+	ts.expectChange(audit.ActionCreate, audit.EntityAmount)
 
 	err := ts.srv.SetAccountCurrentAmount(ctx, acc, "usd", 10)
 	ts.Require().NoError(err, "Failed to set amount on the account.")
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -20,12 +20,16 @@ type AccountStore interface {
 	DeleteAccount(ctx context.Context, acc *Account) error
 	// GetAccount retrieves accounts by its UUID.
 	GetAccount(ctx context.Context, UUID uuid.UUID) (*Account, error)
-	// GetUserAccounts retrieves all user accounts.
-	GetUserAccounts(ctx context.Context, u *users.User) (AccountCollection, error)
+	// GetWorkspaceAccounts retrieves all workspace accounts.
+	GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (AccountCollection, error)
+	// GetWorkspaceAccountsPage retrieves a page of workspace accounts ordered by their creation time.
+	GetWorkspaceAccountsPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error)
 	// SetAccountAmount sets the amount of funds on the account.
 	SetAccountAmount(ctx context.Context, acc *Account, month string, currency Currency, amount float64) error
 	// GetAccountAmounts retrieves amount of funds for each currency on the account for the specified month.
 	GetAccountAmounts(ctx context.Context, acc *Account, month string) (AmountCollection, error)
+	// GetAccountAmountHistory retrieves all the amounts recorded for the account.
+	GetAccountAmountHistory(ctx context.Context, acc *Account) (AmountCollection, error)
 }
 
 // gormStore is GORM implementation of AccountStore.
@@ -39,20 +43,24 @@ func NewGormStore(db *gorm.DB) AccountStore {
 }
 
 func (s *gormStore) CreateAccount(ctx context.Context, acc *Account) error {
-	return s.db.WithContext(ctx).Create(acc).Error
+	return datastore.Conn(ctx, s.db).Create(acc).Error
 }
 
 func (s *gormStore) UpdateAccount(ctx context.Context, acc *Account) error {
-	return s.db.WithContext(ctx).Where("uuid = ?", acc.UUID).Updates(acc).Error
+	err := datastore.Update(datastore.Conn(ctx, s.db), acc)
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		return nil
+	}
+	return err
 }
 
 func (s *gormStore) DeleteAccount(ctx context.Context, acc *Account) error {
-	return s.db.WithContext(ctx).Delete(acc).Error
+	return datastore.Delete(datastore.Conn(ctx, s.db), acc)
 }
 
 func (s *gormStore) GetAccount(ctx context.Context, UUID uuid.UUID) (*Account, error) {
 	acc := &Account{}
-	err := s.db.WithContext(ctx).Where("uuid = ?", UUID).First(acc).Error
+	err := datastore.Conn(ctx, s.db).Where("uuid = ?", UUID).First(acc).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, datastore.ErrRecordNotFound
 	}
@@ -62,17 +70,34 @@ func (s *gormStore) GetAccount(ctx context.Context, UUID uuid.UUID) (*Account, e
 	return acc, nil
 }
 
-func (s *gormStore) GetUserAccounts(ctx context.Context, u *users.User) (AccountCollection, error) {
+func (s *gormStore) GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (AccountCollection, error) {
 	accs := make(AccountCollection, 0)
-	if err := s.db.WithContext(ctx).Find(&accs, "user_id = ?", u.ID).Error; err != nil {
+	if err := datastore.Conn(ctx, s.db).Find(&accs, "workspace_uuid = ?", ws.UUID).Error; err != nil {
 		return nil, err
 	}
 	return accs, nil
 }
 
+func (s *gormStore) GetWorkspaceAccountsPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	query, err := p.Paginate(datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID))
+	if err != nil {
+		return nil, err
+	}
+	accs := make(AccountCollection, 0)
+	if err := query.Find(&accs).Error; err != nil {
+		return nil, err
+	}
+	page := &Page{Accounts: accs}
+	if limit := p.GetLimit(); len(accs) > limit {
+		page.Accounts = accs[:limit]
+		page.NextCursor = accs[limit-1].PageCursor()
+	}
+	return page, nil
+}
+
 func (s *gormStore) SetAccountAmount(ctx context.Context, acc *Account, month string, currency Currency, amount float64) error {
 	a := &Amount{Account: acc, YearMonth: month, CurrencyCode: currency, Amount: amount}
-	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+	return datastore.Conn(ctx, s.db).Clauses(clause.OnConflict{
 		Columns: []clause.Column{
 			{Name: "account_uuid"},
 			{Name: "currency_code"},
@@ -84,7 +109,7 @@ func (s *gormStore) SetAccountAmount(ctx context.Context, acc *Account, month st
 
 func (s *gormStore) GetAccountAmount(ctx context.Context, acc *Account, month string, currency Currency) (*Amount, error) {
 	amount := &Amount{}
-	if err := s.db.WithContext(ctx).
+	if err := datastore.Conn(ctx, s.db).
 		Where("account_uuid = ?", acc.UUID).
 		Where("year_month <= ?", month).
 		Where("currency_code = ?", currency).
@@ -114,9 +139,22 @@ func (s *gormStore) GetAccountAmounts(ctx context.Context, acc *Account, month s
 	return amounts, nil
 }
 
+func (s *gormStore) GetAccountAmountHistory(ctx context.Context, acc *Account) (AmountCollection, error) {
+	amounts := make(AmountCollection, 0)
+	err := datastore.Conn(ctx, s.db).
+		Where("account_uuid = ?", acc.UUID).
+		Order("year_month").
+		Order("currency_code").
+		Find(&amounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
 func (s *gormStore) GetAccountCurrencies(ctx context.Context, acc *Account) ([]Currency, error) {
 	var currencies []Currency
-	err := s.db.WithContext(ctx).
+	err := datastore.Conn(ctx, s.db).
 		Model(&Amount{}).
 		Distinct().
 		Where("account_uuid = ?", acc.UUID).
@@ -0,0 +1,267 @@
+package accounts_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"sync"
+)
+
+// StoreContractSuite verifies the behavior every AccountStore implementation must have.
+type StoreContractSuite struct {
+	suite.Suite
+	Store accounts.AccountStore
+	// Persist saves the records the accounts refer to, if the store requires them to exist.
+	Persist func(records ...any) error
+}
+
+func (ts *StoreContractSuite) TestCreateAccount() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := accounts.NewAccount(ws, "cash")
+	err := ts.Store.CreateAccount(ctx, acc)
+	ts.Require().NoError(err, "Failed to create account.")
+	ts.Require().False(acc.UUID.IsNil(), "Account UUID was not generated.")
+	ts.False(acc.CreatedAt.IsZero(), "Account creation time was not set.")
+
+	found, err := ts.Store.GetAccount(ctx, acc.UUID)
+	ts.Require().NoError(err, "Failed to get account.")
+	ts.Equal(acc.UUID, found.UUID)
+	ts.Equal(ws.UUID, found.WorkspaceUUID)
+	ts.Equal("cash", found.Name)
+}
+
+func (ts *StoreContractSuite) TestGetAccount_NotFound() {
+	_, err := ts.Store.GetAccount(context.Background(), uuid.Must(uuid.NewV4()))
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *StoreContractSuite) TestUpdateAccount() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := ts.account(ws, "cash")
+
+	acc.Name = "wallet"
+	err := ts.Store.UpdateAccount(ctx, acc)
+	ts.Require().NoError(err, "Failed to update account.")
+	found, err := ts.Store.GetAccount(ctx, acc.UUID)
+	ts.Require().NoError(err, "Failed to get account.")
+	ts.Equal("wallet", found.Name)
+
+	missing := &accounts.Account{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}, WorkspaceUUID: ws.UUID, Name: "missing"}
+	err = ts.Store.UpdateAccount(ctx, missing)
+	ts.Require().NoError(err, "Failed to update non-existing account.")
+	_, err = ts.Store.GetAccount(ctx, missing.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound, "Non-existing account was created by the update.")
+}
+
+func (ts *StoreContractSuite) TestUpdateAccount_Stale() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := ts.account(ws, "cash")
+	stale := *acc
+
+	acc.Name = "wallet"
+	err := ts.Store.UpdateAccount(ctx, acc)
+	ts.Require().NoError(err, "Failed to update account.")
+	ts.EqualValues(2, acc.Version)
+
+	stale.Name = "savings"
+	err = ts.Store.UpdateAccount(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	err = ts.Store.DeleteAccount(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	found, err := ts.Store.GetAccount(ctx, acc.UUID)
+	ts.Require().NoError(err, "Failed to get account.")
+	ts.Equal("wallet", found.Name)
+	ts.EqualValues(2, found.Version)
+}
+
+func (ts *StoreContractSuite) TestDeleteAccount() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := ts.account(ws, "cash")
+
+	err := ts.Store.DeleteAccount(ctx, acc)
+	ts.Require().NoError(err, "Failed to delete account.")
+	_, err = ts.Store.GetAccount(ctx, acc.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+	accs, err := ts.Store.GetWorkspaceAccounts(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace accounts.")
+	ts.Empty(accs)
+}
+
+func (ts *StoreContractSuite) TestGetWorkspaceAccounts() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	ts.account(ws, "cash")
+	ts.account(ws, "card")
+	ts.account(ts.workspace(), "other")
+
+	accs, err := ts.Store.GetWorkspaceAccounts(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace accounts.")
+	ts.Len(accs, 2)
+	for _, acc := range accs {
+		ts.Equal(ws.UUID, acc.WorkspaceUUID)
+	}
+
+	accs, err = ts.Store.GetWorkspaceAccounts(ctx, ts.workspace())
+	ts.Require().NoError(err, "Failed to get workspace accounts.")
+	ts.NotNil(accs)
+	ts.Empty(accs)
+}
+
+func (ts *StoreContractSuite) TestGetWorkspaceAccountsPage() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	created := make(map[uuid.UUID]bool)
+	for _, name := range []string{"cash", "card", "bank", "savings", "wallet"} {
+		created[ts.account(ws, name).UUID] = true
+	}
+	ts.account(ts.workspace(), "other")
+
+	p := &datastore.PageRequest{Limit: 2}
+	paged := make(accounts.AccountCollection, 0)
+	for pages := 1; ; pages++ {
+		page, err := ts.Store.GetWorkspaceAccountsPage(ctx, ws, p)
+		ts.Require().NoError(err, "Failed to get page of workspace accounts.")
+		ts.Require().LessOrEqual(len(page.Accounts), 2)
+		paged = append(paged, page.Accounts...)
+		if page.NextCursor == "" {
+			ts.Equal(3, pages)
+			break
+		}
+		p.Cursor = page.NextCursor
+	}
+	ts.Require().Len(paged, len(created))
+	for i, acc := range paged {
+		ts.True(created[acc.UUID], "Unexpected account in the pages.")
+		if i > 0 {
+			ts.True(paged[i-1].Precedes(&acc.Model), "Accounts are out of order.")
+		}
+	}
+
+	page, err := ts.Store.GetWorkspaceAccountsPage(ctx, ts.workspace(), &datastore.PageRequest{})
+	ts.Require().NoError(err, "Failed to get page of workspace accounts.")
+	ts.NotNil(page.Accounts)
+	ts.Empty(page.Accounts)
+	ts.Empty(page.NextCursor)
+
+	_, err = ts.Store.GetWorkspaceAccountsPage(ctx, ws, &datastore.PageRequest{Cursor: "invalid"})
+	ts.ErrorIs(err, datastore.ErrInvalidCursor)
+}
+
+func (ts *StoreContractSuite) TestAccountAmounts() {
+	ctx := context.Background()
+	acc := ts.account(ts.workspace(), "cash")
+	for _, a := range []struct {
+		month    string
+		currency accounts.Currency
+		amount   float64
+	}{
+		{"2010-08", "usd", 10},
+		{"2010-10", "usd", 30},
+		{"2010-10", "usd", 35},
+		{"2010-09", "eur", 20},
+		{"2010-12", "eur", 40},
+	} {
+		err := ts.Store.SetAccountAmount(ctx, acc, a.month, a.currency, a.amount)
+		ts.Require().NoError(err, "Failed to set account amount.")
+	}
+
+	tests := []struct {
+		month string
+		want  accounts.CurrencyAmounts
+	}{
+		{month: "2010-07", want: accounts.CurrencyAmounts{}},
+		{month: "2010-08", want: accounts.CurrencyAmounts{"usd": 10}},
+		{month: "2010-09", want: accounts.CurrencyAmounts{"usd": 10, "eur": 20}},
+		{month: "2010-11", want: accounts.CurrencyAmounts{"usd": 35, "eur": 20}},
+		{month: "2011-01", want: accounts.CurrencyAmounts{"usd": 35, "eur": 40}},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.month, func() {
+			amounts, err := ts.Store.GetAccountAmounts(ctx, acc, tt.month)
+			ts.Require().NoError(err, "Failed to get account amounts.")
+			ts.Equal(tt.want, amounts.GetCurrencyAmounts())
+		})
+	}
+}
+
+func (ts *StoreContractSuite) TestGetAccountAmountHistory() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := ts.account(ws, "cash")
+	other := ts.account(ws, "bank")
+	ts.Require().NoError(ts.Store.SetAccountAmount(ctx, acc, "2010-10", "usd", 30))
+	ts.Require().NoError(ts.Store.SetAccountAmount(ctx, acc, "2010-08", "usd", 10))
+	ts.Require().NoError(ts.Store.SetAccountAmount(ctx, acc, "2010-08", "eur", 20))
+	ts.Require().NoError(ts.Store.SetAccountAmount(ctx, other, "2010-09", "usd", 50))
+
+	amounts, err := ts.Store.GetAccountAmountHistory(ctx, acc)
+	ts.Require().NoError(err, "Failed to get account amount history.")
+	ts.Require().Len(amounts, 3)
+	ts.Equal("2010-08", amounts[0].YearMonth)
+	ts.Equal(accounts.Currency("eur"), amounts[0].CurrencyCode)
+	ts.Equal("2010-08", amounts[1].YearMonth)
+	ts.Equal(accounts.Currency("usd"), amounts[1].CurrencyCode)
+	ts.Equal("2010-10", amounts[2].YearMonth)
+	ts.InDelta(30, amounts[2].Amount, 0.001)
+}
+
+func (ts *StoreContractSuite) TestConcurrentWrites() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	acc := ts.account(ws, "cash")
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- ts.Store.CreateAccount(ctx, accounts.NewAccount(ws, "concurrent"))
+			errs <- ts.Store.SetAccountAmount(ctx, acc, "2010-10", "usd", float64(i))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		ts.Require().NoError(err, "Failed to write concurrently.")
+	}
+
+	accs, err := ts.Store.GetWorkspaceAccounts(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace accounts.")
+	ts.Len(accs, n+1)
+	amounts, err := ts.Store.GetAccountAmounts(ctx, acc, "2010-10")
+	ts.Require().NoError(err, "Failed to get account amounts.")
+	ts.Len(amounts, 1)
+}
+
+func (ts *StoreContractSuite) workspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}, Name: "test"}
+	ts.persist(ws)
+	return ws
+}
+
+func (ts *StoreContractSuite) account(ws *workspaces.Workspace, name string) *accounts.Account {
+	ts.T().Helper()
+	acc := accounts.NewAccount(ws, name)
+	err := ts.Store.CreateAccount(context.Background(), acc)
+	ts.Require().NoError(err, "Failed to create testing account.")
+	return acc
+}
+
+func (ts *StoreContractSuite) persist(records ...any) {
+	ts.T().Helper()
+	if ts.Persist == nil {
+		return
+	}
+	err := ts.Persist(records...)
+	ts.Require().NoError(err, "Failed to persist testing records.")
+}
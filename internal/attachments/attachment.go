@@ -0,0 +1,40 @@
+package attachments
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+)
+
+// Attachment represents a file, such as a receipt, attached to a transaction.
+type Attachment struct {
+	datastore.Model
+	WorkspaceUUID   uuid.UUID                 `gorm:"type:uuid;index"`
+	Workspace       *workspaces.Workspace     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TransactionUUID uuid.UUID                 `gorm:"type:uuid;notNull;index"`
+	Transaction     *transactions.Transaction `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name            string                    `gorm:"notNull"`
+	ContentType     string                    `gorm:"notNull"`
+	Size            int64                     `gorm:"notNull"`
+}
+
+// NewAttachment initializes a new transaction attachment, which belongs to the same workspace as the transaction.
+func NewAttachment(tx *transactions.Transaction, name, contentType string, size int64) *Attachment {
+	return &Attachment{
+		WorkspaceUUID:   tx.WorkspaceUUID,
+		TransactionUUID: tx.UUID,
+		Transaction:     tx,
+		Name:            name,
+		ContentType:     contentType,
+		Size:            size,
+	}
+}
+
+// BlobKey returns the key the attachment content is stored under in the blob storage.
+func (a *Attachment) BlobKey() string {
+	return a.UUID.String()
+}
+
+// AttachmentCollection represents a collection of attachment entities.
+type AttachmentCollection []*Attachment
@@ -0,0 +1,14 @@
+package attachments
+
+import "github.com/joeshaw/envdecode"
+
+type Config struct {
+	MaxSize      int64    `env:"ATTACHMENTS_MAX_SIZE,default=10485760"`
+	AllowedTypes []string `env:"ATTACHMENTS_ALLOWED_TYPES,default=image/jpeg;image/png;image/gif;image/webp;application/pdf"`
+}
+
+func NewConfig() *Config {
+	cfg := Config{}
+	_ = envdecode.Decode(&cfg)
+	return &cfg
+}
@@ -0,0 +1,92 @@
+//go:build integration
+
+package attachments_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"io"
+	"testing"
+)
+
+type AttachmentsIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *attachments.Service
+}
+
+func (ts *AttachmentsIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "att_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := attachments.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	cfg := &attachments.Config{MaxSize: 1024, AllowedTypes: []string{"image/png"}}
+	ts.srv = attachments.NewService(cfg, store, blobs.NewLocalStorage(ts.T().TempDir()))
+
+	err = db.Migrator().AutoMigrate(&labels.Label{}, &payees.Payee{}, &payees.Alias{}, &transactions.Transaction{}, &transactions.Split{}, &attachments.Attachment{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *AttachmentsIntegrationTestSuite) TestCreateAttachment() {
+	ws := ts.createTestingWorkspace()
+	tx := ts.createTestingTransaction(ws)
+	ctx := context.Background()
+	content := append(pngHeader, []byte("image data")...)
+
+	a, err := ts.srv.CreateAttachment(ctx, tx, "receipt.png", bytes.NewReader(content))
+	ts.Require().NoError(err, "Failed to create attachment.")
+
+	found, err := ts.srv.GetAttachment(ctx, a.UUID)
+	ts.Require().NoError(err, "Failed to find created attachment.")
+	ts.Equal("receipt.png", found.Name)
+	ts.Equal("image/png", found.ContentType)
+	ts.Equal(int64(len(content)), found.Size)
+
+	r, err := ts.srv.OpenAttachment(ctx, found)
+	ts.Require().NoError(err, "Failed to open attachment content.")
+	defer func() { _ = r.Close() }()
+	stored, err := io.ReadAll(r)
+	ts.Require().NoError(err, "Failed to read attachment content.")
+	ts.Equal(content, stored)
+}
+
+func (ts *AttachmentsIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func (ts *AttachmentsIntegrationTestSuite) createTestingTransaction(ws *workspaces.Workspace) *transactions.Transaction {
+	ts.T().Helper()
+	tx := transactions.NewTransaction(ws, "2010-01", "usd", -10, "new headphones", nil)
+	if err := ts.db.Save(tx).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing transaction: %s", err)
+	}
+	return tx
+}
+
+func TestAttachmentsIntegration(t *testing.T) {
+	suite.Run(t, new(AttachmentsIntegrationTestSuite))
+}
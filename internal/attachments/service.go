@@ -0,0 +1,114 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/gofrs/uuid"
+	"io"
+	"mime"
+	"net/http"
+)
+
+var (
+	ErrTooLarge              = errors.New("attachment exceeds the maximum allowed size")
+	ErrContentTypeNotAllowed = errors.New("attachment content type is not allowed")
+)
+
+// sniffLen is the amount of content needed to detect its type.
+const sniffLen = 512
+
+// Service is a service responsible for managing transaction attachments.
+type Service struct {
+	db           Store
+	blobs        blobs.Storage
+	maxSize      int64
+	allowedTypes map[string]bool
+}
+
+// NewService initializes a new attachments service.
+func NewService(cfg *Config, db Store, blobStorage blobs.Storage) *Service {
+	allowedTypes := make(map[string]bool, len(cfg.AllowedTypes))
+	for _, t := range cfg.AllowedTypes {
+		allowedTypes[t] = true
+	}
+	return &Service{db: db, blobs: blobStorage, maxSize: cfg.MaxSize, allowedTypes: allowedTypes}
+}
+
+// MaxSize returns the maximum allowed size of attachment content in bytes.
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+// CreateAttachment stores the content and attaches it to the transaction.
+// The content type is detected from the content itself, the type declared by the client is not trusted.
+func (s *Service) CreateAttachment(ctx context.Context, tx *transactions.Transaction, name string, r io.Reader) (*Attachment, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !s.allowedTypes[contentType] {
+		return nil, ErrContentTypeNotAllowed
+	}
+
+	a := NewAttachment(tx, name, contentType, 0)
+	if a.UUID, err = uuid.NewV4(); err != nil {
+		return nil, err
+	}
+	content := &countingReader{r: io.LimitReader(io.MultiReader(bytes.NewReader(head), r), s.maxSize+1)}
+	if err := s.blobs.Put(ctx, a.BlobKey(), content); err != nil {
+		return nil, fmt.Errorf("failed to store attachment content: %w", err)
+	}
+	if content.n > s.maxSize {
+		_ = s.blobs.Delete(ctx, a.BlobKey())
+		return nil, ErrTooLarge
+	}
+	a.Size = content.n
+	if err := s.db.SaveAttachment(ctx, a); err != nil {
+		_ = s.blobs.Delete(ctx, a.BlobKey())
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *Service) GetAttachment(ctx context.Context, UUID uuid.UUID) (*Attachment, error) {
+	return s.db.GetAttachment(ctx, UUID)
+}
+
+func (s *Service) GetTransactionAttachments(ctx context.Context, tx *transactions.Transaction) (AttachmentCollection, error) {
+	return s.db.GetTransactionAttachments(ctx, tx)
+}
+
+// OpenAttachment opens the attachment content for reading, the reader must be closed by the caller.
+func (s *Service) OpenAttachment(ctx context.Context, a *Attachment) (io.ReadCloser, error) {
+	return s.blobs.Get(ctx, a.BlobKey())
+}
+
+// DeleteAttachment deletes the attachment along with its content.
+func (s *Service) DeleteAttachment(ctx context.Context, a *Attachment) error {
+	if err := s.db.DeleteAttachment(ctx, a); err != nil {
+		return err
+	}
+	if err := s.blobs.Delete(ctx, a.BlobKey()); err != nil && !errors.Is(err, blobs.ErrBlobNotFound) {
+		return fmt.Errorf("failed to delete attachment content: %w", err)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
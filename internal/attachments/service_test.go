@@ -0,0 +1,103 @@
+package attachments_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	attmocks "github.com/d-ashesss/mah-moneh/internal/mocks/attachments"
+	blobmocks "github.com/d-ashesss/mah-moneh/internal/mocks/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"testing"
+)
+
+// pngHeader is the signature of a PNG image.
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+type AttachmentsServiceTestSuite struct {
+	suite.Suite
+	store *attmocks.Store
+	blobs *blobmocks.Storage
+	srv   *attachments.Service
+}
+
+func (ts *AttachmentsServiceTestSuite) SetupTest() {
+	ts.store = attmocks.NewStore(ts.T())
+	ts.blobs = blobmocks.NewStorage(ts.T())
+	cfg := &attachments.Config{MaxSize: 64, AllowedTypes: []string{"image/png", "application/pdf"}}
+	ts.srv = attachments.NewService(cfg, ts.store, ts.blobs)
+}
+
+func (ts *AttachmentsServiceTestSuite) consumeBlob(args mock.Arguments) {
+	_, _ = io.ReadAll(args.Get(2).(io.Reader))
+}
+
+func (ts *AttachmentsServiceTestSuite) TestCreateAttachment() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{
+		Model:         datastore.Model{UUID: uuid.Must(uuid.NewV4())},
+		WorkspaceUUID: uuid.Must(uuid.NewV4()),
+	}
+	content := append(pngHeader, []byte("image data")...)
+	ts.blobs.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything).Run(ts.consumeBlob).Return(nil)
+	ts.store.On("SaveAttachment", ctx, mock.AnythingOfType("*attachments.Attachment")).Return(nil)
+
+	a, err := ts.srv.CreateAttachment(ctx, tx, "receipt.png", bytes.NewReader(content))
+	ts.Require().NoError(err, "Failed to create attachment.")
+	ts.Equal("receipt.png", a.Name)
+	ts.Equal("image/png", a.ContentType)
+	ts.Equal(int64(len(content)), a.Size)
+	ts.Equal(tx.UUID, a.TransactionUUID)
+	ts.Equal(tx.WorkspaceUUID, a.WorkspaceUUID)
+	ts.NotEqual(uuid.Nil, a.UUID)
+}
+
+func (ts *AttachmentsServiceTestSuite) TestCreateAttachment_ContentTypeNotAllowed() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{}
+	_, err := ts.srv.CreateAttachment(ctx, tx, "receipt.png", bytes.NewBufferString("plain text"))
+	ts.ErrorIs(err, attachments.ErrContentTypeNotAllowed)
+}
+
+func (ts *AttachmentsServiceTestSuite) TestCreateAttachment_TooLarge() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{}
+	content := append(pngHeader, bytes.Repeat([]byte{0}, 64)...)
+	ts.blobs.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything).Run(ts.consumeBlob).Return(nil)
+	ts.blobs.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil)
+
+	_, err := ts.srv.CreateAttachment(ctx, tx, "receipt.png", bytes.NewReader(content))
+	ts.ErrorIs(err, attachments.ErrTooLarge)
+}
+
+func (ts *AttachmentsServiceTestSuite) TestCreateAttachment_SaveFailed() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{}
+	saveErr := errors.New("test error")
+	ts.blobs.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything).Run(ts.consumeBlob).Return(nil)
+	ts.store.On("SaveAttachment", ctx, mock.AnythingOfType("*attachments.Attachment")).Return(saveErr)
+	ts.blobs.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil)
+
+	_, err := ts.srv.CreateAttachment(ctx, tx, "receipt.png", bytes.NewReader(pngHeader))
+	ts.ErrorIs(err, saveErr)
+}
+
+func (ts *AttachmentsServiceTestSuite) TestDeleteAttachment() {
+	ctx := context.Background()
+	a := &attachments.Attachment{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	ts.store.On("DeleteAttachment", ctx, a).Return(nil)
+	ts.blobs.On("Delete", ctx, a.UUID.String()).Return(blobs.ErrBlobNotFound)
+
+	err := ts.srv.DeleteAttachment(ctx, a)
+	ts.Require().NoError(err, "Failed to delete attachment.")
+}
+
+func TestAttachmentsService(t *testing.T) {
+	suite.Run(t, new(AttachmentsServiceTestSuite))
+}
@@ -0,0 +1,54 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+type Store interface {
+	SaveAttachment(ctx context.Context, a *Attachment) error
+	DeleteAttachment(ctx context.Context, a *Attachment) error
+	GetAttachment(ctx context.Context, UUID uuid.UUID) (*Attachment, error)
+	GetTransactionAttachments(ctx context.Context, tx *transactions.Transaction) (AttachmentCollection, error)
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) SaveAttachment(ctx context.Context, a *Attachment) error {
+	return datastore.Conn(ctx, s.db).Omit("Transaction").Save(a).Error
+}
+
+func (s *gormStore) DeleteAttachment(ctx context.Context, a *Attachment) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db).Unscoped(), a)
+}
+
+func (s *gormStore) GetAttachment(ctx context.Context, UUID uuid.UUID) (*Attachment, error) {
+	var a Attachment
+	err := datastore.Conn(ctx, s.db).Where("uuid = ?", UUID).First(&a).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *gormStore) GetTransactionAttachments(ctx context.Context, tx *transactions.Transaction) (AttachmentCollection, error) {
+	atts := make(AttachmentCollection, 0)
+	err := datastore.Conn(ctx, s.db).Where("transaction_uuid = ?", tx.UUID).Order("created_at").Find(&atts).Error
+	if err != nil {
+		return nil, err
+	}
+	return atts, nil
+}
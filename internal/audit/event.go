@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+)
+
+// Action defines what has happened to the record.
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+// Entity defines the kind of the changed record.
+type Entity string
+
+const (
+	EntityAccount     Entity = "account"
+	EntityAmount      Entity = "amount"
+	EntityCategory    Entity = "category"
+	EntityTransaction Entity = "transaction"
+	EntityRate        Entity = "rate"
+)
+
+// Event represents a recorded change of the data.
+type Event struct {
+	datastore.Model
+	// WorkspaceUUID is the workspace the record belongs to, nil for the data shared by all workspaces like rates.
+	WorkspaceUUID *uuid.UUID `gorm:"type:uuid;index"`
+	// UserID is the user who has made the change, empty for the changes made by the app itself.
+	UserID   string `gorm:"notNull"`
+	Action   Action `gorm:"notNull"`
+	Entity   Entity `gorm:"notNull;index"`
+	EntityID string `gorm:"notNull"`
+	// Before is the JSON snapshot of the record before the change, empty for created records.
+	Before string `gorm:"notNull"`
+	// After is the JSON snapshot of the record after the change, empty for deleted records.
+	After string `gorm:"notNull"`
+}
+
+// EventCollection represents a collection of audit events.
+type EventCollection []*Event
+
+// Change describes a change of the data to be recorded.
+type Change struct {
+	Action        Action
+	Entity        Entity
+	EntityID      string
+	WorkspaceUUID *uuid.UUID
+	Before        any
+	After         any
+}
+
+// Created describes creation of the record.
+func Created(e Entity, id string, ws *uuid.UUID, after any) *Change {
+	return &Change{Action: ActionCreate, Entity: e, EntityID: id, WorkspaceUUID: ws, After: after}
+}
+
+// Updated describes modification of the record.
+func Updated(e Entity, id string, ws *uuid.UUID, before, after any) *Change {
+	return &Change{Action: ActionUpdate, Entity: e, EntityID: id, WorkspaceUUID: ws, Before: before, After: after}
+}
+
+// Deleted describes deletion of the record.
+func Deleted(e Entity, id string, ws *uuid.UUID, before any) *Change {
+	return &Change{Action: ActionDelete, Entity: e, EntityID: id, WorkspaceUUID: ws, Before: before}
+}
+
+// Restored describes restoration of the deleted record.
+func Restored(e Entity, id string, ws *uuid.UUID, after any) *Change {
+	return &Change{Action: ActionRestore, Entity: e, EntityID: id, WorkspaceUUID: ws, After: after}
+}
@@ -0,0 +1,27 @@
+package audit
+
+import "time"
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Filter defines criteria for looking up audit events.
+type Filter struct {
+	Entity Entity
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+}
+
+// GetLimit provides the number of events to look up within allowed range.
+func (f *Filter) GetLimit() int {
+	if f.Limit <= 0 {
+		return DefaultLimit
+	}
+	if f.Limit > MaxLimit {
+		return MaxLimit
+	}
+	return f.Limit
+}
@@ -0,0 +1,156 @@
+//go:build integration
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type AuditIntegrationTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	txm      datastore.TxManager
+	srv      *audit.Service
+	accounts *accounts.Service
+}
+
+func (ts *AuditIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "audit_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	ts.txm = datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = audit.NewService(audit.NewGormStore(db.Session(&gorm.Session{NewDB: true})))
+	ts.accounts = accounts.NewService(accounts.NewGormStore(db.Session(&gorm.Session{NewDB: true})), ts.txm, ts.srv)
+
+	err = db.Migrator().AutoMigrate(&accounts.Account{}, &accounts.Amount{}, &audit.Event{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *AuditIntegrationTestSuite) TestRecordChanges() {
+	ws := ts.createTestingWorkspace()
+	ctx := users.NewContext(context.Background(), &users.User{ID: "user1"})
+
+	acc, err := ts.accounts.CreateAccount(ctx, ws, "cash")
+	ts.Require().NoError(err, "Failed to create account.")
+	acc.Name = "wallet"
+	ts.Require().NoError(ts.accounts.UpdateAccount(ctx, acc), "Failed to update account.")
+	ts.Require().NoError(ts.accounts.SetAccountAmount(ctx, acc, "2010-01", "USD", 10), "Failed to set account amount.")
+	ts.Require().NoError(ts.accounts.DeleteAccount(ctx, acc), "Failed to delete account.")
+
+	events, err := ts.srv.GetWorkspaceEvents(context.Background(), ws, &audit.Filter{Entity: audit.EntityAccount})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Require().Len(events, 3)
+	actions := []audit.Action{events[0].Action, events[1].Action, events[2].Action}
+	ts.ElementsMatch([]audit.Action{audit.ActionCreate, audit.ActionUpdate, audit.ActionDelete}, actions)
+	for _, e := range events {
+		ts.Equal("user1", e.UserID)
+		ts.Equal(acc.UUID.String(), e.EntityID)
+		if e.Action == audit.ActionUpdate {
+			ts.Contains(e.Before, `"Name":"cash"`)
+			ts.Contains(e.After, `"Name":"wallet"`)
+		}
+	}
+
+	amounts, err := ts.srv.GetWorkspaceEvents(context.Background(), ws, &audit.Filter{Entity: audit.EntityAmount})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Require().Len(amounts, 1)
+	ts.Equal(audit.ActionCreate, amounts[0].Action)
+	ts.Empty(amounts[0].Before)
+
+	all, err := ts.srv.GetWorkspaceEvents(context.Background(), ws, &audit.Filter{})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Len(all, 4)
+}
+
+func (ts *AuditIntegrationTestSuite) TestRecordChanges_Rollback() {
+	ws := ts.createTestingWorkspace()
+	errFailed := fmt.Errorf("failed")
+
+	err := ts.txm.InTx(context.Background(), func(ctx context.Context) error {
+		if _, err := ts.accounts.CreateAccount(ctx, ws, "cash"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	ts.Require().ErrorIs(err, errFailed)
+
+	events, err := ts.srv.GetWorkspaceEvents(context.Background(), ws, &audit.Filter{})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Empty(events, "Audit events must be rolled back along with the changes.")
+}
+
+func (ts *AuditIntegrationTestSuite) TestGetWorkspaceEvents_TimeRange() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
+	now := time.Now()
+	ts.createEvent(ws1, audit.EntityCategory, now.Add(-2*time.Hour))
+	recent := ts.createEvent(ws1, audit.EntityCategory, now.Add(-time.Hour))
+	ts.createEvent(ws1, audit.EntityCategory, now)
+	ts.createEvent(ws2, audit.EntityCategory, now.Add(-time.Hour))
+
+	from, to := now.Add(-90*time.Minute), now.Add(-time.Minute)
+	events, err := ts.srv.GetWorkspaceEvents(context.Background(), ws1, &audit.Filter{From: &from, To: &to})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Require().Len(events, 1)
+	ts.Equal(recent.UUID, events[0].UUID)
+
+	events, err = ts.srv.GetWorkspaceEvents(context.Background(), ws1, &audit.Filter{Limit: 2})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Require().Len(events, 2)
+	ts.True(events[0].CreatedAt.After(events[1].CreatedAt), "Events must be ordered newest first.")
+}
+
+func (ts *AuditIntegrationTestSuite) TestGetWorkspaceEvents_Shared() {
+	ws := ts.createTestingWorkspace()
+	e := &audit.Event{Action: audit.ActionCreate, Entity: audit.EntityRate, EntityID: "USD/EUR/2010-01"}
+	ts.Require().NoError(ts.db.Create(e).Error, "Failed to create testing event.")
+	defer ts.db.Delete(e)
+
+	events, err := ts.srv.GetWorkspaceEvents(context.Background(), ws, &audit.Filter{Entity: audit.EntityRate})
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Require().Len(events, 1)
+	ts.Equal(e.UUID, events[0].UUID)
+}
+
+func (ts *AuditIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func (ts *AuditIntegrationTestSuite) createEvent(ws *workspaces.Workspace, entity audit.Entity, at time.Time) *audit.Event {
+	ts.T().Helper()
+	e := &audit.Event{WorkspaceUUID: &ws.UUID, Action: audit.ActionCreate, Entity: entity, EntityID: "test"}
+	e.CreatedAt = at
+	if err := ts.db.Create(e).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing event: %s", err)
+	}
+	return e
+}
+
+func TestAuditIntegration(t *testing.T) {
+	suite.Run(t, new(AuditIntegrationTestSuite))
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+)
+
+// Recorder records changes of the data.
+type Recorder interface {
+	// Record saves the change made by the user acting in the context.
+	Record(ctx context.Context, ch *Change) error
+}
+
+// Discard is a Recorder which drops all the changes.
+var Discard Recorder = discard{}
+
+type discard struct{}
+
+func (discard) Record(context.Context, *Change) error {
+	return nil
+}
+
+// Service is a service responsible for the audit log.
+type Service struct {
+	db Store
+}
+
+// NewService initializes a new audit service.
+func NewService(db Store) *Service {
+	return &Service{db: db}
+}
+
+// Record saves the change as an audit event on behalf of the user acting in the context.
+// The event is saved in the DB transaction active in the context, so it is only kept along with the change.
+func (s *Service) Record(ctx context.Context, ch *Change) error {
+	e := &Event{
+		WorkspaceUUID: ch.WorkspaceUUID,
+		Action:        ch.Action,
+		Entity:        ch.Entity,
+		EntityID:      ch.EntityID,
+	}
+	if u := users.FromContext(ctx); u != nil {
+		e.UserID = u.ID
+	}
+	var err error
+	if e.Before, err = snapshot(ch.Before); err != nil {
+		return err
+	}
+	if e.After, err = snapshot(ch.After); err != nil {
+		return err
+	}
+	return s.db.CreateEvent(ctx, e)
+}
+
+// snapshot encodes the state of the record, which is empty for missing records.
+func snapshot(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode record snapshot: %w", err)
+	}
+	if string(b) == "null" {
+		return "", nil
+	}
+	return string(b), nil
+}
+
+// GetWorkspaceEvents provides the latest changes of workspace data matching the filter,
+// including the changes of the data shared by all workspaces like rates.
+func (s *Service) GetWorkspaceEvents(ctx context.Context, ws *workspaces.Workspace, f *Filter) (EventCollection, error) {
+	return s.db.GetWorkspaceEvents(ctx, ws, f)
+}
@@ -0,0 +1,80 @@
+package audit_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *audit.Service
+}
+
+func (ts *AuditServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = audit.NewService(ts.store)
+}
+
+func (ts *AuditServiceTestSuite) TestRecord() {
+	ctx := users.NewContext(context.Background(), &users.User{ID: "user1"})
+	wsUUID := uuid.Must(uuid.NewV4())
+	before := map[string]any{"name": "cash"}
+	after := map[string]any{"name": "wallet"}
+	ts.store.On("CreateEvent", ctx, mock.AnythingOfType("*audit.Event")).Run(func(args mock.Arguments) {
+		e := args.Get(1).(*audit.Event)
+		ts.Equal("user1", e.UserID)
+		ts.Equal(&wsUUID, e.WorkspaceUUID)
+		ts.Equal(audit.ActionUpdate, e.Action)
+		ts.Equal(audit.EntityAccount, e.Entity)
+		ts.Equal("acc1", e.EntityID)
+		ts.JSONEq(`{"name":"cash"}`, e.Before)
+		ts.JSONEq(`{"name":"wallet"}`, e.After)
+	}).Return(nil).Once()
+
+	err := ts.srv.Record(ctx, audit.Updated(audit.EntityAccount, "acc1", &wsUUID, before, after))
+	ts.Require().NoError(err, "Failed to record the change.")
+}
+
+func (ts *AuditServiceTestSuite) TestRecord_NoUser() {
+	ctx := context.Background()
+	var before *struct{}
+	ts.store.On("CreateEvent", ctx, mock.AnythingOfType("*audit.Event")).Run(func(args mock.Arguments) {
+		e := args.Get(1).(*audit.Event)
+		ts.Empty(e.UserID)
+		ts.Nil(e.WorkspaceUUID)
+		ts.Empty(e.Before, "Missing record must have empty snapshot.")
+		ts.JSONEq(`{"rate":1.1}`, e.After)
+	}).Return(nil).Once()
+
+	err := ts.srv.Record(ctx, audit.Updated(audit.EntityRate, "usd/eur/2010-10", nil, before, map[string]float64{"rate": 1.1}))
+	ts.Require().NoError(err, "Failed to record the change.")
+}
+
+func (ts *AuditServiceTestSuite) TestRecord_InvalidSnapshot() {
+	err := ts.srv.Record(context.Background(), audit.Created(audit.EntityAccount, "acc1", nil, func() {}))
+	ts.Error(err)
+}
+
+func (ts *AuditServiceTestSuite) TestGetWorkspaceEvents() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	f := &audit.Filter{Entity: audit.EntityCategory}
+	events := audit.EventCollection{{Entity: audit.EntityCategory}}
+	ts.store.On("GetWorkspaceEvents", ctx, ws, f).Return(events, nil).Once()
+
+	got, err := ts.srv.GetWorkspaceEvents(ctx, ws, f)
+	ts.Require().NoError(err, "Failed to get audit events.")
+	ts.Equal(events, got)
+}
+
+func TestAuditService(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"gorm.io/gorm"
+)
+
+// Store is an interface for audit events DB API.
+type Store interface {
+	// CreateEvent saves audit event into the DB.
+	CreateEvent(ctx context.Context, e *Event) error
+	// GetWorkspaceEvents retrieves the latest events of the workspace matching the filter, newest first.
+	// Changes of the data shared by all workspaces are included.
+	GetWorkspaceEvents(ctx context.Context, ws *workspaces.Workspace, f *Filter) (EventCollection, error)
+}
+
+// gormStore is GORM implementation of Store.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore initializes GORM implementation of Store.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) CreateEvent(ctx context.Context, e *Event) error {
+	return datastore.Conn(ctx, s.db).Create(e).Error
+}
+
+func (s *gormStore) GetWorkspaceEvents(ctx context.Context, ws *workspaces.Workspace, f *Filter) (EventCollection, error) {
+	query := datastore.Conn(ctx, s.db).Where("workspace_uuid = ? OR workspace_uuid IS NULL", ws.UUID)
+	if f.Entity != "" {
+		query = query.Where("entity = ?", f.Entity)
+	}
+	if f.From != nil {
+		query = query.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("created_at < ?", *f.To)
+	}
+	var events EventCollection
+	err := query.Order("created_at DESC").Order("uuid").Limit(f.GetLimit()).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -1,9 +1,36 @@
 package auth
 
-import "github.com/joeshaw/envdecode"
+import (
+	"github.com/joeshaw/envdecode"
+	"time"
+)
 
 type Config struct {
-	OpenIDConfigurationUrl string `env:"AUTH_OPENID_CONFIGURATION_URL"`
+	// OpenIDConfigurationUrls are the discovery documents of the identity providers whose tokens are accepted.
+	OpenIDConfigurationUrls []string `env:"AUTH_OPENID_CONFIGURATION_URL"`
+	// Issuers restrict the accepted token issuers, all the identity providers are accepted when empty.
+	Issuers []string `env:"AUTH_ISSUERS"`
+	// LegacyIssuer is the issuer of the identity provider used before several providers were supported,
+	// whose users are identified by their subjects. It is the issuer of the first identity provider by default.
+	LegacyIssuer string `env:"AUTH_LEGACY_ISSUER"`
+	// Audience is the audience the tokens must be intended for, not checked when empty.
+	Audience string `env:"AUTH_AUDIENCE"`
+	// Algorithms are the accepted token signing algorithms.
+	Algorithms []string `env:"AUTH_ALGORITHMS,default=RS256"`
+	// ClockSkew is the tolerated difference between the clocks of the app and the identity provider.
+	ClockSkew time.Duration `env:"AUTH_CLOCK_SKEW,default=30s"`
+	// DevMode enables the built-in token issuer for local development. Never enable it in production.
+	DevMode bool `env:"AUTH_DEV_MODE"`
+	// DevIssuer is the issuer of the tokens minted in development mode.
+	DevIssuer string `env:"AUTH_DEV_ISSUER,default=http://localhost:8080"`
+	// DevTokenTTL is how long the tokens minted in development mode are valid.
+	DevTokenTTL time.Duration `env:"AUTH_DEV_TOKEN_TTL,default=24h"`
+	// KeysMinRefreshInterval is the shortest time the keys are cached for, regardless of the cache headers.
+	KeysMinRefreshInterval time.Duration `env:"AUTH_KEYS_MIN_REFRESH_INTERVAL,default=5m"`
+	// KeysRefreshCooldown limits how often the keys are refreshed due to tokens signed with unknown keys.
+	KeysRefreshCooldown time.Duration `env:"AUTH_KEYS_REFRESH_COOLDOWN,default=1m"`
+	// KeysRetryInterval is how long to wait before retrying to load the keys after a failure.
+	KeysRetryInterval time.Duration `env:"AUTH_KEYS_RETRY_INTERVAL,default=10s"`
 }
 
 func NewConfig() *Config {
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/log"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeysNotLoaded = errors.New("keys of the identity provider are not loaded yet")
+	ErrUnknownKey    = errors.New("unknown signing key")
+)
+
+// keyStore provides the keys to verify token signatures with.
+// Keys of the identity provider are cached and refreshed in the background according to the cache headers
+// of the JWKS endpoint, and also on demand when a token is signed with a key that is not known yet.
+type keyStore struct {
+	static             jwk.Set
+	algorithms         []string
+	minRefreshInterval time.Duration
+	refreshCooldown    time.Duration
+
+	mu      sync.RWMutex
+	cache   *jwk.Cache
+	jwksURI string
+	// lastRefresh is the time of the last refresh caused by an unknown key.
+	lastRefresh time.Time
+}
+
+func newKeyStore(cfg *Config) *keyStore {
+	algorithms := cfg.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = []string{jwa.RS256.String()}
+	}
+	return &keyStore{
+		static:             jwk.NewSet(),
+		algorithms:         algorithms,
+		minRefreshInterval: cfg.KeysMinRefreshInterval,
+		refreshCooldown:    cfg.KeysRefreshCooldown,
+	}
+}
+
+// AddKey adds a key that is trusted along with the keys of the identity provider.
+func (ks *keyStore) AddKey(k jwk.Key) error {
+	return ks.static.AddKey(k)
+}
+
+// hasKeys checks whether any keys were added manually.
+func (ks *keyStore) hasKeys() bool {
+	return ks.static.Len() > 0
+}
+
+// load fetches the keys from the JWKS URI and keeps them fresh until the context is done.
+func (ks *keyStore) load(ctx context.Context, jwksURI string) error {
+	cache := jwk.NewCache(ctx, jwk.WithErrSink(keysErrSink{}), jwk.WithRefreshWindow(ks.minRefreshInterval))
+	if err := cache.Register(jwksURI, jwk.WithMinRefreshInterval(ks.minRefreshInterval)); err != nil {
+		return err
+	}
+	set, err := cache.Refresh(ctx, jwksURI)
+	if err != nil {
+		return err
+	}
+	log.Infof("[AUTH] Loaded %d keys from %s", set.Len(), jwksURI)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.cache = cache
+	ks.jwksURI = jwksURI
+	return nil
+}
+
+// loaded checks whether the keys of the identity provider are available.
+func (ks *keyStore) loaded() bool {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.cache != nil
+}
+
+// lookup finds the key by its ID, refreshing the keys of the identity provider if the key is not known yet.
+// Refreshes are rate limited, so that tokens with made up key IDs cannot flood the identity provider.
+func (ks *keyStore) lookup(ctx context.Context, kid string) (jwk.Key, error) {
+	if k, ok := ks.static.LookupKeyID(kid); ok {
+		return k, nil
+	}
+	ks.mu.RLock()
+	cache, jwksURI := ks.cache, ks.jwksURI
+	ks.mu.RUnlock()
+	if cache == nil {
+		return nil, ErrUnknownKey
+	}
+	set, err := cache.Get(ctx, jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := set.LookupKeyID(kid); ok {
+		return k, nil
+	}
+	if !ks.allowRefresh() {
+		return nil, ErrUnknownKey
+	}
+	log.Infof("[AUTH] Refreshing keys from %s to find key %q", jwksURI, kid)
+	set, err = cache.Refresh(ctx, jwksURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh keys: %w", err)
+	}
+	if k, ok := set.LookupKeyID(kid); ok {
+		return k, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (ks *keyStore) allowRefresh() bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	now := time.Now()
+	if now.Sub(ks.lastRefresh) < ks.refreshCooldown {
+		return false
+	}
+	ks.lastRefresh = now
+	return true
+}
+
+// FetchKeys implements jws.KeyProvider.
+func (ks *keyStore) FetchKeys(ctx context.Context, sink jws.KeySink, sig *jws.Signature, _ *jws.Message) error {
+	if tokenAlg := sig.ProtectedHeaders().Algorithm(); !contains(ks.algorithms, tokenAlg.String()) {
+		return fmt.Errorf("%w: %s", ErrAlgorithmNotAllowed, tokenAlg)
+	}
+	kid := sig.ProtectedHeaders().KeyID()
+	if kid == "" {
+		return fmt.Errorf("%w: no key ID in token", ErrUnknownKey)
+	}
+	k, err := ks.lookup(ctx, kid)
+	if err != nil {
+		return err
+	}
+	if v := k.Algorithm(); v.String() != "" {
+		var alg jwa.SignatureAlgorithm
+		if err := alg.Accept(v); err != nil {
+			return fmt.Errorf("invalid signature algorithm %s: %w", v, err)
+		}
+		sink.Key(alg, k)
+		return nil
+	}
+	algs, err := jws.AlgorithmsForKey(k)
+	if err != nil {
+		return err
+	}
+	for _, alg := range algs {
+		if alg == sig.ProtectedHeaders().Algorithm() {
+			sink.Key(alg, k)
+			return nil
+		}
+	}
+	return fmt.Errorf("algorithm of the token does not match the key %q", kid)
+}
+
+type keysErrSink struct{}
+
+func (keysErrSink) Error(err error) {
+	log.Warningf("[AUTH] Failed to refresh keys: %s", err)
+}
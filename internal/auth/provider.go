@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/log"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type openidConfiguration struct {
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+}
+
+// provider is an OpenID identity provider whose tokens are accepted.
+type provider struct {
+	configURL string
+	keys      *keyStore
+
+	mu sync.RWMutex
+	// issuer is the issuer announced by the discovery document.
+	issuer string
+}
+
+func newProvider(cfg *Config, configURL string) *provider {
+	return &provider{
+		configURL: configURL,
+		keys:      newKeyStore(cfg),
+	}
+}
+
+// run loads the provider, retrying until it succeeds or the context is done.
+func (p *provider) run(ctx context.Context, retryInterval time.Duration) {
+	for {
+		err := p.load(ctx)
+		if err == nil {
+			return
+		}
+		log.Warningf("[AUTH] Failed to load keys of %s, retrying in %s: %s", p.configURL, retryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// load fetches the discovery document and the keys of the provider.
+func (p *provider) load(ctx context.Context) error {
+	openidCfg, err := fetchOpenIDConf(ctx, p.configURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch OpenID configuration: %w", err)
+	}
+	if openidCfg.JwksUri == "" {
+		return fmt.Errorf("no JWKS URI in OpenID configuration")
+	}
+	log.Infof("[AUTH] Fetching keys of %s from %s", openidCfg.Issuer, openidCfg.JwksUri)
+	if err := p.keys.load(ctx, openidCfg.JwksUri); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.issuer = openidCfg.Issuer
+	return nil
+}
+
+// ready checks whether the keys of the provider are loaded.
+func (p *provider) ready() bool {
+	return p.keys.loaded()
+}
+
+// matches checks whether the tokens of the issuer are issued by the provider.
+func (p *provider) matches(issuer string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.issuer != "" && p.issuer == issuer
+}
+
+func fetchOpenIDConf(ctx context.Context, url string) (*openidConfiguration, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", res.Status)
+	}
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var cfg openidConfiguration
+	if err := json.Unmarshal(buf, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -2,86 +2,235 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
 	"github.com/d-ashesss/mah-moneh/internal/users"
-	"github.com/d-ashesss/mah-moneh/log"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
-	"io"
-	"net/http"
+	"sync"
 	"time"
 )
 
+var (
+	ErrAlgorithmNotAllowed = errors.New("token signing algorithm is not allowed")
+	ErrInvalidIssuer       = errors.New("token is issued by unexpected issuer")
+	ErrInvalidAudience     = errors.New("token is not intended for this audience")
+	ErrTokenExpired        = errors.New("token has expired")
+	ErrTokenNotYetValid    = errors.New("token is not valid yet")
+	ErrMissingSubject      = errors.New("token has no subject")
+)
+
 type UsersService interface {
-	GetUser(ctx context.Context, ID string) (*users.User, error)
+	EnsureUser(ctx context.Context, claims *users.Claims) (*users.User, error)
+}
+
+type TokensService interface {
+	AuthenticateToken(ctx context.Context, secret string) (*tokens.Token, error)
 }
 
-type openidConfiguration struct {
-	JwksUri string `json:"jwks_uri"`
+// Identity is the authenticated user along with the permissions granted by the presented credentials.
+type Identity struct {
+	User *users.User
+	// Token is the personal access token the user was authenticated with, nil when a JWT was used.
+	Token *tokens.Token
+}
+
+// CanWrite checks whether the credentials allow modifying the data.
+func (i *Identity) CanWrite() bool {
+	return i.Token == nil || i.Token.Scope.AllowsWrite()
 }
 
 type Service struct {
-	keySet jwk.Set
-	users  UsersService
+	cfg       *Config
+	providers []*provider
+	static    *keyStore
+	users     UsersService
+	tokens    TokensService
+
+	dev     *DevIssuer
+	devKeys *keyStore
 }
 
-func NewService(cfg *Config, usersSrv UsersService) *Service {
-	set := jwk.NewSet()
-	if cfg.OpenIDConfigurationUrl != "" {
-		openidCfg, err := fetchOpenIDConf(cfg.OpenIDConfigurationUrl)
-		if err != nil {
-			panic(err)
+func NewService(cfg *Config, usersSrv UsersService, tokensSrv TokensService) *Service {
+	providers := make([]*provider, 0, len(cfg.OpenIDConfigurationUrls))
+	for _, configURL := range cfg.OpenIDConfigurationUrls {
+		providers = append(providers, newProvider(cfg, configURL))
+	}
+	return &Service{
+		cfg:       cfg,
+		providers: providers,
+		static:    newKeyStore(cfg),
+		users:     usersSrv,
+		tokens:    tokensSrv,
+	}
+}
+
+// EnableDevMode starts accepting tokens minted by the built-in development issuer.
+func (s *Service) EnableDevMode() (*DevIssuer, error) {
+	dev, err := NewDevIssuer(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	devKeys := newKeyStore(s.cfg)
+	if err := devKeys.AddKey(dev.pubKey); err != nil {
+		return nil, err
+	}
+	s.dev, s.devKeys = dev, devKeys
+	return dev, nil
+}
+
+// DevIssuer provides the built-in development issuer, nil unless the development mode is enabled.
+func (s *Service) DevIssuer() *DevIssuer {
+	return s.dev
+}
+
+// Run loads the keys of the identity providers, retrying until it succeeds.
+// The keys are then kept fresh in the background until the context is done.
+func (s *Service) Run(ctx context.Context) error {
+	retryInterval := s.cfg.KeysRetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 10 * time.Second
+	}
+	var wg sync.WaitGroup
+	for _, p := range s.providers {
+		p := p
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.run(ctx, retryInterval)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Ready checks whether the service is able to authenticate users with all the identity providers.
+func (s *Service) Ready() bool {
+	for _, p := range s.providers {
+		if !p.ready() {
+			return false
 		}
+	}
+	return true
+}
 
-		if openidCfg.JwksUri != "" {
-			log.Infof("[AUTH] Fetching keys from %s", openidCfg.JwksUri)
-			set, err = jwk.Fetch(context.Background(), openidCfg.JwksUri)
-			if err != nil {
-				panic(err)
-			}
+// route selects the keys to verify the tokens of the issuer with.
+// Keys added manually are used for the tokens not issued by any of the identity providers.
+func (s *Service) route(issuer string) (*keyStore, error) {
+	if len(s.cfg.Issuers) > 0 && !contains(s.cfg.Issuers, issuer) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIssuer, issuer)
+	}
+	if s.dev != nil && issuer == s.dev.Issuer() {
+		return s.devKeys, nil
+	}
+	for _, p := range s.providers {
+		if p.matches(issuer) {
+			return p.keys, nil
 		}
 	}
-	return &Service{
-		keySet: set,
-		users:  usersSrv,
+	if len(s.providers) == 0 || s.static.hasKeys() {
+		return s.static, nil
+	}
+	if !s.Ready() {
+		return nil, ErrKeysNotLoaded
 	}
+	return nil, fmt.Errorf("%w: %q", ErrInvalidIssuer, issuer)
+}
+
+// AddKey adds a key that is trusted for the tokens not issued by any of the identity providers.
+func (s *Service) AddKey(k jwk.Key) error {
+	return s.static.AddKey(k)
 }
 
-func fetchOpenIDConf(url string) (*openidConfiguration, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// Authenticate identifies the user by either a personal access token or a JWT.
+func (s *Service) Authenticate(ctx context.Context, credential string) (*Identity, error) {
+	if tokens.IsSecret(credential) {
+		t, err := s.tokens.AuthenticateToken(ctx, credential)
+		if err != nil {
+			return nil, err
+		}
+		return &Identity{User: t.User, Token: t}, nil
+	}
+	u, err := s.AuthenticateUser(ctx, credential)
 	if err != nil {
 		return nil, err
 	}
-	res, err := http.DefaultClient.Do(req)
+	return &Identity{User: u}, nil
+}
+
+func (s *Service) AuthenticateUser(ctx context.Context, token string) (*users.User, error) {
+	unverified, err := jwt.ParseInsecure([]byte(token))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
-	buf, err := io.ReadAll(res.Body)
-	defer res.Body.Close()
+	keys, err := s.route(unverified.Issuer())
 	if err != nil {
 		return nil, err
 	}
-	var cfg openidConfiguration
-	if err := json.Unmarshal(buf, &cfg); err != nil {
+	t, err := jwt.Parse([]byte(token), jwt.WithKeyProvider(keys), jwt.WithValidate(false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	if err := s.validate(t); err != nil {
 		return nil, err
 	}
-	return &cfg, nil
+	claims := tokenClaims(t)
+	claims.Legacy = s.legacy(claims.Issuer)
+	return s.users.EnsureUser(ctx, claims)
 }
 
-func (s *Service) AddKey(k jwk.Key) error {
-	return s.keySet.AddKey(k)
+// legacy checks whether the tokens of the issuer are issued by the original identity provider.
+func (s *Service) legacy(issuer string) bool {
+	if s.cfg.LegacyIssuer != "" {
+		return issuer == s.cfg.LegacyIssuer
+	}
+	return len(s.providers) > 0 && s.providers[0].matches(issuer)
 }
 
-func (s *Service) AuthenticateUser(ctx context.Context, token string) (*users.User, error) {
-	t, err := jwt.Parse([]byte(token), jwt.WithKeySet(s.keySet))
-	if err != nil {
-		return nil, err
+// validate checks the claims of the token, telling apart the reasons it is rejected for.
+func (s *Service) validate(t jwt.Token) error {
+	opts := []jwt.ValidateOption{jwt.WithAcceptableSkew(s.cfg.ClockSkew)}
+	if s.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(s.cfg.Audience))
+	}
+	err := jwt.Validate(t, opts...)
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired()):
+		return fmt.Errorf("%w: %w", ErrTokenExpired, err)
+	case errors.Is(err, jwt.ErrTokenNotYetValid()), errors.Is(err, jwt.ErrInvalidIssuedAt()):
+		return fmt.Errorf("%w: %w", ErrTokenNotYetValid, err)
+	case errors.Is(err, jwt.ErrInvalidAudience()):
+		return fmt.Errorf("%w: expected %q", ErrInvalidAudience, s.cfg.Audience)
+	case err != nil:
+		return err
 	}
 	if t.Subject() == "" {
-		return nil, fmt.Errorf("invalid auth payload")
+		return ErrMissingSubject
+	}
+	return nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenClaims extracts identity information about the user from the token.
+func tokenClaims(t jwt.Token) *users.Claims {
+	claims := &users.Claims{
+		Subject: t.Subject(),
+		Issuer:  t.Issuer(),
+	}
+	if v, ok := t.Get("email"); ok {
+		claims.Email, _ = v.(string)
+	}
+	if v, ok := t.Get("name"); ok {
+		claims.Name, _ = v.(string)
 	}
-	return s.users.GetUser(ctx, t.Subject())
+	return claims
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
 	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/auth"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/auth"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
 	"github.com/d-ashesss/mah-moneh/internal/users"
 	"github.com/gofrs/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -14,6 +16,9 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/stretchr/testify/suite"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 )
@@ -26,8 +31,9 @@ type AuthServiceTestSuite struct {
 	privKey jwk.Key
 	pubKey  jwk.Key
 
-	users *mocks.UsersService
-	srv   *auth.Service
+	users  *mocks.UsersService
+	tokens *mocks.TokensService
+	srv    *auth.Service
 }
 
 func (ts *AuthServiceTestSuite) SetupSuite() {
@@ -63,8 +69,9 @@ func (ts *AuthServiceTestSuite) SetupSuite() {
 
 func (ts *AuthServiceTestSuite) SetupTest() {
 	ts.users = mocks.NewUsersService(ts.T())
+	ts.tokens = mocks.NewTokensService(ts.T())
 	cfg := &auth.Config{}
-	ts.srv = auth.NewService(cfg, ts.users)
+	ts.srv = auth.NewService(cfg, ts.users, ts.tokens)
 	if err := ts.srv.AddKey(ts.pubKey); err != nil {
 		log.Fatalf("Failed to add test public key: %s", err)
 	}
@@ -108,7 +115,7 @@ func (ts *AuthServiceTestSuite) TestAuthenticateUser_ExpiredToken() {
 func (ts *AuthServiceTestSuite) TestAuthenticateUser_Valid_UserNotFound() {
 	ctx := context.Background()
 	userID := uuid.Must(uuid.NewV4()).String()
-	ts.users.On("GetUser", ctx, userID).Return(nil, errors.New("test error"))
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: userID}).Return(nil, errors.New("test error"))
 
 	tt := jwt.New()
 	err := tt.Set(jwt.SubjectKey, userID)
@@ -124,11 +131,23 @@ func (ts *AuthServiceTestSuite) TestAuthenticateUser_Valid_UserNotFound() {
 func (ts *AuthServiceTestSuite) TestAuthenticateUser_Valid_UserFound() {
 	ctx := context.Background()
 	userID := uuid.Must(uuid.NewV4()).String()
-	ts.users.On("GetUser", ctx, userID).Return(&users.User{ID: userID}, nil)
+	claims := &users.Claims{
+		Subject: userID,
+		Issuer:  "https://auth.example.com/",
+		Email:   "user@example.com",
+		Name:    "Test User",
+	}
+	ts.users.On("EnsureUser", ctx, claims).Return(&users.User{ID: userID}, nil)
 
 	tt := jwt.New()
 	err := tt.Set(jwt.SubjectKey, userID)
 	ts.Require().NoError(err)
+	err = tt.Set(jwt.IssuerKey, claims.Issuer)
+	ts.Require().NoError(err)
+	err = tt.Set("email", claims.Email)
+	ts.Require().NoError(err)
+	err = tt.Set("name", claims.Name)
+	ts.Require().NoError(err)
 	token, err := jwt.Sign(tt, jwt.WithKey(ts.privKey.Algorithm(), ts.privKey))
 	ts.Require().NoError(err)
 
@@ -137,6 +156,395 @@ func (ts *AuthServiceTestSuite) TestAuthenticateUser_Valid_UserFound() {
 	ts.Equal(userID, user.ID)
 }
 
+func (ts *AuthServiceTestSuite) TestAuthenticate_JWT() {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4()).String()
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: userID}).Return(&users.User{ID: userID}, nil)
+
+	tt := jwt.New()
+	err := tt.Set(jwt.SubjectKey, userID)
+	ts.Require().NoError(err)
+	token, err := jwt.Sign(tt, jwt.WithKey(ts.privKey.Algorithm(), ts.privKey))
+	ts.Require().NoError(err)
+
+	identity, err := ts.srv.Authenticate(ctx, string(token))
+	ts.Require().NoError(err)
+	ts.Equal(userID, identity.User.ID)
+	ts.Nil(identity.Token)
+	ts.True(identity.CanWrite())
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticate_AccessToken() {
+	ctx := context.Background()
+	secret := tokens.Prefix + "secret"
+	t := &tokens.Token{User: &users.User{ID: "user1"}, Scope: tokens.ScopeReadOnly}
+	ts.tokens.On("AuthenticateToken", ctx, secret).Return(t, nil)
+
+	identity, err := ts.srv.Authenticate(ctx, secret)
+	ts.Require().NoError(err)
+	ts.Equal("user1", identity.User.ID)
+	ts.Equal(t, identity.Token)
+	ts.False(identity.CanWrite())
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticate_InvalidAccessToken() {
+	ctx := context.Background()
+	secret := tokens.Prefix + "secret"
+	ts.tokens.On("AuthenticateToken", ctx, secret).Return(nil, tokens.ErrInvalidToken)
+
+	identity, err := ts.srv.Authenticate(ctx, secret)
+	ts.Nil(identity)
+	ts.ErrorIs(err, tokens.ErrInvalidToken)
+}
+
+// jwksServer imitates an identity provider serving the OpenID configuration and the keys.
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     jwk.Set
+	failures int
+}
+
+func newJWKSServer(keys ...jwk.Key) *jwksServer {
+	srv := &jwksServer{keys: jwk.NewSet()}
+	for _, k := range keys {
+		_ = srv.keys.AddKey(k)
+	}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		if srv.failures > 0 {
+			srv.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/jwks.json"})
+		case "/jwks.json":
+			_ = json.NewEncoder(w).Encode(srv.keys)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return srv
+}
+
+func (srv *jwksServer) configURL() string {
+	return srv.URL + "/.well-known/openid-configuration"
+}
+
+func (srv *jwksServer) addKey(k jwk.Key) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	_ = srv.keys.AddKey(k)
+}
+
+func newTestKeys(kid string) (jwk.Key, jwk.Key) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		log.Fatalf("Failed to generate private key: %s", err)
+	}
+	privKey, _ := jwk.FromRaw(rsaKey)
+	pubKey, _ := jwk.FromRaw(rsaKey.Public())
+	for _, k := range []jwk.Key{privKey, pubKey} {
+		_ = k.Set(jwk.KeyIDKey, kid)
+		_ = k.Set(jwk.AlgorithmKey, jwa.RS256)
+	}
+	return privKey, pubKey
+}
+
+func (ts *AuthServiceTestSuite) signToken(privKey jwk.Key, issuer, userID string) string {
+	tt := jwt.New()
+	ts.Require().NoError(tt.Set(jwt.IssuerKey, issuer))
+	ts.Require().NoError(tt.Set(jwt.SubjectKey, userID))
+	token, err := jwt.Sign(tt, jwt.WithKey(privKey.Algorithm(), privKey))
+	ts.Require().NoError(err)
+	return string(token)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_KeysNotLoaded() {
+	srv := newJWKSServer(ts.pubKey)
+	defer srv.Close()
+	authSrv := auth.NewService(&auth.Config{OpenIDConfigurationUrls: []string{srv.configURL()}}, ts.users, ts.tokens)
+	ts.False(authSrv.Ready())
+
+	user, err := authSrv.AuthenticateUser(context.Background(), ts.signToken(ts.privKey, srv.URL, "user1"))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrKeysNotLoaded)
+}
+
+func (ts *AuthServiceTestSuite) TestRun_RetriesAndLoadsKeys() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := newJWKSServer(ts.pubKey)
+	defer srv.Close()
+	srv.failures = 2
+	cfg := &auth.Config{
+		OpenIDConfigurationUrls: []string{srv.configURL()},
+		KeysMinRefreshInterval:  time.Minute,
+		KeysRetryInterval:       10 * time.Millisecond,
+	}
+	authSrv := auth.NewService(cfg, ts.users, ts.tokens)
+
+	err := authSrv.Run(ctx)
+	ts.Require().NoError(err)
+	ts.True(authSrv.Ready())
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: srv.URL, Legacy: true}).Return(&users.User{ID: "user1"}, nil)
+	user, err := authSrv.AuthenticateUser(ctx, ts.signToken(ts.privKey, srv.URL, "user1"))
+	ts.Require().NoError(err)
+	ts.Equal("user1", user.ID)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_RotatedKey() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := newJWKSServer(ts.pubKey)
+	defer srv.Close()
+	cfg := &auth.Config{
+		OpenIDConfigurationUrls: []string{srv.configURL()},
+		KeysMinRefreshInterval:  time.Hour,
+		KeysRefreshCooldown:     time.Hour,
+	}
+	authSrv := auth.NewService(cfg, ts.users, ts.tokens)
+	ts.Require().NoError(authSrv.Run(ctx))
+
+	rotatedPrivKey, rotatedPubKey := newTestKeys("rotated_key")
+	srv.addKey(rotatedPubKey)
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: srv.URL, Legacy: true}).Return(&users.User{ID: "user1"}, nil)
+	user, err := authSrv.AuthenticateUser(ctx, ts.signToken(rotatedPrivKey, srv.URL, "user1"))
+	ts.Require().NoError(err, "Rotated key must be fetched on demand.")
+	ts.Equal("user1", user.ID)
+
+	unknownPrivKey, unknownPubKey := newTestKeys("unknown_key")
+	srv.addKey(unknownPubKey)
+	user, err = authSrv.AuthenticateUser(ctx, ts.signToken(unknownPrivKey, srv.URL, "user1"))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrUnknownKey, "Keys must not be refreshed again during the cooldown.")
+}
+
+// newService initializes a service trusting the test key.
+func (ts *AuthServiceTestSuite) newService(cfg *auth.Config) *auth.Service {
+	srv := auth.NewService(cfg, ts.users, ts.tokens)
+	ts.Require().NoError(srv.AddKey(ts.pubKey))
+	return srv
+}
+
+func (ts *AuthServiceTestSuite) signClaims(claims map[string]any) string {
+	tt := jwt.New()
+	for k, v := range claims {
+		ts.Require().NoError(tt.Set(k, v))
+	}
+	token, err := jwt.Sign(tt, jwt.WithKey(ts.privKey.Algorithm(), ts.privKey))
+	ts.Require().NoError(err)
+	return string(token)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_ClockSkew() {
+	ctx := context.Background()
+	srv := ts.newService(&auth.Config{ClockSkew: 30 * time.Second})
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1"}).Return(&users.User{ID: "user1"}, nil)
+
+	user, err := srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey:    "user1",
+		jwt.ExpirationKey: time.Now().Add(-10 * time.Second),
+	}))
+	ts.Require().NoError(err, "Token expired within the skew must be accepted.")
+	ts.Equal("user1", user.ID)
+
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey:    "user1",
+		jwt.ExpirationKey: time.Now().Add(-time.Minute),
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrTokenExpired)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_NotYetValid() {
+	user, err := ts.srv.AuthenticateUser(context.Background(), ts.signClaims(map[string]any{
+		jwt.SubjectKey:   "user1",
+		jwt.NotBeforeKey: time.Now().Add(time.Hour),
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrTokenNotYetValid)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_Audience() {
+	ctx := context.Background()
+	srv := ts.newService(&auth.Config{Audience: "https://api.example.com/"})
+
+	user, err := srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrInvalidAudience)
+
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey:  "user1",
+		jwt.AudienceKey: []string{"https://other.example.com/"},
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrInvalidAudience)
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1"}).Return(&users.User{ID: "user1"}, nil)
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey:  "user1",
+		jwt.AudienceKey: []string{"https://other.example.com/", "https://api.example.com/"},
+	}))
+	ts.Require().NoError(err)
+	ts.Equal("user1", user.ID)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_ConfiguredIssuer() {
+	ctx := context.Background()
+	srv := ts.newService(&auth.Config{Issuers: []string{"https://auth.example.com/", "https://accounts.example.com"}})
+
+	user, err := srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  "https://evil.example.com/",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrInvalidIssuer)
+
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrInvalidIssuer)
+
+	claims := &users.Claims{Subject: "user1", Issuer: "https://accounts.example.com"}
+	ts.users.On("EnsureUser", ctx, claims).Return(&users.User{ID: "user1"}, nil)
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  "https://accounts.example.com",
+	}))
+	ts.Require().NoError(err)
+	ts.Equal("user1", user.ID)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_DiscoveredIssuer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	idp := newJWKSServer(ts.pubKey)
+	defer idp.Close()
+	srv := auth.NewService(&auth.Config{OpenIDConfigurationUrls: []string{idp.configURL()}}, ts.users, ts.tokens)
+	ts.Require().NoError(srv.Run(ctx))
+
+	user, err := srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  "https://evil.example.com/",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrInvalidIssuer)
+
+	claims := &users.Claims{Subject: "user1", Issuer: idp.URL, Legacy: true}
+	ts.users.On("EnsureUser", ctx, claims).Return(&users.User{ID: "user1"}, nil)
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  idp.URL,
+	}))
+	ts.Require().NoError(err)
+	ts.Equal("user1", user.ID)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_AlgorithmNotAllowed() {
+	srv := ts.newService(&auth.Config{Algorithms: []string{"ES256"}})
+
+	user, err := srv.AuthenticateUser(context.Background(), ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrAlgorithmNotAllowed)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_MissingSubject() {
+	user, err := ts.srv.AuthenticateUser(context.Background(), ts.signClaims(map[string]any{
+		jwt.IssuerKey: "https://auth.example.com/",
+	}))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrMissingSubject)
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_MultipleProviders() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	people := newJWKSServer(ts.pubKey)
+	defer people.Close()
+	servicePrivKey, servicePubKey := newTestKeys("service_key")
+	services := newJWKSServer(servicePubKey)
+	defer services.Close()
+	cfg := &auth.Config{
+		OpenIDConfigurationUrls: []string{people.configURL(), services.configURL()},
+		KeysRefreshCooldown:     time.Hour,
+	}
+	srv := auth.NewService(cfg, ts.users, ts.tokens)
+	ts.Require().NoError(srv.Run(ctx))
+	ts.True(srv.Ready())
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: people.URL, Legacy: true}).Return(&users.User{ID: "person"}, nil)
+	user, err := srv.AuthenticateUser(ctx, ts.signToken(ts.privKey, people.URL, "user1"))
+	ts.Require().NoError(err)
+	ts.Equal("person", user.ID)
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: services.URL}).Return(&users.User{ID: "service"}, nil)
+	user, err = srv.AuthenticateUser(ctx, ts.signToken(servicePrivKey, services.URL, "user1"))
+	ts.Require().NoError(err)
+	ts.Equal("service", user.ID)
+
+	user, err = srv.AuthenticateUser(ctx, ts.signToken(ts.privKey, services.URL, "user1"))
+	ts.Nil(user)
+	ts.ErrorIs(err, auth.ErrUnknownKey, "Token must be verified with the keys of its issuer only.")
+}
+
+func (ts *AuthServiceTestSuite) TestAuthenticateUser_LegacyIssuer() {
+	ctx := context.Background()
+	srv := ts.newService(&auth.Config{LegacyIssuer: "https://auth.example.com/"})
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: "https://auth.example.com/", Legacy: true}).
+		Return(&users.User{ID: "user1"}, nil)
+	user, err := srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  "https://auth.example.com/",
+	}))
+	ts.Require().NoError(err)
+	ts.Equal("user1", user.ID)
+
+	ts.users.On("EnsureUser", ctx, &users.Claims{Subject: "user1", Issuer: "https://accounts.example.com/"}).
+		Return(&users.User{ID: "other"}, nil)
+	user, err = srv.AuthenticateUser(ctx, ts.signClaims(map[string]any{
+		jwt.SubjectKey: "user1",
+		jwt.IssuerKey:  "https://accounts.example.com/",
+	}))
+	ts.Require().NoError(err)
+	ts.Equal("other", user.ID)
+}
+
+func (ts *AuthServiceTestSuite) TestDevMode() {
+	ctx := context.Background()
+	srv := auth.NewService(&auth.Config{DevIssuer: "http://localhost:8080", Audience: "mah-moneh"}, ts.users, ts.tokens)
+	ts.Nil(srv.DevIssuer())
+	dev, err := srv.EnableDevMode()
+	ts.Require().NoError(err, "Failed to enable development mode.")
+	ts.Equal(dev, srv.DevIssuer())
+	ts.Equal("http://localhost:8080/.well-known/jwks.json", dev.JWKSURI())
+	ts.Equal(1, dev.PublicKeys().Len())
+
+	token, expiresAt, err := dev.Mint("dev-user", "dev@example.com", "Developer")
+	ts.Require().NoError(err, "Failed to mint token.")
+	ts.True(expiresAt.After(time.Now()))
+
+	claims := &users.Claims{Subject: "dev-user", Issuer: "http://localhost:8080", Email: "dev@example.com", Name: "Developer"}
+	ts.users.On("EnsureUser", ctx, claims).Return(&users.User{ID: "dev-user"}, nil)
+	user, err := srv.AuthenticateUser(ctx, token)
+	ts.Require().NoError(err, "Minted token must be accepted.")
+	ts.Equal("dev-user", user.ID)
+
+	user, err = ts.srv.AuthenticateUser(ctx, token)
+	ts.Nil(user)
+	ts.Error(err, "Minted token must not be accepted unless the development mode is enabled.")
+}
+
 func TestAuthService(t *testing.T) {
 	suite.Run(t, new(AuthServiceTestSuite))
 }
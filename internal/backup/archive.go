@@ -0,0 +1,186 @@
+package backup
+
+import (
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// FormatVersion is the version of the archive format produced by the export.
+// It is incremented with every incompatible change of the format, archives of other versions are not imported.
+const FormatVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrInvalidArchive     = errors.New("invalid archive")
+)
+
+// Archive is a snapshot of the data of a workspace along with the settings of the user who made it.
+// Records refer to each other with their UUIDs at the time of the export.
+type Archive struct {
+	Version      int            `json:"version"`
+	ExportedAt   time.Time      `json:"exported_at"`
+	Settings     *Settings      `json:"settings,omitempty"`
+	Accounts     []*Account     `json:"accounts"`
+	Categories   []*Category    `json:"categories"`
+	Labels       []*Label       `json:"labels"`
+	Payees       []*Payee       `json:"payees"`
+	Transactions []*Transaction `json:"transactions"`
+	Rates        []*Rate        `json:"rates"`
+}
+
+// NewArchive initializes an empty archive of the current format.
+func NewArchive() *Archive {
+	return &Archive{
+		Version:      FormatVersion,
+		ExportedAt:   time.Now().UTC(),
+		Accounts:     make([]*Account, 0),
+		Categories:   make([]*Category, 0),
+		Labels:       make([]*Label, 0),
+		Payees:       make([]*Payee, 0),
+		Transactions: make([]*Transaction, 0),
+		Rates:        make([]*Rate, 0),
+	}
+}
+
+type Settings struct {
+	DefaultCurrency string `json:"default_currency"`
+	Locale          string `json:"locale"`
+	FiscalYearStart string `json:"fiscal_year_start"`
+}
+
+func newSettings(s users.Settings) *Settings {
+	return &Settings{
+		DefaultCurrency: s.DefaultCurrency,
+		Locale:          s.Locale,
+		FiscalYearStart: s.FiscalYearStart,
+	}
+}
+
+// apply updates the user settings with the archived ones, reporting whether anything has changed.
+func (s *Settings) apply(to *users.Settings) bool {
+	changed := false
+	for _, f := range []struct {
+		from string
+		to   *string
+	}{
+		{s.DefaultCurrency, &to.DefaultCurrency},
+		{s.Locale, &to.Locale},
+		{s.FiscalYearStart, &to.FiscalYearStart},
+	} {
+		if f.from != "" && f.from != *f.to {
+			*f.to = f.from
+			changed = true
+		}
+	}
+	return changed
+}
+
+type Account struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Name    string    `json:"name"`
+	Amounts []*Amount `json:"amounts"`
+}
+
+type Amount struct {
+	Month    string            `json:"month"`
+	Currency accounts.Currency `json:"currency"`
+	Amount   float64           `json:"amount"`
+}
+
+func newAccount(acc *accounts.Account, amts accounts.AmountCollection) *Account {
+	a := &Account{UUID: acc.UUID, Name: acc.Name, Amounts: make([]*Amount, 0, len(amts))}
+	for _, amt := range amts {
+		a.Amounts = append(a.Amounts, &Amount{Month: amt.YearMonth, Currency: amt.CurrencyCode, Amount: amt.Amount})
+	}
+	return a
+}
+
+type Category struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+func newCategory(cat *categories.Category) *Category {
+	return &Category{UUID: cat.UUID, Name: cat.Name}
+}
+
+type Label struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+func newLabel(lbl *labels.Label) *Label {
+	return &Label{UUID: lbl.UUID, Name: lbl.Name}
+}
+
+type Payee struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Name    string    `json:"name"`
+	Aliases []string  `json:"aliases"`
+}
+
+func newPayee(p *payees.Payee) *Payee {
+	return &Payee{UUID: p.UUID, Name: p.Name, Aliases: p.Aliases.Names()}
+}
+
+type Transaction struct {
+	UUID         uuid.UUID         `json:"uuid"`
+	Month        string            `json:"month"`
+	Date         string            `json:"date,omitempty"`
+	Currency     accounts.Currency `json:"currency"`
+	Amount       float64           `json:"amount"`
+	Description  string            `json:"description"`
+	CategoryUUID *uuid.UUID        `json:"category_uuid,omitempty"`
+	AccountUUID  *uuid.UUID        `json:"account_uuid,omitempty"`
+	PayeeUUID    *uuid.UUID        `json:"payee_uuid,omitempty"`
+	Splits       []*Split          `json:"splits,omitempty"`
+	Labels       []uuid.UUID       `json:"labels,omitempty"`
+}
+
+type Split struct {
+	CategoryUUID *uuid.UUID `json:"category_uuid,omitempty"`
+	Amount       float64    `json:"amount"`
+	Note         string     `json:"note"`
+}
+
+func newTransaction(tx *transactions.Transaction) *Transaction {
+	t := &Transaction{
+		UUID:         tx.UUID,
+		Month:        tx.YearMonth,
+		Currency:     tx.Currency,
+		Amount:       tx.Amount,
+		Description:  tx.Description,
+		CategoryUUID: tx.CategoryUUID,
+		AccountUUID:  tx.AccountUUID,
+		PayeeUUID:    tx.PayeeUUID,
+	}
+	if tx.Date != nil {
+		t.Date = tx.Date.Format(time.DateOnly)
+	}
+	for _, split := range tx.Splits {
+		t.Splits = append(t.Splits, &Split{CategoryUUID: split.CategoryUUID, Amount: split.Amount, Note: split.Note})
+	}
+	for _, lbl := range tx.Labels {
+		t.Labels = append(t.Labels, lbl.UUID)
+	}
+	return t
+}
+
+type Rate struct {
+	Base   accounts.Currency `json:"base"`
+	Target accounts.Currency `json:"target"`
+	Month  string            `json:"month"`
+	Rate   float64           `json:"rate"`
+}
+
+func newRate(r *currencies.Rate) *Rate {
+	return &Rate{Base: r.Base, Target: r.Target, Month: r.YearMonth, Rate: r.Rate}
+}
@@ -0,0 +1,444 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"strings"
+	"time"
+)
+
+// Count summarizes the outcome of an import for a kind of records.
+type Count struct {
+	Created int
+	Updated int
+	Skipped int
+}
+
+// Report summarizes the outcome of an import.
+type Report struct {
+	Settings     Count
+	Accounts     Count
+	Amounts      Count
+	Categories   Count
+	Labels       Count
+	Payees       Count
+	Transactions Count
+	Rates        Count
+}
+
+// importer restores an archive into a workspace, keeping track of the records the archived UUIDs are mapped to.
+type importer struct {
+	srv      *Service
+	ws       *workspaces.Workspace
+	strategy Strategy
+	report   *Report
+
+	accounts   map[uuid.UUID]*accounts.Account
+	categories map[uuid.UUID]*categories.Category
+	labels     map[uuid.UUID]*labels.Label
+	payees     map[uuid.UUID]*payees.Payee
+}
+
+func newImporter(srv *Service, ws *workspaces.Workspace, strategy Strategy) *importer {
+	return &importer{
+		srv:        srv,
+		ws:         ws,
+		strategy:   strategy,
+		report:     &Report{},
+		accounts:   make(map[uuid.UUID]*accounts.Account),
+		categories: make(map[uuid.UUID]*categories.Category),
+		labels:     make(map[uuid.UUID]*labels.Label),
+		payees:     make(map[uuid.UUID]*payees.Payee),
+	}
+}
+
+// importWorkspace imports the archived records belonging to the workspace and the user settings.
+func (i *importer) importWorkspace(ctx context.Context, u *users.User, a *Archive) error {
+	if err := i.importSettings(ctx, u, a.Settings); err != nil {
+		return err
+	}
+	if err := i.importAccounts(ctx, a.Accounts); err != nil {
+		return err
+	}
+	if err := i.importCategories(ctx, a.Categories); err != nil {
+		return err
+	}
+	if err := i.importLabels(ctx, a.Labels); err != nil {
+		return err
+	}
+	if err := i.importPayees(ctx, a.Payees); err != nil {
+		return err
+	}
+	return i.importTransactions(ctx, a.Transactions)
+}
+
+// importSettings applies the archived settings, unless the user has already changed the default ones and they are to be kept.
+func (i *importer) importSettings(ctx context.Context, u *users.User, s *Settings) error {
+	if s == nil {
+		return nil
+	}
+	p, err := i.srv.users.GetProfile(ctx, u)
+	if err != nil {
+		return err
+	}
+	if i.strategy == StrategySkip && p.Settings != users.DefaultSettings() || !s.apply(&p.Settings) {
+		i.report.Settings.Skipped++
+		return nil
+	}
+	if err := i.srv.users.UpdateProfile(ctx, p); err != nil {
+		return err
+	}
+	i.report.Settings.Updated++
+	return nil
+}
+
+func (i *importer) importAccounts(ctx context.Context, archived []*Account) error {
+	accs, err := i.srv.accounts.GetWorkspaceAccounts(ctx, i.ws)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*accounts.Account, len(accs))
+	for _, acc := range accs {
+		if _, ok := existing[acc.Name]; !ok {
+			existing[acc.Name] = acc
+		}
+	}
+	for _, a := range archived {
+		if err := i.validate("account", a.UUID, a.Name, i.accounts[a.UUID] != nil); err != nil {
+			return err
+		}
+		if acc, ok := existing[a.Name]; ok && i.strategy != StrategyDuplicate {
+			i.accounts[a.UUID] = acc
+			i.report.Accounts.Skipped++
+			if err := i.importAmounts(ctx, acc, a.Amounts); err != nil {
+				return err
+			}
+			continue
+		}
+		acc, err := i.srv.accounts.CreateAccount(ctx, i.ws, a.Name)
+		if err != nil {
+			return err
+		}
+		i.accounts[a.UUID] = acc
+		i.report.Accounts.Created++
+		if err := i.importAmounts(ctx, acc, a.Amounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *importer) importAmounts(ctx context.Context, acc *accounts.Account, archived []*Amount) error {
+	amts, err := i.srv.accounts.GetAccountAmountHistory(ctx, acc)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]float64, len(amts))
+	for _, amt := range amts {
+		existing[amt.YearMonth+"/"+string(amt.CurrencyCode)] = amt.Amount
+	}
+	for _, a := range archived {
+		if !validMonth(a.Month) || a.Currency == "" {
+			return fmt.Errorf("%w: invalid amount of account %s", ErrInvalidArchive, acc.Name)
+		}
+		amount, ok := existing[a.Month+"/"+string(a.Currency)]
+		if ok && (i.strategy == StrategySkip || amount == a.Amount) {
+			i.report.Amounts.Skipped++
+			continue
+		}
+		if err := i.srv.accounts.SetAccountAmount(ctx, acc, a.Month, a.Currency, a.Amount); err != nil {
+			return err
+		}
+		if ok {
+			i.report.Amounts.Updated++
+		} else {
+			i.report.Amounts.Created++
+		}
+	}
+	return nil
+}
+
+func (i *importer) importCategories(ctx context.Context, archived []*Category) error {
+	cats, err := i.srv.categories.GetWorkspaceCategories(ctx, i.ws)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*categories.Category, len(cats))
+	for _, cat := range cats {
+		if _, ok := existing[cat.Name]; !ok {
+			existing[cat.Name] = cat
+		}
+	}
+	for _, c := range archived {
+		if err := i.validate("category", c.UUID, c.Name, i.categories[c.UUID] != nil); err != nil {
+			return err
+		}
+		if cat, ok := existing[c.Name]; ok && i.strategy != StrategyDuplicate {
+			i.categories[c.UUID] = cat
+			i.report.Categories.Skipped++
+			continue
+		}
+		cat, err := i.srv.categories.CreateCategory(ctx, i.ws, c.Name)
+		if err != nil {
+			return err
+		}
+		i.categories[c.UUID] = cat
+		i.report.Categories.Created++
+	}
+	return nil
+}
+
+func (i *importer) importLabels(ctx context.Context, archived []*Label) error {
+	lbls, err := i.srv.labels.GetWorkspaceLabels(ctx, i.ws)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*labels.Label, len(lbls))
+	for _, lbl := range lbls {
+		if _, ok := existing[lbl.Name]; !ok {
+			existing[lbl.Name] = lbl
+		}
+	}
+	for _, l := range archived {
+		if err := i.validate("label", l.UUID, l.Name, i.labels[l.UUID] != nil); err != nil {
+			return err
+		}
+		if lbl, ok := existing[l.Name]; ok && i.strategy != StrategyDuplicate {
+			i.labels[l.UUID] = lbl
+			i.report.Labels.Skipped++
+			continue
+		}
+		lbl, err := i.srv.labels.CreateLabel(ctx, i.ws, l.Name)
+		if err != nil {
+			return err
+		}
+		i.labels[l.UUID] = lbl
+		i.report.Labels.Created++
+	}
+	return nil
+}
+
+func (i *importer) importPayees(ctx context.Context, archived []*Payee) error {
+	ps, err := i.srv.payees.GetWorkspacePayees(ctx, i.ws)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*payees.Payee, len(ps))
+	for _, p := range ps {
+		if _, ok := existing[p.Name]; !ok {
+			existing[p.Name] = p
+		}
+	}
+	for _, a := range archived {
+		if err := i.validate("payee", a.UUID, a.Name, i.payees[a.UUID] != nil); err != nil {
+			return err
+		}
+		if p, ok := existing[a.Name]; ok && i.strategy != StrategyDuplicate {
+			i.payees[a.UUID] = p
+			aliases := payees.NewPayee(i.ws, a.Name, a.Aliases).Aliases.Names()
+			if i.strategy == StrategySkip || strings.Join(aliases, "\n") == strings.Join(p.Aliases.Names(), "\n") {
+				i.report.Payees.Skipped++
+				continue
+			}
+			p.SetAliases(aliases)
+			if err := i.srv.payees.UpdatePayee(ctx, p); err != nil {
+				return err
+			}
+			i.report.Payees.Updated++
+			continue
+		}
+		p, err := i.srv.payees.CreatePayee(ctx, i.ws, a.Name, a.Aliases)
+		if err != nil {
+			return err
+		}
+		i.payees[a.UUID] = p
+		i.report.Payees.Created++
+	}
+	return nil
+}
+
+func (i *importer) importTransactions(ctx context.Context, archived []*Transaction) error {
+	existing := make(map[string]transactions.TransactionCollection)
+	if i.strategy != StrategyDuplicate {
+		txs, err := i.srv.workspaceTransactions(ctx, i.ws)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			key := fingerprint(tx)
+			existing[key] = append(existing[key], tx)
+		}
+	}
+	for _, t := range archived {
+		tx, err := i.transaction(t)
+		if err != nil {
+			return err
+		}
+		// Every existing transaction is matched only once, so that identical transactions are imported as many times as they are archived.
+		key := fingerprint(tx)
+		if matches := existing[key]; len(matches) > 0 {
+			existing[key] = matches[1:]
+			if i.strategy == StrategySkip {
+				i.report.Transactions.Skipped++
+				continue
+			}
+			if err := i.overwriteTransaction(ctx, matches[0], tx); err != nil {
+				return err
+			}
+			i.report.Transactions.Updated++
+			continue
+		}
+		err = i.srv.transactions.SaveTransaction(ctx, tx)
+		if errors.Is(err, transactions.ErrSplitsAmountMismatch) {
+			return fmt.Errorf("%w: transaction %s: %s", ErrInvalidArchive, t.UUID, err)
+		}
+		if err != nil {
+			return err
+		}
+		i.report.Transactions.Created++
+	}
+	return nil
+}
+
+// overwriteTransaction updates the existing transaction with the references of the archived one.
+// Splits of the existing transaction are kept as they are.
+func (i *importer) overwriteTransaction(ctx context.Context, tx, archived *transactions.Transaction) error {
+	tx.Category, tx.CategoryUUID = archived.Category, nil
+	if tx.Category != nil {
+		tx.CategoryUUID = &tx.Category.UUID
+	}
+	tx.Account, tx.AccountUUID = archived.Account, nil
+	if tx.Account != nil {
+		tx.AccountUUID = &tx.Account.UUID
+	}
+	tx.Payee, tx.PayeeUUID = archived.Payee, nil
+	if tx.Payee != nil {
+		tx.PayeeUUID = &tx.Payee.UUID
+	}
+	if err := i.srv.transactions.SaveTransaction(ctx, tx); err != nil {
+		return err
+	}
+	return i.srv.transactions.SetTransactionLabels(ctx, tx, archived.Labels)
+}
+
+// transaction prepares a new transaction of the workspace from the archived one.
+func (i *importer) transaction(t *Transaction) (*transactions.Transaction, error) {
+	cat, err := i.category(t.CategoryUUID)
+	if err != nil {
+		return nil, err
+	}
+	tx := transactions.NewTransaction(i.ws, t.Month, t.Currency, t.Amount, t.Description, cat)
+	if t.Date != "" {
+		d, err := time.Parse(time.DateOnly, t.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid date of transaction %s", ErrInvalidArchive, t.UUID)
+		}
+		tx.SetDate(d)
+	}
+	if !validMonth(tx.YearMonth) || tx.Currency == "" {
+		return nil, fmt.Errorf("%w: invalid transaction %s", ErrInvalidArchive, t.UUID)
+	}
+	if t.AccountUUID != nil {
+		acc, ok := i.accounts[*t.AccountUUID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown account %s", ErrInvalidArchive, t.AccountUUID)
+		}
+		tx.Account = acc
+	}
+	if t.PayeeUUID != nil {
+		p, ok := i.payees[*t.PayeeUUID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown payee %s", ErrInvalidArchive, t.PayeeUUID)
+		}
+		tx.Payee = p
+	}
+	for _, s := range t.Splits {
+		cat, err := i.category(s.CategoryUUID)
+		if err != nil {
+			return nil, err
+		}
+		tx.Splits = append(tx.Splits, transactions.NewSplit(cat, s.Amount, s.Note))
+	}
+	tx.Labels = make(labels.LabelCollection, 0, len(t.Labels))
+	for _, id := range t.Labels {
+		lbl, ok := i.labels[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown label %s", ErrInvalidArchive, id)
+		}
+		tx.Labels = append(tx.Labels, lbl)
+	}
+	return tx, nil
+}
+
+func (i *importer) category(id *uuid.UUID) (*categories.Category, error) {
+	if id == nil {
+		return nil, nil
+	}
+	cat, ok := i.categories[*id]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown category %s", ErrInvalidArchive, id)
+	}
+	return cat, nil
+}
+
+// importRates imports the archived conversion rates which are not known yet.
+func (i *importer) importRates(ctx context.Context, archived []*Rate) error {
+	rates, err := i.srv.rates.GetRates(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(rates))
+	for _, r := range rates {
+		existing[fmt.Sprintf("%s/%s/%s", r.Base, r.Target, r.YearMonth)] = true
+	}
+	for _, r := range archived {
+		if !validMonth(r.Month) || r.Base == "" || r.Target == "" {
+			return fmt.Errorf("%w: invalid rate %s/%s", ErrInvalidArchive, r.Base, r.Target)
+		}
+		key := fmt.Sprintf("%s/%s/%s", r.Base, r.Target, r.Month)
+		if existing[key] {
+			i.report.Rates.Skipped++
+			continue
+		}
+		if err := i.srv.rates.SetRate(ctx, r.Base, r.Target, r.Month, r.Rate); err != nil {
+			return err
+		}
+		existing[key] = true
+		i.report.Rates.Created++
+	}
+	return nil
+}
+
+// validate checks that the archived record can be imported.
+func (i *importer) validate(kind string, id uuid.UUID, name string, duplicate bool) error {
+	if id.IsNil() || name == "" {
+		return fmt.Errorf("%w: invalid %s %q", ErrInvalidArchive, kind, name)
+	}
+	if duplicate {
+		return fmt.Errorf("%w: duplicate %s %s", ErrInvalidArchive, kind, id)
+	}
+	return nil
+}
+
+// fingerprint identifies the transaction by its contents.
+func fingerprint(tx *transactions.Transaction) string {
+	date := ""
+	if tx.Date != nil {
+		date = tx.Date.Format(time.DateOnly)
+	}
+	return fmt.Sprintf("%s|%s|%s|%.4f|%s", tx.YearMonth, date, tx.Currency, tx.Amount, tx.Description)
+}
+
+func validMonth(month string) bool {
+	_, err := time.Parse(accounts.FmtYearMonth, month)
+	return err == nil
+}
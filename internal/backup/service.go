@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+)
+
+var (
+	ErrInvalidStrategy = errors.New("invalid conflict strategy")
+)
+
+// Strategy defines what happens to the archived records matching the existing ones on import.
+// Accounts, categories, labels and payees match by name, transactions match by month, date, currency, amount and description.
+type Strategy string
+
+const (
+	// StrategySkip keeps the existing records as they are, the matching archived ones are not imported.
+	StrategySkip Strategy = "skip"
+	// StrategyOverwrite updates the existing records with the matching archived ones.
+	StrategyOverwrite Strategy = "overwrite"
+	// StrategyDuplicate imports all the archived records as new ones.
+	StrategyDuplicate Strategy = "duplicate"
+)
+
+type UsersService interface {
+	GetProfile(ctx context.Context, u *users.User) (*users.Profile, error)
+	UpdateProfile(ctx context.Context, p *users.Profile) error
+}
+
+type AccountsService interface {
+	CreateAccount(ctx context.Context, ws *workspaces.Workspace, name string) (*accounts.Account, error)
+	GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (accounts.AccountCollection, error)
+	SetAccountAmount(ctx context.Context, acc *accounts.Account, month string, currency accounts.Currency, amount float64) error
+	GetAccountAmountHistory(ctx context.Context, acc *accounts.Account) (accounts.AmountCollection, error)
+}
+
+type CategoriesService interface {
+	CreateCategory(ctx context.Context, ws *workspaces.Workspace, name string) (*categories.Category, error)
+	GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*categories.Category, error)
+}
+
+type LabelsService interface {
+	CreateLabel(ctx context.Context, ws *workspaces.Workspace, name string) (*labels.Label, error)
+	GetWorkspaceLabels(ctx context.Context, ws *workspaces.Workspace) (labels.LabelCollection, error)
+}
+
+type PayeesService interface {
+	CreatePayee(ctx context.Context, ws *workspaces.Workspace, name string, aliases []string) (*payees.Payee, error)
+	UpdatePayee(ctx context.Context, p *payees.Payee) error
+	GetWorkspacePayees(ctx context.Context, ws *workspaces.Workspace) (payees.PayeeCollection, error)
+}
+
+type TransactionsService interface {
+	SaveTransaction(ctx context.Context, tx *transactions.Transaction) error
+	SearchTransactions(ctx context.Context, ws *workspaces.Workspace, f *transactions.Filter) (*transactions.Page, error)
+	SetTransactionLabels(ctx context.Context, tx *transactions.Transaction, lbls labels.LabelCollection) error
+}
+
+type RatesService interface {
+	SetRate(ctx context.Context, base, target accounts.Currency, month string, rate float64) error
+	GetRates(ctx context.Context) (currencies.RateCollection, error)
+}
+
+// Service is a service responsible for exporting and importing the data of workspaces.
+type Service struct {
+	txm          datastore.TxManager
+	users        UsersService
+	accounts     AccountsService
+	categories   CategoriesService
+	labels       LabelsService
+	payees       PayeesService
+	transactions TransactionsService
+	rates        RatesService
+}
+
+// NewService initializes a new backup service.
+func NewService(
+	txm datastore.TxManager,
+	usersSrv UsersService,
+	accountsSrv AccountsService,
+	categoriesSrv CategoriesService,
+	labelsSrv LabelsService,
+	payeesSrv PayeesService,
+	transactionsSrv TransactionsService,
+	ratesSrv RatesService,
+) *Service {
+	return &Service{
+		txm:          txm,
+		users:        usersSrv,
+		accounts:     accountsSrv,
+		categories:   categoriesSrv,
+		labels:       labelsSrv,
+		payees:       payeesSrv,
+		transactions: transactionsSrv,
+		rates:        ratesSrv,
+	}
+}
+
+// Export archives all the data of the workspace along with the settings of the user.
+// Conversion rates are shared by all the workspaces, so all of them are archived.
+func (s *Service) Export(ctx context.Context, u *users.User, ws *workspaces.Workspace) (*Archive, error) {
+	a := NewArchive()
+	p, err := s.users.GetProfile(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	a.Settings = newSettings(p.Settings)
+
+	accs, err := s.accounts.GetWorkspaceAccounts(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	for _, acc := range accs {
+		amts, err := s.accounts.GetAccountAmountHistory(ctx, acc)
+		if err != nil {
+			return nil, err
+		}
+		a.Accounts = append(a.Accounts, newAccount(acc, amts))
+	}
+	cats, err := s.categories.GetWorkspaceCategories(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	for _, cat := range cats {
+		a.Categories = append(a.Categories, newCategory(cat))
+	}
+	lbls, err := s.labels.GetWorkspaceLabels(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	for _, lbl := range lbls {
+		a.Labels = append(a.Labels, newLabel(lbl))
+	}
+	ps, err := s.payees.GetWorkspacePayees(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range ps {
+		a.Payees = append(a.Payees, newPayee(p))
+	}
+	txs, err := s.workspaceTransactions(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		a.Transactions = append(a.Transactions, newTransaction(tx))
+	}
+	rates, err := s.rates.GetRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rates {
+		a.Rates = append(a.Rates, newRate(r))
+	}
+	return a, nil
+}
+
+// Import restores the archive into the workspace, the archived records get new UUIDs.
+// Records of the workspace and the settings of the user are imported atomically.
+// Conversion rates are shared by all the workspaces, so the archived ones are not imported and reported as skipped.
+func (s *Service) Import(ctx context.Context, u *users.User, ws *workspaces.Workspace, a *Archive, strategy Strategy) (*Report, error) {
+	return s.importArchive(ctx, u, ws, a, strategy, false)
+}
+
+// ImportWithRates restores the archive into the workspace like Import, also importing the archived conversion rates.
+// Only the missing rates are imported and never overwritten, they are imported in the same DB transaction as the rest.
+// Rates are shared by all the workspaces, so it is meant for the administrators only.
+func (s *Service) ImportWithRates(ctx context.Context, u *users.User, ws *workspaces.Workspace, a *Archive, strategy Strategy) (*Report, error) {
+	return s.importArchive(ctx, u, ws, a, strategy, true)
+}
+
+func (s *Service) importArchive(ctx context.Context, u *users.User, ws *workspaces.Workspace, a *Archive, strategy Strategy, withRates bool) (*Report, error) {
+	if a.Version != FormatVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	switch strategy {
+	case "":
+		strategy = StrategySkip
+	case StrategySkip, StrategyOverwrite, StrategyDuplicate:
+	default:
+		return nil, ErrInvalidStrategy
+	}
+	imp := newImporter(s, ws, strategy)
+	err := s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := imp.importWorkspace(ctx, u, a); err != nil {
+			return err
+		}
+		if !withRates {
+			imp.report.Rates.Skipped = len(a.Rates)
+			return nil
+		}
+		return imp.importRates(ctx, a.Rates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return imp.report, nil
+}
+
+// workspaceTransactions retrieves all the transactions of the workspace page by page.
+func (s *Service) workspaceTransactions(ctx context.Context, ws *workspaces.Workspace) (transactions.TransactionCollection, error) {
+	txs := make(transactions.TransactionCollection, 0)
+	f := &transactions.Filter{Sort: transactions.SortByCreatedAt, Limit: transactions.MaxLimit}
+	for {
+		page, err := s.transactions.SearchTransactions(ctx, ws, f)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, page.Transactions...)
+		if page.NextCursor == "" {
+			return txs, nil
+		}
+		f.Cursor = page.NextCursor
+	}
+}
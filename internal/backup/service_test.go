@@ -0,0 +1,242 @@
+package backup_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/backup"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/backup"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BackupServiceTestSuite struct {
+	suite.Suite
+	users        *mocks.UsersService
+	accounts     *mocks.AccountsService
+	categories   *mocks.CategoriesService
+	labels       *mocks.LabelsService
+	payees       *mocks.PayeesService
+	transactions *mocks.TransactionsService
+	rates        *mocks.RatesService
+	srv          *backup.Service
+}
+
+func (ts *BackupServiceTestSuite) SetupTest() {
+	ts.users = mocks.NewUsersService(ts.T())
+	ts.accounts = mocks.NewAccountsService(ts.T())
+	ts.categories = mocks.NewCategoriesService(ts.T())
+	ts.labels = mocks.NewLabelsService(ts.T())
+	ts.payees = mocks.NewPayeesService(ts.T())
+	ts.transactions = mocks.NewTransactionsService(ts.T())
+	ts.rates = mocks.NewRatesService(ts.T())
+	ts.srv = backup.NewService(datastore.NewNopTxManager(), ts.users, ts.accounts, ts.categories, ts.labels, ts.payees, ts.transactions, ts.rates)
+}
+
+func model() datastore.Model {
+	return datastore.Model{UUID: uuid.Must(uuid.NewV4()), Version: 1}
+}
+
+func (ts *BackupServiceTestSuite) TestExport() {
+	ctx := context.Background()
+	u := &users.User{ID: "test"}
+	ws := &workspaces.Workspace{}
+	acc := &accounts.Account{Model: model(), Name: "cash"}
+	cat := &categories.Category{Model: model(), Name: "food"}
+	lbl := &labels.Label{Model: model(), Name: "trip"}
+	p := payees.NewPayee(ws, "shop", []string{"the shop"})
+	p.Model = model()
+	tx1 := transactions.NewTransaction(ws, "2010-10", "usd", -10, "lunch", cat)
+	tx1.Model, tx1.CategoryUUID, tx1.Labels = model(), &cat.UUID, labels.LabelCollection{lbl}
+	tx2 := transactions.NewTransaction(ws, "2010-11", "usd", -20, "shopping", nil)
+	tx2.Model, tx2.PayeeUUID, tx2.AccountUUID = model(), &p.UUID, &acc.UUID
+
+	ts.users.On("GetProfile", ctx, u).Return(&users.Profile{Settings: users.DefaultSettings()}, nil)
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accounts.AccountCollection{acc}, nil)
+	ts.accounts.On("GetAccountAmountHistory", ctx, acc).Return(accounts.AmountCollection{{YearMonth: "2010-10", CurrencyCode: "usd", Amount: 100}}, nil)
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{cat}, nil)
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{lbl}, nil)
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{p}, nil)
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.MatchedBy(func(f *transactions.Filter) bool { return f.Cursor == "" })).
+		Return(&transactions.Page{Transactions: transactions.TransactionCollection{tx1}, NextCursor: "next"}, nil).Once()
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.MatchedBy(func(f *transactions.Filter) bool { return f.Cursor == "next" })).
+		Return(&transactions.Page{Transactions: transactions.TransactionCollection{tx2}}, nil).Once()
+	ts.rates.On("GetRates", ctx).Return(currencies.RateCollection{{Base: "usd", Target: "eur", YearMonth: "2010-10", Rate: 0.9}}, nil)
+
+	a, err := ts.srv.Export(ctx, u, ws)
+	ts.Require().NoError(err, "Failed to export.")
+	ts.Equal(backup.FormatVersion, a.Version)
+	ts.Equal("USD", a.Settings.DefaultCurrency)
+	ts.Require().Len(a.Accounts, 1)
+	ts.Equal(acc.UUID, a.Accounts[0].UUID)
+	ts.Equal([]*backup.Amount{{Month: "2010-10", Currency: "usd", Amount: 100}}, a.Accounts[0].Amounts)
+	ts.Equal([]*backup.Category{{UUID: cat.UUID, Name: "food"}}, a.Categories)
+	ts.Equal([]*backup.Label{{UUID: lbl.UUID, Name: "trip"}}, a.Labels)
+	ts.Equal([]*backup.Payee{{UUID: p.UUID, Name: "shop", Aliases: []string{"the shop"}}}, a.Payees)
+	ts.Require().Len(a.Transactions, 2)
+	ts.Equal(&cat.UUID, a.Transactions[0].CategoryUUID)
+	ts.Equal([]uuid.UUID{lbl.UUID}, a.Transactions[0].Labels)
+	ts.Equal(&p.UUID, a.Transactions[1].PayeeUUID)
+	ts.Equal(&acc.UUID, a.Transactions[1].AccountUUID)
+	ts.Equal([]*backup.Rate{{Base: "usd", Target: "eur", Month: "2010-10", Rate: 0.9}}, a.Rates)
+}
+
+func (ts *BackupServiceTestSuite) TestImport_UnsupportedVersion() {
+	a := backup.NewArchive()
+	a.Version = backup.FormatVersion + 1
+	_, err := ts.srv.Import(context.Background(), &users.User{}, &workspaces.Workspace{}, a, backup.StrategySkip)
+	ts.ErrorIs(err, backup.ErrUnsupportedVersion)
+}
+
+func (ts *BackupServiceTestSuite) TestImport_InvalidStrategy() {
+	_, err := ts.srv.Import(context.Background(), &users.User{}, &workspaces.Workspace{}, backup.NewArchive(), "merge")
+	ts.ErrorIs(err, backup.ErrInvalidStrategy)
+}
+
+func (ts *BackupServiceTestSuite) TestImport_UnknownReference() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	unknown := uuid.Must(uuid.NewV4())
+	a := backup.NewArchive()
+	a.Transactions = []*backup.Transaction{{UUID: uuid.Must(uuid.NewV4()), Month: "2010-10", Currency: "usd", Amount: -10, CategoryUUID: &unknown}}
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accounts.AccountCollection{}, nil)
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{}, nil)
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{}, nil)
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{}, nil)
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.AnythingOfType("*transactions.Filter")).Return(&transactions.Page{}, nil)
+
+	_, err := ts.srv.Import(ctx, &users.User{}, ws, a, backup.StrategySkip)
+	ts.ErrorIs(err, backup.ErrInvalidArchive)
+}
+
+func (ts *BackupServiceTestSuite) TestImport() {
+	ctx := context.Background()
+	u := &users.User{ID: "test"}
+	ws := &workspaces.Workspace{Model: model()}
+	cash := &accounts.Account{Model: model(), Name: "cash"}
+	bank := &accounts.Account{Model: model(), Name: "bank"}
+	food := &categories.Category{Model: model(), Name: "food"}
+	profile := &users.Profile{Settings: users.DefaultSettings()}
+	archivedCash, archivedBank, archivedFood := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+
+	a := backup.NewArchive()
+	a.Settings = &backup.Settings{DefaultCurrency: "EUR"}
+	a.Accounts = []*backup.Account{
+		{UUID: archivedCash, Name: "cash", Amounts: []*backup.Amount{{Month: "2010-10", Currency: "usd", Amount: 50}, {Month: "2010-11", Currency: "usd", Amount: 70}}},
+		{UUID: archivedBank, Name: "bank", Amounts: []*backup.Amount{{Month: "2010-10", Currency: "eur", Amount: 10}}},
+	}
+	a.Categories = []*backup.Category{{UUID: archivedFood, Name: "food"}}
+	a.Transactions = []*backup.Transaction{
+		{UUID: uuid.Must(uuid.NewV4()), Month: "2010-10", Currency: "usd", Amount: -10, Description: "lunch", CategoryUUID: &archivedFood, AccountUUID: &archivedCash},
+		{UUID: uuid.Must(uuid.NewV4()), Month: "2010-10", Currency: "usd", Amount: -10, Description: "lunch", AccountUUID: &archivedBank},
+	}
+	a.Rates = []*backup.Rate{{Base: "usd", Target: "eur", Month: "2010-10", Rate: 0.9}, {Base: "usd", Target: "eur", Month: "2010-11", Rate: 0.8}}
+	existing := transactions.NewTransaction(ws, "2010-10", "usd", -10, "lunch", nil)
+	existing.Model = model()
+
+	ts.users.On("GetProfile", ctx, u).Return(profile, nil)
+	ts.users.On("UpdateProfile", ctx, profile).Return(nil).Once()
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accounts.AccountCollection{cash}, nil)
+	ts.accounts.On("GetAccountAmountHistory", ctx, cash).Return(accounts.AmountCollection{{YearMonth: "2010-10", CurrencyCode: "usd", Amount: 40}}, nil)
+	ts.accounts.On("SetAccountAmount", ctx, cash, "2010-11", accounts.Currency("usd"), 70.0).Return(nil).Once()
+	ts.accounts.On("CreateAccount", ctx, ws, "bank").Return(bank, nil).Once()
+	ts.accounts.On("GetAccountAmountHistory", ctx, bank).Return(accounts.AmountCollection{}, nil)
+	ts.accounts.On("SetAccountAmount", ctx, bank, "2010-10", accounts.Currency("eur"), 10.0).Return(nil).Once()
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{food}, nil)
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{}, nil)
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{}, nil)
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.AnythingOfType("*transactions.Filter")).
+		Return(&transactions.Page{Transactions: transactions.TransactionCollection{existing}}, nil)
+	ts.transactions.On("SaveTransaction", ctx, mock.MatchedBy(func(tx *transactions.Transaction) bool {
+		return tx.UUID.IsNil() && tx.WorkspaceUUID == ws.UUID && tx.Account == bank && tx.Category == nil
+	})).Return(nil).Once()
+
+	r, err := ts.srv.Import(ctx, u, ws, a, backup.StrategySkip)
+	ts.Require().NoError(err, "Failed to import.")
+	ts.Equal("EUR", profile.Settings.DefaultCurrency)
+	ts.Equal(backup.Count{Updated: 1}, r.Settings)
+	ts.Equal(backup.Count{Created: 1, Skipped: 1}, r.Accounts)
+	ts.Equal(backup.Count{Created: 2, Skipped: 1}, r.Amounts)
+	ts.Equal(backup.Count{Skipped: 1}, r.Categories)
+	ts.Equal(backup.Count{Created: 1, Skipped: 1}, r.Transactions)
+	ts.Equal(backup.Count{Skipped: 2}, r.Rates, "Rates must not be imported into a workspace.")
+}
+
+func (ts *BackupServiceTestSuite) TestImportWithRates() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{Model: model()}
+	a := backup.NewArchive()
+	a.Rates = []*backup.Rate{{Base: "usd", Target: "eur", Month: "2010-10", Rate: 0.9}, {Base: "usd", Target: "eur", Month: "2010-11", Rate: 0.8}}
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accounts.AccountCollection{}, nil)
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{}, nil)
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{}, nil)
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{}, nil)
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.AnythingOfType("*transactions.Filter")).Return(&transactions.Page{}, nil)
+	ts.rates.On("GetRates", ctx).Return(currencies.RateCollection{{Base: "usd", Target: "eur", YearMonth: "2010-10", Rate: 1}}, nil)
+	ts.rates.On("SetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-11", 0.8).Return(nil).Once()
+
+	r, err := ts.srv.ImportWithRates(ctx, &users.User{}, ws, a, backup.StrategySkip)
+	ts.Require().NoError(err, "Failed to import.")
+	ts.Equal(backup.Count{Created: 1, Skipped: 1}, r.Rates)
+}
+
+func (ts *BackupServiceTestSuite) TestImport_Overwrite() {
+	ctx := context.Background()
+	u := &users.User{ID: "test"}
+	ws := &workspaces.Workspace{Model: model()}
+	cash := &accounts.Account{Model: model(), Name: "cash"}
+	shop := payees.NewPayee(ws, "shop", nil)
+	shop.Model = model()
+	trip := &labels.Label{Model: model(), Name: "trip"}
+	profile := &users.Profile{Settings: users.Settings{DefaultCurrency: "EUR", Locale: "de", FiscalYearStart: "04-01"}}
+	archivedCash, archivedShop, archivedTrip := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+
+	a := backup.NewArchive()
+	a.Settings = &backup.Settings{DefaultCurrency: "USD"}
+	a.Accounts = []*backup.Account{{UUID: archivedCash, Name: "cash", Amounts: []*backup.Amount{{Month: "2010-10", Currency: "usd", Amount: 50}}}}
+	a.Labels = []*backup.Label{{UUID: archivedTrip, Name: "trip"}}
+	a.Payees = []*backup.Payee{{UUID: archivedShop, Name: "shop", Aliases: []string{"the shop"}}}
+	a.Transactions = []*backup.Transaction{
+		{UUID: uuid.Must(uuid.NewV4()), Month: "2010-10", Currency: "usd", Amount: -10, Description: "lunch", PayeeUUID: &archivedShop, Labels: []uuid.UUID{archivedTrip}},
+	}
+	existing := transactions.NewTransaction(ws, "2010-10", "usd", -10, "lunch", nil)
+	existing.Model = model()
+
+	ts.users.On("GetProfile", ctx, u).Return(profile, nil)
+	ts.users.On("UpdateProfile", ctx, profile).Return(nil).Once()
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accounts.AccountCollection{cash}, nil)
+	ts.accounts.On("GetAccountAmountHistory", ctx, cash).Return(accounts.AmountCollection{{YearMonth: "2010-10", CurrencyCode: "usd", Amount: 40}}, nil)
+	ts.accounts.On("SetAccountAmount", ctx, cash, "2010-10", accounts.Currency("usd"), 50.0).Return(nil).Once()
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{}, nil)
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{trip}, nil)
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{shop}, nil)
+	ts.payees.On("UpdatePayee", ctx, shop).Return(nil).Once()
+	ts.transactions.On("SearchTransactions", ctx, ws, mock.AnythingOfType("*transactions.Filter")).
+		Return(&transactions.Page{Transactions: transactions.TransactionCollection{existing}}, nil)
+	ts.transactions.On("SaveTransaction", ctx, existing).Return(nil).Once()
+	ts.transactions.On("SetTransactionLabels", ctx, existing, labels.LabelCollection{trip}).Return(nil).Once()
+
+	r, err := ts.srv.Import(ctx, u, ws, a, backup.StrategyOverwrite)
+	ts.Require().NoError(err, "Failed to import.")
+	ts.Equal("USD", profile.Settings.DefaultCurrency)
+	ts.Equal("de", profile.Settings.Locale)
+	ts.Equal([]string{"the shop"}, shop.Aliases.Names())
+	ts.Equal(&shop.UUID, existing.PayeeUUID)
+	ts.Equal(backup.Count{Updated: 1}, r.Amounts)
+	ts.Equal(backup.Count{Updated: 1}, r.Payees)
+	ts.Equal(backup.Count{Skipped: 1}, r.Labels)
+	ts.Equal(backup.Count{Updated: 1}, r.Transactions)
+}
+
+func TestBackupService(t *testing.T) {
+	suite.Run(t, new(BackupServiceTestSuite))
+}
@@ -0,0 +1,18 @@
+package blobs
+
+import "github.com/joeshaw/envdecode"
+
+const (
+	DriverLocal = "local"
+)
+
+type Config struct {
+	Driver   string `env:"BLOB_STORAGE_DRIVER,default=local"`
+	LocalDir string `env:"BLOB_STORAGE_LOCAL_DIR,default=data/blobs"`
+}
+
+func NewConfig() *Config {
+	cfg := Config{}
+	_ = envdecode.Decode(&cfg)
+	return &cfg
+}
@@ -0,0 +1,59 @@
+package blobs_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+type LocalStorageTestSuite struct {
+	suite.Suite
+	storage *blobs.LocalStorage
+}
+
+func (ts *LocalStorageTestSuite) SetupTest() {
+	ts.storage = blobs.NewLocalStorage(filepath.Join(ts.T().TempDir(), "blobs"))
+}
+
+func (ts *LocalStorageTestSuite) TestPutGet() {
+	ctx := context.Background()
+	err := ts.storage.Put(ctx, "receipt", bytes.NewBufferString("receipt content"))
+	ts.Require().NoError(err, "Failed to put the blob.")
+
+	r, err := ts.storage.Get(ctx, "receipt")
+	ts.Require().NoError(err, "Failed to get the blob.")
+	defer func() { _ = r.Close() }()
+	content, err := io.ReadAll(r)
+	ts.Require().NoError(err, "Failed to read the blob.")
+	ts.Equal("receipt content", string(content))
+}
+
+func (ts *LocalStorageTestSuite) TestDelete() {
+	ctx := context.Background()
+	err := ts.storage.Put(ctx, "receipt", bytes.NewBufferString("receipt content"))
+	ts.Require().NoError(err, "Failed to put the blob.")
+
+	err = ts.storage.Delete(ctx, "receipt")
+	ts.Require().NoError(err, "Failed to delete the blob.")
+
+	_, err = ts.storage.Get(ctx, "receipt")
+	ts.ErrorIs(err, blobs.ErrBlobNotFound)
+	err = ts.storage.Delete(ctx, "receipt")
+	ts.ErrorIs(err, blobs.ErrBlobNotFound)
+}
+
+func (ts *LocalStorageTestSuite) TestInvalidKey() {
+	ctx := context.Background()
+	for _, key := range []string{"", "..", "../receipt", "nested/receipt", ".hidden"} {
+		err := ts.storage.Put(ctx, key, bytes.NewBufferString("receipt content"))
+		ts.ErrorIsf(err, blobs.ErrInvalidKey, "Key %q", key)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	suite.Run(t, new(LocalStorageTestSuite))
+}
@@ -0,0 +1,30 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+// Storage stores binary objects under string keys.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Open initializes the blob storage selected in the config.
+func Open(cfg *Config) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.LocalDir), nil
+	default:
+		return nil, fmt.Errorf("unsupported blob storage driver %q", cfg.Driver)
+	}
+}
@@ -3,11 +3,11 @@ package capital
 import (
 	"context"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 type AccountsService interface {
-	GetUserAccounts(ctx context.Context, u *users.User) (accounts.AccountCollection, error)
+	GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (accounts.AccountCollection, error)
 	GetAccountAmounts(ctx context.Context, acc *accounts.Account, month string) (accounts.CurrencyAmounts, error)
 }
 
@@ -22,8 +22,8 @@ func NewService(as AccountsService) *Service {
 }
 
 // GetCapital calculates capital for the specified month.
-func (s *Service) GetCapital(ctx context.Context, u *users.User, month string) (*Capital, error) {
-	accs, err := s.accounts.GetUserAccounts(ctx, u)
+func (s *Service) GetCapital(ctx context.Context, ws *workspaces.Workspace, month string) (*Capital, error) {
+	accs, err := s.accounts.GetWorkspaceAccounts(ctx, ws)
 	if err != nil {
 		return nil, err
 	}
@@ -5,7 +5,7 @@ import (
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/capital"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/capital"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -23,16 +23,16 @@ func (ts *CapitalServiceTestSuite) SetupTest() {
 
 func (ts *CapitalServiceTestSuite) TestGetCapital_SingleAccount() {
 	ctx := context.Background()
-	u := &users.User{}
+	ws := &workspaces.Workspace{}
 	acc := &accounts.Account{}
 	accs := accounts.AccountCollection{acc}
 	amounts := accounts.CurrencyAmounts{
 		"usd": 10,
 		"eur": 12,
 	}
-	ts.accounts.On("GetUserAccounts", ctx, u).Return(accs, nil)
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accs, nil)
 	ts.accounts.On("GetAccountAmounts", ctx, acc, "2010-10").Return(amounts, nil)
-	c, err := ts.srv.GetCapital(ctx, u, "2010-10")
+	c, err := ts.srv.GetCapital(ctx, ws, "2010-10")
 	ts.Require().NoError(err, "Failed to get capital.")
 	ts.InDelta(10.0, c.Amounts["usd"], 0.001)
 	ts.InDelta(12.0, c.Amounts["eur"], 0.001)
@@ -40,7 +40,7 @@ func (ts *CapitalServiceTestSuite) TestGetCapital_SingleAccount() {
 
 func (ts *CapitalServiceTestSuite) TestGetCapital_MultipleAccounts() {
 	ctx := context.Background()
-	u := &users.User{}
+	ws := &workspaces.Workspace{}
 	acc1 := &accounts.Account{}
 	acc2 := &accounts.Account{}
 	accs := accounts.AccountCollection{acc1, acc2}
@@ -51,10 +51,10 @@ func (ts *CapitalServiceTestSuite) TestGetCapital_MultipleAccounts() {
 		"usd": 10,
 		"eur": 12,
 	}
-	ts.accounts.On("GetUserAccounts", ctx, u).Return(accs, nil)
+	ts.accounts.On("GetWorkspaceAccounts", ctx, ws).Return(accs, nil)
 	ts.accounts.On("GetAccountAmounts", ctx, acc1, "2010-10").Return(amounts1, nil).Once()
 	ts.accounts.On("GetAccountAmounts", ctx, acc2, "2010-10").Return(amounts2, nil).Once()
-	c, err := ts.srv.GetCapital(ctx, u, "2010-10")
+	c, err := ts.srv.GetCapital(ctx, ws, "2010-10")
 	ts.Require().NoError(err, "Failed to get capital.")
 	ts.InDelta(25.0, c.Amounts["usd"], 0.001)
 	ts.InDelta(12.0, c.Amounts["eur"], 0.001)
@@ -2,17 +2,24 @@ package categories
 
 import (
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
-	"github.com/lib/pq"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
 )
 
 type Category struct {
 	datastore.Model
-	User *users.User `gorm:"embedded;embeddedPrefix:user_;notNull"`
-	Name string
-	Tags pq.StringArray `gorm:"type:text[]"`
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string
+	Tags          datastore.StringList
 }
 
-func NewCategory(u *users.User, name string) *Category {
-	return &Category{User: u, Name: name}
+// Page represents a page of workspace categories.
+type Page struct {
+	Categories []*Category
+	NextCursor string
+}
+
+func NewCategory(ws *workspaces.Workspace, name string) *Category {
+	return &Category{WorkspaceUUID: ws.UUID, Name: name}
 }
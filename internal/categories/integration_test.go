@@ -4,10 +4,10 @@ package categories_test
 
 import (
 	"context"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
-	"github.com/gofrs/uuid"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 	"testing"
@@ -32,7 +32,7 @@ func (ts *CategoriesIntegrationTestSuite) SetupSuite() {
 
 	ts.db = db.Session(&gorm.Session{NewDB: true})
 	store := categories.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
-	ts.srv = categories.NewService(store)
+	ts.srv = categories.NewService(store, datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true})), audit.Discard)
 
 	err = db.Migrator().AutoMigrate(&categories.Category{})
 	if err != nil {
@@ -41,8 +41,8 @@ func (ts *CategoriesIntegrationTestSuite) SetupSuite() {
 }
 
 func (ts *CategoriesIntegrationTestSuite) TestSaveCategory() {
-	u := ts.createTestingUser()
-	cat, err := ts.srv.CreateCategory(context.Background(), u, "create-test-category")
+	ws := ts.createTestingWorkspace()
+	cat, err := ts.srv.CreateCategory(context.Background(), ws, "create-test-category")
 	ts.Require().NoError(err, "Failed to create a category.")
 	ts.Require().NotNil(cat, "Received invalid category.")
 
@@ -54,8 +54,8 @@ func (ts *CategoriesIntegrationTestSuite) TestSaveCategory() {
 }
 
 func (ts *CategoriesIntegrationTestSuite) TestDeleteCategory() {
-	u := ts.createTestingUser()
-	cat := categories.NewCategory(u, "delete-test-category")
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "delete-test-category")
 	err := ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to create testing category.")
 
@@ -67,42 +67,71 @@ func (ts *CategoriesIntegrationTestSuite) TestDeleteCategory() {
 	ts.Require().ErrorIs(err, gorm.ErrRecordNotFound, "Deleted category should not be found.")
 }
 
-func (ts *CategoriesIntegrationTestSuite) TestGetUserCategories() {
-	u1 := ts.createTestingUser()
-	u2 := ts.createTestingUser()
+func (ts *CategoriesIntegrationTestSuite) TestGetWorkspaceCategories() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
 	var (
 		cat  *categories.Category
 		cats []*categories.Category
 		err  error
 	)
 
-	cat = categories.NewCategory(u1, "u1 cat1")
+	cat = categories.NewCategory(ws1, "u1 cat1")
 	err = ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to create testing category.")
 
-	cat = categories.NewCategory(u1, "u1 cat2")
+	cat = categories.NewCategory(ws1, "u1 cat2")
 	err = ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to create testing category.")
 
-	cat = categories.NewCategory(u2, "u2 cat1")
+	cat = categories.NewCategory(ws2, "u2 cat1")
 	err = ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to create testing category.")
 
-	cats, err = ts.srv.GetUserCategories(context.Background(), u1)
+	cats, err = ts.srv.GetWorkspaceCategories(context.Background(), ws1)
 	ts.Require().NoError(err, "Failed to get user's categories.")
 	ts.Len(cats, 2, "Got invalid number of categories.")
 
-	cats, err = ts.srv.GetUserCategories(context.Background(), u2)
+	cats, err = ts.srv.GetWorkspaceCategories(context.Background(), ws2)
 	ts.Require().NoError(err, "Failed to get user's categories.")
 	ts.Len(cats, 1, "Got invalid number of categories.")
 }
 
-func (ts *CategoriesIntegrationTestSuite) createTestingUser() *users.User {
+func (ts *CategoriesIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
 	ts.T().Helper()
-	UUID, _ := uuid.NewV4()
-	return &users.User{ID: UUID.String()}
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
 }
 
 func TestCategoriesIntegration(t *testing.T) {
 	suite.Run(t, new(CategoriesIntegrationTestSuite))
 }
+
+func TestGormStore(t *testing.T) {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		t.Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "cat_store_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to the DB: %s", err)
+	}
+	if err := db.Migrator().AutoMigrate(&categories.Category{}); err != nil {
+		t.Fatalf("Failed to migrate required tables: %s", err)
+	}
+	suite.Run(t, &StoreContractSuite{
+		Store: categories.NewGormStore(db.Session(&gorm.Session{NewDB: true})),
+		Persist: func(records ...any) error {
+			for _, r := range records {
+				if err := db.Create(r).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package categories
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu   sync.RWMutex
+	cats []*Category
+}
+
+// NewMemoryStore initializes in-memory implementation of Store.
+func NewMemoryStore() Store {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) SaveCategory(_ context.Context, cat *Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := cat.Stamp(time.Now()); err != nil {
+		return err
+	}
+	for i, c := range s.cats {
+		if c.UUID == cat.UUID {
+			if c.DeletedAt.Valid {
+				return datastore.ErrRecordExists
+			}
+			if err := cat.Advance(&c.Model); err != nil {
+				return err
+			}
+			s.cats[i] = copyCategory(cat)
+			return nil
+		}
+	}
+	s.cats = append(s.cats, copyCategory(cat))
+	return nil
+}
+
+func (s *memoryStore) DeleteCategory(_ context.Context, cat *Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.find(cat.UUID)
+	if stored == nil {
+		return nil
+	}
+	if cat.Version != 0 && cat.Version != stored.Version {
+		return datastore.ErrStaleRecord
+	}
+	cat.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	stored.DeletedAt = cat.DeletedAt
+	return nil
+}
+
+func (s *memoryStore) GetCategory(_ context.Context, UUID uuid.UUID) (*Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.find(UUID)
+	if stored == nil {
+		return nil, datastore.ErrRecordNotFound
+	}
+	return copyCategory(stored), nil
+}
+
+func (s *memoryStore) GetWorkspaceCategories(_ context.Context, ws *workspaces.Workspace) ([]*Category, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cats := make([]*Category, 0)
+	for _, c := range s.cats {
+		if c.WorkspaceUUID == ws.UUID && !c.DeletedAt.Valid {
+			cats = append(cats, copyCategory(c))
+		}
+	}
+	return cats, nil
+}
+
+func (s *memoryStore) GetWorkspaceCategoriesPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	after, err := p.After()
+	if err != nil {
+		return nil, err
+	}
+	cats, err := s.GetWorkspaceCategories(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		return cats[i].Precedes(&cats[j].Model)
+	})
+	limit := p.GetLimit()
+	page := &Page{Categories: make([]*Category, 0, limit)}
+	for _, cat := range cats {
+		if after != nil && !after.Precedes(&cat.Model) {
+			continue
+		}
+		if len(page.Categories) == limit {
+			page.NextCursor = page.Categories[limit-1].PageCursor()
+			break
+		}
+		page.Categories = append(page.Categories, cat)
+	}
+	return page, nil
+}
+
+// find looks up the stored category that is not deleted.
+func (s *memoryStore) find(UUID uuid.UUID) *Category {
+	for _, c := range s.cats {
+		if c.UUID == UUID && !c.DeletedAt.Valid {
+			return c
+		}
+	}
+	return nil
+}
+
+func copyCategory(cat *Category) *Category {
+	c := *cat
+	if cat.Tags != nil {
+		c.Tags = append(datastore.StringList{}, cat.Tags...)
+	}
+	return &c
+}
@@ -0,0 +1,11 @@
+package categories_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	suite.Run(t, &StoreContractSuite{Store: categories.NewMemoryStore()})
+}
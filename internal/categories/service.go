@@ -2,21 +2,31 @@ package categories
 
 import (
 	"context"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 )
 
 type Service struct {
-	db Store
+	db    Store
+	txm   datastore.TxManager
+	audit audit.Recorder
 }
 
-func NewService(db Store) *Service {
-	return &Service{db: db}
+func NewService(db Store, txm datastore.TxManager, rec audit.Recorder) *Service {
+	return &Service{db: db, txm: txm, audit: rec}
 }
 
-func (s *Service) CreateCategory(ctx context.Context, u *users.User, name string) (*Category, error) {
-	cat := NewCategory(u, name)
-	if err := s.db.SaveCategory(ctx, cat); err != nil {
+func (s *Service) CreateCategory(ctx context.Context, ws *workspaces.Workspace, name string) (*Category, error) {
+	cat := NewCategory(ws, name)
+	err := s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.SaveCategory(ctx, cat); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Created(audit.EntityCategory, cat.UUID.String(), &cat.WorkspaceUUID, cat))
+	})
+	if err != nil {
 		return nil, err
 	}
 	return cat, nil
@@ -27,9 +37,18 @@ func (s *Service) GetCategory(ctx context.Context, UUID uuid.UUID) (*Category, e
 }
 
 func (s *Service) DeleteCategory(ctx context.Context, cat *Category) error {
-	return s.db.DeleteCategory(ctx, cat)
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.DeleteCategory(ctx, cat); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Deleted(audit.EntityCategory, cat.UUID.String(), &cat.WorkspaceUUID, cat))
+	})
 }
 
-func (s *Service) GetUserCategories(ctx context.Context, u *users.User) ([]*Category, error) {
-	return s.db.GetUserCategories(ctx, u)
+func (s *Service) GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*Category, error) {
+	return s.db.GetWorkspaceCategories(ctx, ws)
+}
+
+func (s *Service) GetWorkspaceCategoriesPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	return s.db.GetWorkspaceCategoriesPage(ctx, ws, p)
 }
@@ -2,9 +2,13 @@ package categories_test
 
 import (
 	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/categories"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -13,40 +17,70 @@ import (
 type CategoriesServiceTestSuite struct {
 	suite.Suite
 	store *mocks.Store
+	audit *auditmocks.Recorder
 	srv   *categories.Service
 }
 
 func (ts *CategoriesServiceTestSuite) SetupTest() {
 	ts.store = mocks.NewStore(ts.T())
-	ts.srv = categories.NewService(ts.store)
+	ts.audit = auditmocks.NewRecorder(ts.T())
+	ts.srv = categories.NewService(ts.store, datastore.NewNopTxManager(), ts.audit)
 }
 
 func (ts *CategoriesServiceTestSuite) TestCreateCategory() {
 	ctx := context.Background()
 	ts.store.On("SaveCategory", ctx, mock.AnythingOfType("*categories.Category")).Return(nil)
-	u := &users.User{}
-	cat, err := ts.srv.CreateCategory(ctx, u, "test-cat")
+	ts.audit.On("Record", ctx, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == audit.ActionCreate && ch.Entity == audit.EntityCategory && ch.Before == nil
+	})).Return(nil).Once()
+	ws := &workspaces.Workspace{}
+	cat, err := ts.srv.CreateCategory(ctx, ws, "test-cat")
 	ts.Require().NoError(err, "Failed to create category.")
 	ts.Require().NotNil(cat, "Received nil category.")
 }
 
+func (ts *CategoriesServiceTestSuite) TestCreateCategory_AuditError() {
+	ctx := context.Background()
+	errFailed := fmt.Errorf("failed")
+	ts.store.On("SaveCategory", ctx, mock.AnythingOfType("*categories.Category")).Return(nil)
+	ts.audit.On("Record", ctx, mock.AnythingOfType("*audit.Change")).Return(errFailed).Once()
+	cat, err := ts.srv.CreateCategory(ctx, &workspaces.Workspace{}, "test-cat")
+	ts.ErrorIs(err, errFailed)
+	ts.Nil(cat)
+}
+
 func (ts *CategoriesServiceTestSuite) TestDeleteCategory() {
 	ctx := context.Background()
 	cat := &categories.Category{}
 	ts.store.On("DeleteCategory", ctx, cat).Return(nil)
+	ts.audit.On("Record", ctx, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == audit.ActionDelete && ch.Before == cat
+	})).Return(nil).Once()
 	err := ts.srv.DeleteCategory(ctx, cat)
 	ts.Require().NoError(err, "Failed to delete category.")
 }
 
-func (ts *CategoriesServiceTestSuite) TestGetUserCategories() {
+func (ts *CategoriesServiceTestSuite) TestGetWorkspaceCategories() {
 	ctx := context.Background()
-	u := &users.User{}
-	ts.store.On("GetUserCategories", ctx, u).Return([]*categories.Category{}, nil)
-	cats, err := ts.srv.GetUserCategories(ctx, u)
+	ws := &workspaces.Workspace{}
+	ts.store.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{}, nil)
+	cats, err := ts.srv.GetWorkspaceCategories(ctx, ws)
 	ts.Require().NoError(err, "Failed to get user categories.")
 	ts.Require().NotNil(cats, "Got nil categories.")
 }
 
+func (ts *CategoriesServiceTestSuite) TestGetWorkspaceCategoriesPage() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	p := &datastore.PageRequest{Limit: 10}
+	ts.store.On("GetWorkspaceCategoriesPage", ctx, ws, p).
+		Return(&categories.Page{Categories: []*categories.Category{}, NextCursor: "next"}, nil)
+	page, err := ts.srv.GetWorkspaceCategoriesPage(ctx, ws, p)
+	ts.Require().NoError(err, "Failed to get page of categories.")
+	ts.NotNil(page.Categories)
+	ts.Equal("next", page.NextCursor)
+}
+
 func TestCategoriesService(t *testing.T) {
 	suite.Run(t, new(CategoriesServiceTestSuite))
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
 )
@@ -13,7 +13,8 @@ type Store interface {
 	SaveCategory(ctx context.Context, cat *Category) error
 	DeleteCategory(ctx context.Context, cat *Category) error
 	GetCategory(ctx context.Context, uuid uuid.UUID) (*Category, error)
-	GetUserCategories(ctx context.Context, u *users.User) ([]*Category, error)
+	GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*Category, error)
+	GetWorkspaceCategoriesPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error)
 }
 
 type gormStore struct {
@@ -25,16 +26,16 @@ func NewGormStore(db *gorm.DB) Store {
 }
 
 func (s *gormStore) SaveCategory(ctx context.Context, cat *Category) error {
-	return s.db.WithContext(ctx).Save(cat).Error
+	return datastore.Save(datastore.Conn(ctx, s.db), cat)
 }
 
 func (s *gormStore) DeleteCategory(ctx context.Context, cat *Category) error {
-	return s.db.WithContext(ctx).Delete(cat).Error
+	return datastore.Delete(datastore.Conn(ctx, s.db), cat)
 }
 
 func (s *gormStore) GetCategory(ctx context.Context, UUID uuid.UUID) (*Category, error) {
 	var cat Category
-	err := s.db.WithContext(ctx).Where("uuid = ?", UUID).First(&cat).Error
+	err := datastore.Conn(ctx, s.db).Where("uuid = ?", UUID).First(&cat).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, datastore.ErrRecordNotFound
 	}
@@ -44,10 +45,27 @@ func (s *gormStore) GetCategory(ctx context.Context, UUID uuid.UUID) (*Category,
 	return &cat, nil
 }
 
-func (s *gormStore) GetUserCategories(ctx context.Context, u *users.User) ([]*Category, error) {
+func (s *gormStore) GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*Category, error) {
 	cats := make([]*Category, 0)
-	if err := s.db.WithContext(ctx).Where("user_id", u.ID).Find(&cats).Error; err != nil {
+	if err := datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID).Find(&cats).Error; err != nil {
 		return nil, err
 	}
 	return cats, nil
 }
+
+func (s *gormStore) GetWorkspaceCategoriesPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*Page, error) {
+	query, err := p.Paginate(datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID))
+	if err != nil {
+		return nil, err
+	}
+	cats := make([]*Category, 0)
+	if err := query.Find(&cats).Error; err != nil {
+		return nil, err
+	}
+	page := &Page{Categories: cats}
+	if limit := p.GetLimit(); len(cats) > limit {
+		page.Categories = cats[:limit]
+		page.NextCursor = cats[limit-1].PageCursor()
+	}
+	return page, nil
+}
@@ -0,0 +1,195 @@
+package categories_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"sync"
+)
+
+// StoreContractSuite verifies the behavior every Store implementation must have.
+type StoreContractSuite struct {
+	suite.Suite
+	Store categories.Store
+	// Persist saves the records the categories refer to, if the store requires them to exist.
+	Persist func(records ...any) error
+}
+
+func (ts *StoreContractSuite) TestSaveCategory() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	cat := categories.NewCategory(ws, "food")
+	cat.Tags = datastore.StringList{"groceries", "daily"}
+	err := ts.Store.SaveCategory(ctx, cat)
+	ts.Require().NoError(err, "Failed to save category.")
+	ts.Require().False(cat.UUID.IsNil(), "Category UUID was not generated.")
+
+	found, err := ts.Store.GetCategory(ctx, cat.UUID)
+	ts.Require().NoError(err, "Failed to get category.")
+	ts.Equal(ws.UUID, found.WorkspaceUUID)
+	ts.Equal("food", found.Name)
+	ts.Equal(datastore.StringList{"groceries", "daily"}, found.Tags)
+
+	cat.Name = "meals"
+	cat.Tags = nil
+	err = ts.Store.SaveCategory(ctx, cat)
+	ts.Require().NoError(err, "Failed to update category.")
+
+	found, err = ts.Store.GetCategory(ctx, cat.UUID)
+	ts.Require().NoError(err, "Failed to get category.")
+	ts.Equal("meals", found.Name)
+	ts.Empty(found.Tags)
+}
+
+func (ts *StoreContractSuite) TestSaveCategory_Stale() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	cat := ts.category(ws, "food")
+	ts.EqualValues(1, cat.Version)
+	stale := *cat
+
+	cat.Name = "meals"
+	err := ts.Store.SaveCategory(ctx, cat)
+	ts.Require().NoError(err, "Failed to update category.")
+	ts.EqualValues(2, cat.Version)
+
+	stale.Name = "groceries"
+	err = ts.Store.SaveCategory(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	err = ts.Store.DeleteCategory(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	found, err := ts.Store.GetCategory(ctx, cat.UUID)
+	ts.Require().NoError(err, "Failed to get category.")
+	ts.Equal("meals", found.Name)
+}
+
+func (ts *StoreContractSuite) TestGetCategory_NotFound() {
+	_, err := ts.Store.GetCategory(context.Background(), uuid.Must(uuid.NewV4()))
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *StoreContractSuite) TestDeleteCategory() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	cat := ts.category(ws, "food")
+
+	err := ts.Store.DeleteCategory(ctx, cat)
+	ts.Require().NoError(err, "Failed to delete category.")
+	_, err = ts.Store.GetCategory(ctx, cat.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+	cats, err := ts.Store.GetWorkspaceCategories(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace categories.")
+	ts.Empty(cats)
+}
+
+func (ts *StoreContractSuite) TestGetWorkspaceCategories() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	ts.category(ws, "food")
+	ts.category(ws, "rent")
+	ts.category(ts.workspace(), "other")
+
+	cats, err := ts.Store.GetWorkspaceCategories(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace categories.")
+	ts.Len(cats, 2)
+	for _, cat := range cats {
+		ts.Equal(ws.UUID, cat.WorkspaceUUID)
+	}
+
+	cats, err = ts.Store.GetWorkspaceCategories(ctx, ts.workspace())
+	ts.Require().NoError(err, "Failed to get workspace categories.")
+	ts.NotNil(cats)
+	ts.Empty(cats)
+}
+
+func (ts *StoreContractSuite) TestGetWorkspaceCategoriesPage() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	created := make(map[uuid.UUID]bool)
+	for _, name := range []string{"food", "rent", "fun", "travel", "health"} {
+		created[ts.category(ws, name).UUID] = true
+	}
+	ts.category(ts.workspace(), "other")
+
+	p := &datastore.PageRequest{Limit: 2}
+	paged := make([]*categories.Category, 0)
+	for pages := 1; ; pages++ {
+		page, err := ts.Store.GetWorkspaceCategoriesPage(ctx, ws, p)
+		ts.Require().NoError(err, "Failed to get page of workspace categories.")
+		ts.Require().LessOrEqual(len(page.Categories), 2)
+		paged = append(paged, page.Categories...)
+		if page.NextCursor == "" {
+			ts.Equal(3, pages)
+			break
+		}
+		p.Cursor = page.NextCursor
+	}
+	ts.Require().Len(paged, len(created))
+	for i, cat := range paged {
+		ts.True(created[cat.UUID], "Unexpected category in the pages.")
+		if i > 0 {
+			ts.True(paged[i-1].Precedes(&cat.Model), "Categories are out of order.")
+		}
+	}
+
+	page, err := ts.Store.GetWorkspaceCategoriesPage(ctx, ts.workspace(), &datastore.PageRequest{})
+	ts.Require().NoError(err, "Failed to get page of workspace categories.")
+	ts.NotNil(page.Categories)
+	ts.Empty(page.Categories)
+	ts.Empty(page.NextCursor)
+
+	_, err = ts.Store.GetWorkspaceCategoriesPage(ctx, ws, &datastore.PageRequest{Cursor: "invalid"})
+	ts.ErrorIs(err, datastore.ErrInvalidCursor)
+}
+
+func (ts *StoreContractSuite) TestConcurrentWrites() {
+	ctx := context.Background()
+	ws := ts.workspace()
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ts.Store.SaveCategory(ctx, categories.NewCategory(ws, "concurrent"))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		ts.Require().NoError(err, "Failed to write concurrently.")
+	}
+
+	cats, err := ts.Store.GetWorkspaceCategories(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace categories.")
+	ts.Len(cats, n)
+}
+
+func (ts *StoreContractSuite) workspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}, Name: "test"}
+	ts.persist(ws)
+	return ws
+}
+
+func (ts *StoreContractSuite) category(ws *workspaces.Workspace, name string) *categories.Category {
+	ts.T().Helper()
+	cat := categories.NewCategory(ws, name)
+	err := ts.Store.SaveCategory(context.Background(), cat)
+	ts.Require().NoError(err, "Failed to create testing category.")
+	return cat
+}
+
+func (ts *StoreContractSuite) persist(records ...any) {
+	ts.T().Helper()
+	if ts.Persist == nil {
+		return
+	}
+	err := ts.Persist(records...)
+	ts.Require().NoError(err, "Failed to persist testing records.")
+}
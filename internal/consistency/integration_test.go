@@ -0,0 +1,157 @@
+//go:build integration
+
+package consistency_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/consistency"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type ConsistencyIntegrationTestSuite struct {
+	suite.Suite
+	db    *gorm.DB
+	store consistency.Store
+}
+
+func (ts *ConsistencyIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "consistency_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	ts.store = consistency.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+
+	err = db.Migrator().AutoMigrate(
+		&accounts.Account{},
+		&categories.Category{},
+		&labels.Label{},
+		&payees.Payee{},
+		&payees.Alias{},
+		&transactions.Transaction{},
+		&transactions.Split{},
+		&recurring.Template{},
+	)
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *ConsistencyIntegrationTestSuite) TestFindIssues_ForeignReference() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
+	cat := ts.create(categories.NewCategory(ws2, "food")).(*categories.Category)
+	acc := ts.create(accounts.NewAccount(ws2, "cash")).(*accounts.Account)
+	lbl := ts.create(labels.NewLabel(ws2, "trip")).(*labels.Label)
+	own := ts.create(categories.NewCategory(ws1, "food")).(*categories.Category)
+
+	foreignCat := ts.create(transactions.NewTransaction(ws1, "2010-01", "USD", -10, "lunch", cat)).(*transactions.Transaction)
+	foreignAcc := transactions.NewTransaction(ws1, "2010-01", "USD", -10, "lunch", own)
+	foreignAcc.AccountUUID = &acc.UUID
+	ts.create(foreignAcc)
+	foreignLbl := transactions.NewTransaction(ws1, "2010-01", "USD", -10, "lunch", own)
+	foreignLbl.Labels = labels.LabelCollection{lbl}
+	ts.create(foreignLbl)
+	valid := ts.create(transactions.NewTransaction(ws2, "2010-01", "USD", -10, "lunch", cat)).(*transactions.Transaction)
+	deleted := ts.create(transactions.NewTransaction(ws1, "2010-01", "USD", -10, "lunch", cat)).(*transactions.Transaction)
+	ts.Require().NoError(ts.db.Delete(deleted).Error, "Failed to delete testing transaction.")
+	tpl := ts.create(recurring.NewTemplate(ws1, recurring.CadenceMonthly, "2010-01", "", "USD", -10, "rent", nil, acc)).(*recurring.Template)
+
+	issues, err := ts.store.FindIssues(context.Background(), consistency.CheckForeignReference)
+	ts.Require().NoError(err, "Failed to check the data.")
+	found := ts.issuesOf(issues, foreignCat.UUID, foreignAcc.UUID, foreignLbl.UUID, valid.UUID, deleted.UUID, tpl.UUID)
+	ts.Require().Len(found, 4)
+	ts.Equal("transaction", found[foreignCat.UUID].Entity)
+	ts.Equal(ws1.UUID, found[foreignCat.UUID].WorkspaceUUID)
+	ts.Equal("category_uuid refers to a missing record or a record of another workspace", found[foreignCat.UUID].Detail)
+	ts.Equal("account_uuid refers to a missing record or a record of another workspace", found[foreignAcc.UUID].Detail)
+	ts.Equal("labels refer to a label of another workspace", found[foreignLbl.UUID].Detail)
+	ts.Equal("recurring", found[tpl.UUID].Entity)
+}
+
+func (ts *ConsistencyIntegrationTestSuite) TestFindIssues_SplitTotal() {
+	ws := ts.createTestingWorkspace()
+	invalid := transactions.NewTransaction(ws, "2010-01", "USD", -100, "market", nil)
+	invalid.Splits = transactions.SplitCollection{{Amount: -30}, {Amount: -50}}
+	ts.create(invalid)
+	valid := transactions.NewTransaction(ws, "2010-01", "USD", -100, "market", nil)
+	valid.Splits = transactions.SplitCollection{{Amount: -30}, {Amount: -70}}
+	ts.create(valid)
+
+	issues, err := ts.store.FindIssues(context.Background(), consistency.CheckSplitTotal)
+	ts.Require().NoError(err, "Failed to check the data.")
+	found := ts.issuesOf(issues, invalid.UUID, valid.UUID)
+	ts.Require().Len(found, 1)
+	ts.Equal(consistency.CheckSplitTotal, found[invalid.UUID].Check)
+	ts.Equal("splits sum up to -80 instead of -100", found[invalid.UUID].Detail)
+}
+
+func (ts *ConsistencyIntegrationTestSuite) TestFindIssues_TransactionMonth() {
+	ws := ts.createTestingWorkspace()
+	invalid := transactions.NewTransaction(ws, "", "USD", -10, "lunch", nil)
+	invalid.SetDate(time.Date(2010, 1, 31, 0, 0, 0, 0, time.UTC))
+	invalid.YearMonth = "2010-02"
+	ts.create(invalid)
+	valid := transactions.NewTransaction(ws, "", "USD", -10, "lunch", nil)
+	valid.SetDate(time.Date(2010, 1, 31, 0, 0, 0, 0, time.UTC))
+	ts.create(valid)
+	undated := ts.create(transactions.NewTransaction(ws, "2010-02", "USD", -10, "lunch", nil)).(*transactions.Transaction)
+
+	issues, err := ts.store.FindIssues(context.Background(), consistency.CheckTransactionMonth)
+	ts.Require().NoError(err, "Failed to check the data.")
+	found := ts.issuesOf(issues, invalid.UUID, valid.UUID, undated.UUID)
+	ts.Require().Len(found, 1)
+	ts.Equal("month 2010-02 does not match date 2010-01-31", found[invalid.UUID].Detail)
+}
+
+func (ts *ConsistencyIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func (ts *ConsistencyIntegrationTestSuite) create(record any) any {
+	ts.T().Helper()
+	if err := ts.db.Create(record).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing record: %s", err)
+	}
+	return record
+}
+
+// issuesOf picks the issues of the records created by the test, the other tests leave their issues in the DB too.
+func (ts *ConsistencyIntegrationTestSuite) issuesOf(issues consistency.IssueCollection, UUIDs ...uuid.UUID) map[uuid.UUID]*consistency.Issue {
+	found := make(map[uuid.UUID]*consistency.Issue)
+	for _, issue := range issues {
+		for _, UUID := range UUIDs {
+			if issue.UUID == UUID {
+				found[UUID] = issue
+			}
+		}
+	}
+	return found
+}
+
+func TestConsistencyIntegration(t *testing.T) {
+	suite.Run(t, new(ConsistencyIntegrationTestSuite))
+}
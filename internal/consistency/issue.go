@@ -0,0 +1,33 @@
+package consistency
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Check is a kind of inconsistency looked for in the data.
+type Check string
+
+const (
+	// CheckForeignReference finds records referring to records which are missing or belong to another workspace.
+	CheckForeignReference Check = "foreign_reference"
+	// CheckSplitTotal finds transactions whose split amounts do not sum up to the transaction amount.
+	CheckSplitTotal Check = "split_total"
+	// CheckTransactionMonth finds transactions whose month does not match their date.
+	CheckTransactionMonth Check = "transaction_month"
+)
+
+// Checks lists all the checks of the data.
+var Checks = []Check{CheckForeignReference, CheckSplitTotal, CheckTransactionMonth}
+
+// Issue represents an inconsistency found in the data.
+type Issue struct {
+	Check Check
+	// Entity is the kind of the inconsistent record.
+	Entity        string
+	UUID          uuid.UUID
+	WorkspaceUUID uuid.UUID
+	Detail        string
+}
+
+// IssueCollection represents a collection of issues.
+type IssueCollection []*Issue
@@ -0,0 +1,29 @@
+package consistency
+
+import (
+	"context"
+	"fmt"
+)
+
+// Service is a service responsible for checking consistency of the data.
+type Service struct {
+	db Store
+}
+
+// NewService initializes a new consistency service.
+func NewService(db Store) *Service {
+	return &Service{db: db}
+}
+
+// FindIssues runs all the checks of the data, listing the issues found grouped by the check.
+func (s *Service) FindIssues(ctx context.Context) (IssueCollection, error) {
+	issues := make(IssueCollection, 0)
+	for _, c := range Checks {
+		found, err := s.db.FindIssues(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", c, err)
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
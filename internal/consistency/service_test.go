@@ -0,0 +1,46 @@
+package consistency_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/consistency"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/consistency"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ConsistencyServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *consistency.Service
+}
+
+func (ts *ConsistencyServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = consistency.NewService(ts.store)
+}
+
+func (ts *ConsistencyServiceTestSuite) TestFindIssues() {
+	ctx := context.Background()
+	reference := &consistency.Issue{Check: consistency.CheckForeignReference, Entity: "transaction"}
+	split := &consistency.Issue{Check: consistency.CheckSplitTotal, Entity: "transaction"}
+	ts.store.On("FindIssues", ctx, consistency.CheckForeignReference).Return(consistency.IssueCollection{reference}, nil).Once()
+	ts.store.On("FindIssues", ctx, consistency.CheckSplitTotal).Return(consistency.IssueCollection{split}, nil).Once()
+	ts.store.On("FindIssues", ctx, consistency.CheckTransactionMonth).Return(consistency.IssueCollection{}, nil).Once()
+
+	issues, err := ts.srv.FindIssues(ctx)
+	ts.Require().NoError(err, "Failed to check the data.")
+	ts.Equal(consistency.IssueCollection{reference, split}, issues)
+}
+
+func (ts *ConsistencyServiceTestSuite) TestFindIssues_Error() {
+	ctx := context.Background()
+	ts.store.On("FindIssues", ctx, consistency.CheckForeignReference).Return(nil, fmt.Errorf("test")).Once()
+
+	_, err := ts.srv.FindIssues(ctx)
+	ts.EqualError(err, "failed to check foreign_reference: test")
+}
+
+func TestConsistencyService(t *testing.T) {
+	suite.Run(t, new(ConsistencyServiceTestSuite))
+}
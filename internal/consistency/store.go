@@ -0,0 +1,197 @@
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// batchSize is the number of transactions loaded at once by the checks done in the app.
+const batchSize = 500
+
+// Store is an interface for consistency checks DB API.
+type Store interface {
+	// FindIssues runs the check against all the live records.
+	FindIssues(ctx context.Context, c Check) (IssueCollection, error)
+}
+
+// gormStore is GORM implementation of Store.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore initializes GORM implementation of Store.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// reference describes a column of the records referring to the records of the same workspace.
+type reference struct {
+	entity string
+	model  any
+	column string
+	target any
+}
+
+var references = []reference{
+	{"transaction", &transactions.Transaction{}, "category_uuid", &categories.Category{}},
+	{"transaction", &transactions.Transaction{}, "account_uuid", &accounts.Account{}},
+	{"transaction", &transactions.Transaction{}, "payee_uuid", &payees.Payee{}},
+	{"recurring", &recurring.Template{}, "category_uuid", &categories.Category{}},
+	{"recurring", &recurring.Template{}, "account_uuid", &accounts.Account{}},
+}
+
+// row is a row of the inconsistent record.
+type row struct {
+	UUID          uuid.UUID
+	WorkspaceUUID uuid.UUID
+}
+
+func (s *gormStore) FindIssues(ctx context.Context, c Check) (IssueCollection, error) {
+	switch c {
+	case CheckForeignReference:
+		return s.findForeignReferences(ctx)
+	case CheckSplitTotal:
+		return s.findSplitTotalMismatches(ctx)
+	case CheckTransactionMonth:
+		return s.findTransactionMonthMismatches(ctx)
+	}
+	return nil, fmt.Errorf("unknown check %q", c)
+}
+
+// findForeignReferences finds live records referring to missing records or the records of another workspace.
+// Deleted records are still in the trash, so references to them are fine.
+func (s *gormStore) findForeignReferences(ctx context.Context) (IssueCollection, error) {
+	issues := make(IssueCollection, 0)
+	for _, ref := range references {
+		targets := datastore.Conn(ctx, s.db).Unscoped().Model(ref.target).Select("uuid, workspace_uuid")
+		var rows []*row
+		err := datastore.Conn(ctx, s.db).Model(ref.model).
+			Select("uuid, workspace_uuid").
+			Where(fmt.Sprintf("%[1]s IS NOT NULL AND (%[1]s, workspace_uuid) NOT IN (?)", ref.column), targets).
+			Order("uuid").
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			issues = append(issues, &Issue{
+				Check:         CheckForeignReference,
+				Entity:        ref.entity,
+				UUID:          r.UUID,
+				WorkspaceUUID: r.WorkspaceUUID,
+				Detail:        fmt.Sprintf("%s refers to a missing record or a record of another workspace", ref.column),
+			})
+		}
+	}
+	labeled, err := s.findForeignLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range labeled {
+		issues = append(issues, &Issue{
+			Check:         CheckForeignReference,
+			Entity:        "transaction",
+			UUID:          r.UUID,
+			WorkspaceUUID: r.WorkspaceUUID,
+			Detail:        "labels refer to a label of another workspace",
+		})
+	}
+	return issues, nil
+}
+
+// findForeignLabels finds live transactions labeled with the labels of another workspace.
+func (s *gormStore) findForeignLabels(ctx context.Context) ([]*row, error) {
+	db := datastore.Conn(ctx, s.db)
+	txTable, err := tableName(db, &transactions.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	lblTable, err := tableName(db, &labels.Label{})
+	if err != nil {
+		return nil, err
+	}
+	var rows []*row
+	err = db.Table("? AS tl", clause.Table{Name: db.NamingStrategy.JoinTableName("transaction_labels")}).
+		Joins("JOIN ? AS t ON t.uuid = tl.transaction_uuid", clause.Table{Name: txTable}).
+		Joins("JOIN ? AS l ON l.uuid = tl.label_uuid", clause.Table{Name: lblTable}).
+		Where("t.deleted_at IS NULL AND t.workspace_uuid <> l.workspace_uuid").
+		Distinct("t.uuid", "t.workspace_uuid").
+		Order("t.uuid").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// tableName provides the name of the table of the model.
+func tableName(db *gorm.DB, model any) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", err
+	}
+	return stmt.Table, nil
+}
+
+// findSplitTotalMismatches validates the splits of all the split transactions.
+func (s *gormStore) findSplitTotalMismatches(ctx context.Context) (IssueCollection, error) {
+	split := datastore.Conn(ctx, s.db).Model(&transactions.Split{}).Select("transaction_uuid")
+	issues := make(IssueCollection, 0)
+	var txs transactions.TransactionCollection
+	err := datastore.Conn(ctx, s.db).
+		Preload("Splits").
+		Where("uuid IN (?)", split).
+		FindInBatches(&txs, batchSize, func(*gorm.DB, int) error {
+			for _, tx := range txs {
+				if err := tx.ValidateSplits(); err != nil {
+					issues = append(issues, transactionIssue(CheckSplitTotal, tx, fmt.Sprintf("splits sum up to %g instead of %g", tx.Splits.GetTotal(), tx.Amount)))
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// findTransactionMonthMismatches compares the month of all the dated transactions with their date.
+func (s *gormStore) findTransactionMonthMismatches(ctx context.Context) (IssueCollection, error) {
+	issues := make(IssueCollection, 0)
+	var txs transactions.TransactionCollection
+	err := datastore.Conn(ctx, s.db).
+		Where("date IS NOT NULL").
+		FindInBatches(&txs, batchSize, func(*gorm.DB, int) error {
+			for _, tx := range txs {
+				if month := tx.Date.Format(accounts.FmtYearMonth); month != tx.YearMonth {
+					issues = append(issues, transactionIssue(CheckTransactionMonth, tx, fmt.Sprintf("month %s does not match date %s", tx.YearMonth, tx.Date.Format(time.DateOnly))))
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+func transactionIssue(c Check, tx *transactions.Transaction, detail string) *Issue {
+	return &Issue{
+		Check:         c,
+		Entity:        "transaction",
+		UUID:          tx.UUID,
+		WorkspaceUUID: tx.WorkspaceUUID,
+		Detail:        detail,
+	}
+}
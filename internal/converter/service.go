@@ -1,9 +1,12 @@
 package converter
 
-import "github.com/d-ashesss/mah-moneh/internal/accounts"
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+)
 
 type CurrencyService interface {
-	GetRate(base, target accounts.Currency, month string) float64
+	GetRate(ctx context.Context, base, target accounts.Currency, month string) float64
 }
 
 // Service represents converter service.
@@ -17,10 +20,10 @@ func NewService(cs CurrencyService) *Service {
 }
 
 // GetTotal calculates total amount in specified currency.
-func (s *Service) GetTotal(amounts accounts.CurrencyAmounts, targetCurrency accounts.Currency, month string) float64 {
+func (s *Service) GetTotal(ctx context.Context, amounts accounts.CurrencyAmounts, targetCurrency accounts.Currency, month string) float64 {
 	var total float64
 	for currency, amount := range amounts {
-		rate := s.currencies.GetRate(currency, targetCurrency, month)
+		rate := s.currencies.GetRate(ctx, currency, targetCurrency, month)
 		total += amount * rate
 	}
 	return total
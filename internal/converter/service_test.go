@@ -1,6 +1,7 @@
 package converter_test
 
 import (
+	"context"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/converter"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/converter"
@@ -16,20 +17,21 @@ type ConverterServiceTestSuite struct {
 
 func (ts *ConverterServiceTestSuite) SetupTest() {
 	cs := mocks.NewCurrencyService(ts.T())
-	cs.On("GetRate", accounts.Currency("usd"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(1.0).Maybe()
-	cs.On("GetRate", accounts.Currency("usd"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(1.1).Maybe()
-	cs.On("GetRate", accounts.Currency("usd"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(0.1).Maybe()
-	cs.On("GetRate", accounts.Currency("eur"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(1.0).Maybe()
-	cs.On("GetRate", accounts.Currency("eur"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(0.91).Maybe()
-	cs.On("GetRate", accounts.Currency("eur"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(0.091).Maybe()
-	cs.On("GetRate", accounts.Currency("btc"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(1.0).Maybe()
-	cs.On("GetRate", accounts.Currency("btc"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(10.0).Maybe()
-	cs.On("GetRate", accounts.Currency("btc"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(10.99).Maybe()
-	cs.On("GetRate", mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("string")).Return(0.0).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("usd"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(1.0).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("usd"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(1.1).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("usd"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(0.1).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("eur"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(1.0).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("eur"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(0.91).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("eur"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(0.091).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("btc"), accounts.Currency("btc"), mock.AnythingOfType("string")).Return(1.0).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("btc"), accounts.Currency("usd"), mock.AnythingOfType("string")).Return(10.0).Maybe()
+	cs.On("GetRate", mock.Anything, accounts.Currency("btc"), accounts.Currency("eur"), mock.AnythingOfType("string")).Return(10.99).Maybe()
+	cs.On("GetRate", mock.Anything, mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("string")).Return(0.0).Maybe()
 	ts.srv = converter.NewService(cs)
 }
 
 func (ts *ConverterServiceTestSuite) TestGetTotal() {
+	ctx := context.Background()
 	amounts := accounts.CurrencyAmounts{
 		"usd": 100,
 		"eur": 100,
@@ -38,14 +40,14 @@ func (ts *ConverterServiceTestSuite) TestGetTotal() {
 	}
 	var total float64
 
-	total = ts.srv.GetTotal(amounts, "usd", "2010-10")
+	total = ts.srv.GetTotal(ctx, amounts, "usd", "2010-10")
 	ts.InDelta(241., total, 0.001)
 
-	total = ts.srv.GetTotal(amounts, "eur", "2010-10")
+	total = ts.srv.GetTotal(ctx, amounts, "eur", "2010-10")
 	ts.T().Log(total)
 	ts.InDelta(264.95, total, 0.001)
 
-	total = ts.srv.GetTotal(amounts, "btc", "2010-10")
+	total = ts.srv.GetTotal(ctx, amounts, "btc", "2010-10")
 	ts.InDelta(24.1, total, 0.001)
 }
 
@@ -3,9 +3,14 @@
 package currencies_test
 
 import (
+	"context"
+	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/currencies"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 	"testing"
@@ -13,8 +18,10 @@ import (
 
 type CurrenciesIntegrationTestSuite struct {
 	suite.Suite
-	db  *gorm.DB
-	srv *currencies.Service
+	db    *gorm.DB
+	store currencies.Store
+	txm   datastore.TxManager
+	srv   *currencies.Service
 }
 
 func (ts *CurrenciesIntegrationTestSuite) SetupSuite() {
@@ -29,8 +36,9 @@ func (ts *CurrenciesIntegrationTestSuite) SetupSuite() {
 	}
 
 	ts.db = db.Session(&gorm.Session{NewDB: true})
-	store := currencies.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
-	ts.srv = currencies.NewService(store)
+	ts.store = currencies.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.txm = datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = currencies.NewService(ts.store, ts.txm, audit.Discard)
 
 	err = db.Migrator().AutoMigrate(&currencies.Rate{})
 	if err != nil {
@@ -43,7 +51,7 @@ func (ts *CurrenciesIntegrationTestSuite) TestSetRate() {
 		err  error
 		rate *currencies.Rate
 	)
-	err = ts.srv.SetRate("usd", "eur", "2010-10", 0.9)
+	err = ts.srv.SetRate(context.Background(), "usd", "eur", "2010-10", 0.9)
 	ts.Require().NoError(err, "Failed to set the rate.")
 
 	rate = &currencies.Rate{}
@@ -51,7 +59,7 @@ func (ts *CurrenciesIntegrationTestSuite) TestSetRate() {
 	ts.Require().NoError(err, "Failed to get the rate.")
 	ts.InDelta(0.9, rate.Rate, 0.001)
 
-	err = ts.srv.SetRate("usd", "eur", "2010-10", 1.1)
+	err = ts.srv.SetRate(context.Background(), "usd", "eur", "2010-10", 1.1)
 	ts.Require().NoError(err, "Failed to update the rate.")
 
 	rate = &currencies.Rate{}
@@ -60,24 +68,38 @@ func (ts *CurrenciesIntegrationTestSuite) TestSetRate() {
 	ts.InDelta(1.1, rate.Rate, 0.001)
 }
 
+func (ts *CurrenciesIntegrationTestSuite) TestSetRate_AuditFailure() {
+	ctx := context.Background()
+	rec := auditmocks.NewRecorder(ts.T())
+	rec.On("Record", mock.Anything, mock.AnythingOfType("*audit.Change")).Return(errors.New("failed")).Once()
+	srv := currencies.NewService(ts.store, ts.txm, rec)
+
+	err := srv.SetRate(ctx, "usd", "gbp", "2010-10", 0.8)
+	ts.Require().Error(err, "Rate must not be set without the audit record.")
+
+	err = ts.db.Where("base = ? AND target = ?", "usd", "gbp").First(&currencies.Rate{}).Error
+	ts.ErrorIs(err, gorm.ErrRecordNotFound, "Rate must be rolled back along with the audit record.")
+}
+
 func (ts *CurrenciesIntegrationTestSuite) TestGetRate() {
+	ctx := context.Background()
 	ts.createRate("usd", "eur", "2010-10", 1.1)
 	ts.createRate("usd", "eur", "2010-08", 1.0)
 	var rate float64
 
-	rate = ts.srv.GetRate("usd", "eur", "2010-07")
+	rate = ts.srv.GetRate(ctx, "usd", "eur", "2010-07")
 	ts.InDelta(1.0, rate, 0.001)
 
-	rate = ts.srv.GetRate("usd", "eur", "2010-08")
+	rate = ts.srv.GetRate(ctx, "usd", "eur", "2010-08")
 	ts.InDelta(1.0, rate, 0.001)
 
-	rate = ts.srv.GetRate("usd", "eur", "2010-09")
+	rate = ts.srv.GetRate(ctx, "usd", "eur", "2010-09")
 	ts.InDelta(1.0, rate, 0.001)
 
-	rate = ts.srv.GetRate("usd", "eur", "2010-11")
+	rate = ts.srv.GetRate(ctx, "usd", "eur", "2010-11")
 	ts.InDelta(1.1, rate, 0.001)
 
-	rate = ts.srv.GetRate("usd", "eth", "2010-11")
+	rate = ts.srv.GetRate(ctx, "usd", "eth", "2010-11")
 	ts.InDelta(0.0, rate, 0.001)
 }
 
@@ -96,3 +118,19 @@ func (ts *CurrenciesIntegrationTestSuite) createRate(base, target accounts.Curre
 func TestCurrenciesIntegration(t *testing.T) {
 	suite.Run(t, new(CurrenciesIntegrationTestSuite))
 }
+
+func TestGormStore(t *testing.T) {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		t.Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "crc_store_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to the DB: %s", err)
+	}
+	if err := db.Migrator().AutoMigrate(&currencies.Rate{}); err != nil {
+		t.Fatalf("Failed to migrate required tables: %s", err)
+	}
+	suite.Run(t, &StoreContractSuite{Store: currencies.NewGormStore(db.Session(&gorm.Session{NewDB: true}))})
+}
@@ -0,0 +1,81 @@
+package currencies
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"sort"
+	"sync"
+)
+
+// memoryStore is in-memory implementation of Store.
+type memoryStore struct {
+	mu    sync.RWMutex
+	rates []*Rate
+}
+
+// NewMemoryStore initializes in-memory implementation of Store.
+func NewMemoryStore() Store {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) SetRate(_ context.Context, base, target accounts.Currency, month string, rate float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.rates {
+		if r.Base == base && r.Target == target && r.YearMonth == month {
+			r.Rate = rate
+			return nil
+		}
+	}
+	s.rates = append(s.rates, &Rate{Base: base, Target: target, YearMonth: month, Rate: rate})
+	return nil
+}
+
+// GetRate retrieves the latest rate at or before the month, falling back to the earliest rate after it.
+func (s *memoryStore) GetRate(_ context.Context, base, target accounts.Currency, month string) (*Rate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var before, after *Rate
+	for _, r := range s.rates {
+		if r.Base != base || r.Target != target {
+			continue
+		}
+		if r.YearMonth <= month {
+			if before == nil || r.YearMonth > before.YearMonth {
+				before = r
+			}
+		} else if after == nil || r.YearMonth < after.YearMonth {
+			after = r
+		}
+	}
+	found := before
+	if found == nil {
+		found = after
+	}
+	if found == nil {
+		return nil, datastore.ErrRecordNotFound
+	}
+	r := *found
+	return &r, nil
+}
+
+func (s *memoryStore) GetRates(context.Context) (RateCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rates := make(RateCollection, 0, len(s.rates))
+	for _, r := range s.rates {
+		rate := *r
+		rates = append(rates, &rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Base != rates[j].Base {
+			return rates[i].Base < rates[j].Base
+		}
+		if rates[i].Target != rates[j].Target {
+			return rates[i].Target < rates[j].Target
+		}
+		return rates[i].YearMonth < rates[j].YearMonth
+	})
+	return rates, nil
+}
@@ -0,0 +1,11 @@
+package currencies_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	suite.Run(t, &StoreContractSuite{Store: currencies.NewMemoryStore()})
+}
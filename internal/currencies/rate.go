@@ -8,3 +8,6 @@ type Rate struct {
 	YearMonth string            `gorm:"primaryKey"`
 	Rate      float64
 }
+
+// RateCollection represents a collection of conversion rates.
+type RateCollection []*Rate
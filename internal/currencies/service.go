@@ -1,27 +1,60 @@
 package currencies
 
-import "github.com/d-ashesss/mah-moneh/internal/accounts"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+)
 
 // Service represents currencies service.
 type Service struct {
-	db Store
+	db    Store
+	txm   datastore.TxManager
+	audit audit.Recorder
 }
 
 // NewService initializes new currencies service.
-func NewService(db Store) *Service {
-	return &Service{db: db}
+func NewService(db Store, txm datastore.TxManager, rec audit.Recorder) *Service {
+	return &Service{db: db, txm: txm, audit: rec}
 }
 
 // SetRate sets the conversion rate for requested currencies in specified month.
-func (s *Service) SetRate(base, target accounts.Currency, month string, rate float64) error {
-	return s.db.SetRate(base, target, month, rate)
+func (s *Service) SetRate(ctx context.Context, base, target accounts.Currency, month string, rate float64) error {
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		var before *Rate
+		r, err := s.db.GetRate(ctx, base, target, month)
+		if err != nil && !errors.Is(err, datastore.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && r.YearMonth == month {
+			prev := *r
+			before = &prev
+		}
+		if err := s.db.SetRate(ctx, base, target, month, rate); err != nil {
+			return err
+		}
+		after := &Rate{Base: base, Target: target, YearMonth: month, Rate: rate}
+		id := fmt.Sprintf("%s/%s/%s", base, target, month)
+		if before == nil {
+			return s.audit.Record(ctx, audit.Created(audit.EntityRate, id, nil, after))
+		}
+		return s.audit.Record(ctx, audit.Updated(audit.EntityRate, id, nil, before, after))
+	})
 }
 
 // GetRate provides the conversion rate for requested currencies in specified month.
-func (s *Service) GetRate(base, target accounts.Currency, month string) float64 {
-	r, err := s.db.GetRate(base, target, month)
+func (s *Service) GetRate(ctx context.Context, base, target accounts.Currency, month string) float64 {
+	r, err := s.db.GetRate(ctx, base, target, month)
 	if err != nil {
 		return 0
 	}
 	return r.Rate
 }
+
+// GetRates provides all the known conversion rates.
+func (s *Service) GetRates(ctx context.Context) (RateCollection, error) {
+	return s.db.GetRates(ctx)
+}
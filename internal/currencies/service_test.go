@@ -1,9 +1,13 @@
 package currencies_test
 
 import (
+	"context"
 	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/currencies"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -13,32 +17,66 @@ import (
 type CurrenciesServiceTestSuite struct {
 	suite.Suite
 	store *mocks.Store
+	audit *auditmocks.Recorder
 	srv   *currencies.Service
 }
 
 func (ts *CurrenciesServiceTestSuite) SetupTest() {
 	ts.store = mocks.NewStore(ts.T())
-	ts.srv = currencies.NewService(ts.store)
+	ts.audit = auditmocks.NewRecorder(ts.T())
+	ts.srv = currencies.NewService(ts.store, datastore.NewNopTxManager(), ts.audit)
 }
 
 func (ts *CurrenciesServiceTestSuite) TestSetRate() {
-	ts.store.On("SetRate", accounts.Currency("usd"), accounts.Currency("eur"), "2010-10", 10.0).
+	ctx := context.Background()
+	ts.store.On("GetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10").
+		Return(&currencies.Rate{YearMonth: "2010-09", Rate: 9}, nil).Once()
+	ts.store.On("SetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10", 10.0).
 		Return(nil).Once()
-	err := ts.srv.SetRate("usd", "eur", "2010-10", 10)
+	ts.audit.On("Record", ctx, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == audit.ActionCreate && ch.Entity == audit.EntityRate && ch.EntityID == "usd/eur/2010-10"
+	})).Return(nil).Once()
+	err := ts.srv.SetRate(ctx, "usd", "eur", "2010-10", 10)
+	ts.Require().NoError(err, "Failed to set the rate.")
+}
+
+func (ts *CurrenciesServiceTestSuite) TestSetRate_Update() {
+	ctx := context.Background()
+	before := &currencies.Rate{Base: "usd", Target: "eur", YearMonth: "2010-10", Rate: 9}
+	ts.store.On("GetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10").
+		Return(before, nil).Once()
+	ts.store.On("SetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10", 10.0).
+		Return(nil).Once()
+	ts.audit.On("Record", ctx, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == audit.ActionUpdate && ch.Before.(*currencies.Rate).Rate == 9 && ch.After.(*currencies.Rate).Rate == 10
+	})).Return(nil).Once()
+	err := ts.srv.SetRate(ctx, "usd", "eur", "2010-10", 10)
+	ts.Require().NoError(err, "Failed to set the rate.")
+}
+
+func (ts *CurrenciesServiceTestSuite) TestSetRate_NotFound() {
+	ctx := context.Background()
+	ts.store.On("GetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10").
+		Return(nil, datastore.ErrRecordNotFound).Once()
+	ts.store.On("SetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10", 10.0).
+		Return(nil).Once()
+	ts.audit.On("Record", ctx, mock.AnythingOfType("*audit.Change")).Return(nil).Once()
+	err := ts.srv.SetRate(ctx, "usd", "eur", "2010-10", 10)
 	ts.Require().NoError(err, "Failed to set the rate.")
 }
 
 func (ts *CurrenciesServiceTestSuite) TestGetRate() {
+	ctx := context.Background()
 	eurRate := &currencies.Rate{Rate: 1.1}
-	ts.store.On("GetRate", accounts.Currency("usd"), accounts.Currency("eur"), "2010-10").
+	ts.store.On("GetRate", ctx, accounts.Currency("usd"), accounts.Currency("eur"), "2010-10").
 		Return(eurRate, nil)
-	ts.store.On("GetRate", mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("string")).
+	ts.store.On("GetRate", ctx, mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("accounts.Currency"), mock.AnythingOfType("string")).
 		Return(nil, errors.New("not found")).Maybe()
 
-	eur := ts.srv.GetRate("usd", "eur", "2010-10")
+	eur := ts.srv.GetRate(ctx, "usd", "eur", "2010-10")
 	ts.InDelta(1.1, eur, 0.001, "Got invalid rate.")
 
-	eth := ts.srv.GetRate("usd", "eth", "2010-10")
+	eth := ts.srv.GetRate(ctx, "usd", "eth", "2010-10")
 	ts.InDelta(0., eth, 0.001, "Got invalid rate.")
 }
 
@@ -1,18 +1,22 @@
 package currencies
 
 import (
+	"context"
 	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Store is an interface for currencies DB API.
 type Store interface {
 	// SetRate saves conversion rate into the DB.
-	SetRate(base, target accounts.Currency, month string, rate float64) error
+	SetRate(ctx context.Context, base, target accounts.Currency, month string, rate float64) error
 	// GetRate retrieves conversion rate from the DB.
-	GetRate(base, target accounts.Currency, month string) (*Rate, error)
+	GetRate(ctx context.Context, base, target accounts.Currency, month string) (*Rate, error)
+	// GetRates retrieves all the conversion rates from the DB.
+	GetRates(ctx context.Context) (RateCollection, error)
 }
 
 // gormStore is GORM implementation of Store.
@@ -24,19 +28,26 @@ func NewGormStore(db *gorm.DB) Store {
 	return &gormStore{db: db}
 }
 
-func (g *gormStore) SetRate(base, target accounts.Currency, month string, rate float64) error {
+func (g *gormStore) SetRate(ctx context.Context, base, target accounts.Currency, month string, rate float64) error {
 	r := &Rate{
 		Base:      base,
 		Target:    target,
 		YearMonth: month,
 		Rate:      rate,
 	}
-	return g.db.Save(r).Error
+	return datastore.Conn(ctx, g.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "base"},
+			{Name: "target"},
+			{Name: "year_month"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"rate"}),
+	}).Create(r).Error
 }
 
-func (g *gormStore) GetRate(base, target accounts.Currency, month string) (*Rate, error) {
+func (g *gormStore) GetRate(ctx context.Context, base, target accounts.Currency, month string) (*Rate, error) {
 	r := &Rate{}
-	query := g.db.
+	query := datastore.Conn(ctx, g.db).
 		Where("base = ?", base).
 		Where("target = ?", target).
 		Session(&gorm.Session{})
@@ -57,3 +68,11 @@ func (g *gormStore) GetRate(base, target accounts.Currency, month string) (*Rate
 	}
 	return r, nil
 }
+
+func (g *gormStore) GetRates(ctx context.Context) (RateCollection, error) {
+	rates := make(RateCollection, 0)
+	if err := datastore.Conn(ctx, g.db).Order("base").Order("target").Order("year_month").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
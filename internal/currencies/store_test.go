@@ -0,0 +1,119 @@
+package currencies_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"sync"
+)
+
+// StoreContractSuite verifies the behavior every Store implementation must have.
+type StoreContractSuite struct {
+	suite.Suite
+	Store currencies.Store
+}
+
+func (ts *StoreContractSuite) TestGetRate() {
+	ctx := context.Background()
+	base, target := ts.currency(), ts.currency()
+	for _, r := range []struct {
+		month string
+		rate  float64
+	}{
+		{"2010-08", 1.0},
+		{"2010-10", 1.1},
+		{"2010-10", 1.2},
+	} {
+		err := ts.Store.SetRate(ctx, base, target, r.month, r.rate)
+		ts.Require().NoError(err, "Failed to set the rate.")
+	}
+
+	tests := []struct {
+		name  string
+		month string
+		want  float64
+	}{
+		{name: "fallback to later", month: "2010-07", want: 1.0},
+		{name: "exact", month: "2010-08", want: 1.0},
+		{name: "latest before", month: "2010-09", want: 1.0},
+		{name: "updated", month: "2010-10", want: 1.2},
+		{name: "after all", month: "2011-01", want: 1.2},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			r, err := ts.Store.GetRate(ctx, base, target, tt.month)
+			ts.Require().NoError(err, "Failed to get the rate.")
+			ts.InDelta(tt.want, r.Rate, 0.001)
+		})
+	}
+}
+
+func (ts *StoreContractSuite) TestGetRate_NotFound() {
+	ctx := context.Background()
+	base, target := ts.currency(), ts.currency()
+	err := ts.Store.SetRate(ctx, base, target, "2010-10", 1.1)
+	ts.Require().NoError(err, "Failed to set the rate.")
+
+	_, err = ts.Store.GetRate(ctx, target, base, "2010-10")
+	ts.ErrorIs(err, datastore.ErrRecordNotFound, "Rates must not be inverted.")
+	_, err = ts.Store.GetRate(ctx, base, ts.currency(), "2010-10")
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *StoreContractSuite) TestGetRates() {
+	ctx := context.Background()
+	base, target := ts.currency(), ts.currency()
+	for _, r := range []struct {
+		month string
+		rate  float64
+	}{
+		{"2010-10", 1.1},
+		{"2010-08", 1.0},
+	} {
+		err := ts.Store.SetRate(ctx, base, target, r.month, r.rate)
+		ts.Require().NoError(err, "Failed to set the rate.")
+	}
+
+	rates, err := ts.Store.GetRates(ctx)
+	ts.Require().NoError(err, "Failed to get the rates.")
+	var months []string
+	for _, r := range rates {
+		if r.Base == base {
+			ts.Equal(target, r.Target)
+			months = append(months, r.YearMonth)
+		}
+	}
+	ts.Equal([]string{"2010-08", "2010-10"}, months)
+}
+
+func (ts *StoreContractSuite) TestConcurrentWrites() {
+	ctx := context.Background()
+	base, target := ts.currency(), ts.currency()
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- ts.Store.SetRate(ctx, base, target, "2010-10", float64(i))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		ts.Require().NoError(err, "Failed to write concurrently.")
+	}
+
+	_, err := ts.Store.GetRate(ctx, base, target, "2010-10")
+	ts.Require().NoError(err, "Failed to get the rate.")
+}
+
+// currency provides a unique currency code, so that the tests do not interfere through shared rates.
+func (ts *StoreContractSuite) currency() accounts.Currency {
+	return accounts.Currency(uuid.Must(uuid.NewV4()).String()[:8])
+}
@@ -5,13 +5,22 @@ import (
 	"github.com/joeshaw/envdecode"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type Config struct {
+	Driver string `env:"DB_DRIVER,default=postgres"`
+
 	Host     string `env:"DB_HOST,default=localhost"`
 	Port     string `env:"DB_PORT,default=5432"`
 	User     string `env:"DB_USER,default=postgres"`
 	Password string `env:"DB_PASSWORD"`
 	Name     string `env:"DB_NAME,default=postgres"`
 
+	SQLitePath string `env:"DB_SQLITE_PATH,default=data/mah-moneh.db"`
+
 	Debug bool `env:"DB_DEBUG,default=false"`
 
 	TablePrefix string
@@ -39,3 +48,8 @@ func (c *Config) Dsn() string {
 	dsn = fmt.Sprintf("%s database=%s", dsn, c.Name)
 	return dsn
 }
+
+// SQLiteDsn provides the connection string of the SQLite database with foreign keys enforced.
+func (c *Config) SQLiteDsn() string {
+	return fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", c.SQLitePath)
+}
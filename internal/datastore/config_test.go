@@ -47,3 +47,11 @@ func TestConfig_Dsn(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_SQLiteDsn(t *testing.T) {
+	cfg := &datastore.Config{SQLitePath: "data/test.db"}
+	want := "file:data/test.db?_foreign_keys=on&_busy_timeout=5000"
+	if got := cfg.SQLiteDsn(); got != want {
+		t.Errorf("SQLiteDsn() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Cursor points at the last record of a page in a list ordered by a value and the record UUID.
+type Cursor struct {
+	Value any       `json:"v,omitempty"`
+	UUID  uuid.UUID `json:"id"`
+}
+
+// NewCursor initializes a new cursor.
+func NewCursor(value any, UUID uuid.UUID) *Cursor {
+	return &Cursor{Value: value, UUID: UUID}
+}
+
+// Encode provides an opaque string representation of the cursor.
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses cursor from its opaque string representation.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &Cursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.UUID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
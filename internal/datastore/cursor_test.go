@@ -0,0 +1,37 @@
+package datastore_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+	"testing"
+)
+
+func TestCursor_Encode(t *testing.T) {
+	UUID := uuid.Must(uuid.NewV4())
+	c := datastore.NewCursor("2010-10", UUID)
+	got, err := datastore.DecodeCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if got.Value != "2010-10" || got.UUID != UUID {
+		t.Errorf("DecodeCursor() = %v, want %v", got, c)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "$$$"},
+		{name: "not json", cursor: "bm90IGpzb24"},
+		{name: "no uuid", cursor: "eyJ2IjoiMjAxMC0xMCJ9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := datastore.DecodeCursor(tt.cursor); err != datastore.ErrInvalidCursor {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, datastore.ErrInvalidCursor)
+			}
+		})
+	}
+}
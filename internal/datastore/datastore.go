@@ -4,31 +4,79 @@ import (
 	"fmt"
 	"github.com/gofrs/uuid"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
+	"os"
+	"path/filepath"
 	"time"
 )
 
 var (
 	ErrRecordNotFound = fmt.Errorf("record not found")
+	ErrRecordExists   = fmt.Errorf("record already exists")
 )
 
 // Model defines fields common for most models.
 type Model struct {
-	UUID      uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UUID      uuid.UUID `gorm:"type:uuid;primaryKey"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt
+	// Version is incremented with every change of the record, see Versioned.
+	Version uint `gorm:"notNull;default:1"`
+}
+
+// BeforeCreate generates UUID of a new record, so it does not depend on the DB engine.
+// New records start with the first version.
+func (m *Model) BeforeCreate(*gorm.DB) error {
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	if m.UUID != uuid.Nil {
+		return nil
+	}
+	UUID, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	m.UUID = UUID
+	return nil
+}
+
+// Stamp sets up a record the way gorm does on saving, for the stores not backed by gorm.
+// A new record gets its UUID and creation time, any record gets its update time.
+func (m *Model) Stamp(now time.Time) error {
+	if err := m.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+	return nil
 }
 
 func Open(cfg *Config) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(cfg.Dsn()), &gorm.Config{
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Discard,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Driver == DriverSQLite {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		// SQLite allows a single writer at a time, sharing one connection avoids busy errors.
+		sqlDB.SetMaxOpenConns(1)
+	}
 	if cfg.Debug {
 		db.Logger = logger.Default
 		db = db.Debug()
@@ -38,3 +86,24 @@ func Open(cfg *Config) (*gorm.DB, error) {
 	}
 	return db, nil
 }
+
+func openDialector(cfg *Config) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case DriverPostgres:
+		return postgres.Open(cfg.Dsn()), nil
+	case DriverSQLite:
+		if cfg.SQLitePath != ":memory:" {
+			if err := os.MkdirAll(filepath.Dir(cfg.SQLitePath), 0750); err != nil {
+				return nil, fmt.Errorf("failed to create database directory: %w", err)
+			}
+		}
+		return sqlite.Open(cfg.SQLiteDsn()), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+}
+
+// Driver provides the name of the DB engine the connection is using.
+func Driver(db *gorm.DB) string {
+	return db.Dialector.Name()
+}
@@ -0,0 +1,182 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+var (
+	ErrSchemaAhead    = fmt.Errorf("database schema is newer than the application")
+	ErrSchemaOutdated = fmt.Errorf("database schema has pending migrations")
+)
+
+// Migration is a single step of the database schema evolution.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// schemaMigration is a record of the applied migration.
+type schemaMigration struct {
+	Version   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"notNull"`
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations to the database and keeps track of the schema version.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewMigrator initializes a migrator of the list of migrations.
+func NewMigrator(db *gorm.DB, migrations []*Migration) *Migrator {
+	sorted := make([]*Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	return &Migrator{db: db, migrations: sorted}
+}
+
+// Latest provides the version of the most recent known migration.
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Initialized checks if the database keeps track of its schema version.
+func (m *Migrator) Initialized(ctx context.Context) bool {
+	return m.db.WithContext(ctx).Migrator().HasTable(&schemaMigration{})
+}
+
+// Version provides the version of the latest migration applied to the database.
+func (m *Migrator) Version(ctx context.Context) (uint, error) {
+	if !m.Initialized(ctx) {
+		return 0, nil
+	}
+	var version uint
+	err := m.db.WithContext(ctx).Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// Check verifies that the database schema matches the latest known migration.
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case version > m.Latest():
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaAhead, version, m.Latest())
+	case version < m.Latest():
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, version, m.Latest())
+	}
+	return nil
+}
+
+// Pending provides the migrations that were not applied to the database yet.
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version > m.Latest() {
+		return nil, fmt.Errorf("%w: version %d, expected %d", ErrSchemaAhead, version, m.Latest())
+	}
+	var pending []*Migration
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies all pending migrations, each in its own transaction.
+// It provides the applied migrations.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	if err := m.init(ctx); err != nil {
+		return nil, err
+	}
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, mig := range pending {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := mig.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("failed to apply migration %d %s: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down reverts the given number of the most recently applied migrations, each in its own transaction.
+// It provides the reverted migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version > m.Latest() {
+		return nil, fmt.Errorf("%w: version %d, expected %d", ErrSchemaAhead, version, m.Latest())
+	}
+	var reverted []*Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		mig := m.migrations[i]
+		if mig.Version > version {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := mig.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{Version: mig.Version}).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("failed to revert migration %d %s: %w", mig.Version, mig.Name, err)
+		}
+		reverted = append(reverted, mig)
+	}
+	return reverted, nil
+}
+
+// Baseline marks the migrations up to the given version as applied without running them.
+// It is meant for databases whose schema was created before the migrations were introduced.
+func (m *Migrator) Baseline(ctx context.Context, version uint) error {
+	if err := m.init(ctx); err != nil {
+		return err
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			err := tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+			if err != nil {
+				return fmt.Errorf("failed to mark migration %d %s as applied: %w", mig.Version, mig.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) init(ctx context.Context) error {
+	if err := m.db.WithContext(ctx).Migrator().AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema version table: %w", err)
+	}
+	return nil
+}
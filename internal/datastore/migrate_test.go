@@ -0,0 +1,151 @@
+package datastore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func openMemoryDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := datastore.Open(&datastore.Config{Driver: datastore.DriverSQLite, SQLitePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to open the DB: %s", err)
+	}
+	return db
+}
+
+func tableMigration(version uint, table string) *datastore.Migration {
+	return &datastore.Migration{
+		Version: version,
+		Name:    "create_" + table,
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec(fmt.Sprintf("CREATE TABLE %s (id integer)", table)).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec(fmt.Sprintf("DROP TABLE %s", table)).Error
+		},
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	m := datastore.NewMigrator(db, []*datastore.Migration{tableMigration(2, "second"), tableMigration(1, "first")})
+
+	if m.Latest() != 2 {
+		t.Errorf("Latest() = %d, want 2", m.Latest())
+	}
+	if err := m.Check(ctx); !errors.Is(err, datastore.ErrSchemaOutdated) {
+		t.Errorf("Check() error = %v, want %v", err, datastore.ErrSchemaOutdated)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(applied) != 2 || applied[0].Version != 1 || applied[1].Version != 2 {
+		t.Errorf("Up() applied unexpected migrations: %v", applied)
+	}
+	if !db.Migrator().HasTable("first") || !db.Migrator().HasTable("second") {
+		t.Errorf("Up() did not create the tables")
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("Up() = %v, %v, want no migrations applied", applied, err)
+	}
+
+	reverted, err := m.Down(ctx, 1)
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(reverted) != 1 || reverted[0].Version != 2 {
+		t.Errorf("Down() reverted unexpected migrations: %v", reverted)
+	}
+	if db.Migrator().HasTable("second") || !db.Migrator().HasTable("first") {
+		t.Errorf("Down() did not revert the latest migration only")
+	}
+	if version, err := m.Version(ctx); err != nil || version != 1 {
+		t.Errorf("Version() = %d, %v, want 1", version, err)
+	}
+}
+
+func TestMigrator_Ahead(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	if _, err := datastore.NewMigrator(db, []*datastore.Migration{tableMigration(1, "first"), tableMigration(2, "second")}).Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	m := datastore.NewMigrator(db, []*datastore.Migration{tableMigration(1, "first")})
+	if err := m.Check(ctx); !errors.Is(err, datastore.ErrSchemaAhead) {
+		t.Errorf("Check() error = %v, want %v", err, datastore.ErrSchemaAhead)
+	}
+	if _, err := m.Up(ctx); !errors.Is(err, datastore.ErrSchemaAhead) {
+		t.Errorf("Up() error = %v, want %v", err, datastore.ErrSchemaAhead)
+	}
+	if _, err := m.Down(ctx, 1); !errors.Is(err, datastore.ErrSchemaAhead) {
+		t.Errorf("Down() error = %v, want %v", err, datastore.ErrSchemaAhead)
+	}
+}
+
+func TestMigrator_Failure(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	broken := &datastore.Migration{
+		Version: 2,
+		Name:    "broken",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE TABLE partial (id integer)").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE TABLE invalid (").Error
+		},
+		Down: func(tx *gorm.DB) error { return nil },
+	}
+	m := datastore.NewMigrator(db, []*datastore.Migration{tableMigration(1, "first"), broken})
+
+	applied, err := m.Up(ctx)
+	if err == nil {
+		t.Fatalf("Up() expected error")
+	}
+	if len(applied) != 1 {
+		t.Errorf("Up() applied %d migrations, want 1", len(applied))
+	}
+	if db.Migrator().HasTable("partial") {
+		t.Errorf("Up() did not roll back the failed migration")
+	}
+	if version, err := m.Version(ctx); err != nil || version != 1 {
+		t.Errorf("Version() = %d, %v, want 1", version, err)
+	}
+}
+
+func TestMigrator_Baseline(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	m := datastore.NewMigrator(db, []*datastore.Migration{tableMigration(1, "first"), tableMigration(2, "second")})
+
+	if m.Initialized(ctx) {
+		t.Errorf("Initialized() = true on empty DB")
+	}
+	if err := m.Baseline(ctx, 1); err != nil {
+		t.Fatalf("Baseline() error = %v", err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != 1 {
+		t.Errorf("Version() = %d, %v, want 1", version, err)
+	}
+	if db.Migrator().HasTable("first") {
+		t.Errorf("Baseline() must not run the migrations")
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if !db.Migrator().HasTable("second") {
+		t.Errorf("Up() did not apply the migration after the baseline")
+	}
+}
@@ -0,0 +1,76 @@
+package datastore
+
+import (
+	"bytes"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// PageRequest defines a page of a list of records ordered by their creation time.
+// Records created at the same time are ordered by their UUIDs, so the order is stable.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// GetLimit provides the page size within allowed range.
+func (p *PageRequest) GetLimit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// After provides the position of the last record of the previous page, nil for the first page.
+func (p *PageRequest) After() (*Model, error) {
+	if p.Cursor == "" {
+		return nil, nil
+	}
+	c, err := DecodeCursor(p.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := c.Value.(string)
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Model{UUID: c.UUID, CreatedAt: createdAt}, nil
+}
+
+// Paginate restricts the query to the records of the page in their order.
+// One extra record is fetched to find out whether there is a next page.
+func (p *PageRequest) Paginate(query *gorm.DB) (*gorm.DB, error) {
+	after, err := p.After()
+	if err != nil {
+		return nil, err
+	}
+	if after != nil {
+		query = query.Where("(created_at, uuid) > (?, ?)", after.CreatedAt, after.UUID)
+	}
+	return query.Order("created_at").Order("uuid").Limit(p.GetLimit() + 1), nil
+}
+
+// PageCursor provides the cursor of the page following the record.
+func (m *Model) PageCursor() string {
+	return NewCursor(m.CreatedAt.Format(time.RFC3339Nano), m.UUID).Encode()
+}
+
+// Precedes reports whether the record comes before the other one in the order of pages.
+func (m *Model) Precedes(other *Model) bool {
+	if !m.CreatedAt.Equal(other.CreatedAt) {
+		return m.CreatedAt.Before(other.CreatedAt)
+	}
+	return bytes.Compare(m.UUID.Bytes(), other.UUID.Bytes()) < 0
+}
@@ -0,0 +1,63 @@
+package datastore_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+	"testing"
+	"time"
+)
+
+func TestPageRequest_GetLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: datastore.DefaultPageLimit},
+		{limit: -1, want: datastore.DefaultPageLimit},
+		{limit: 10, want: 10},
+		{limit: 1000, want: datastore.MaxPageLimit},
+	}
+	for _, tt := range tests {
+		p := &datastore.PageRequest{Limit: tt.limit}
+		if got := p.GetLimit(); got != tt.want {
+			t.Errorf("GetLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestPageRequest_After(t *testing.T) {
+	m := &datastore.Model{UUID: uuid.Must(uuid.NewV4()), CreatedAt: time.Date(2010, 10, 1, 12, 0, 0, 123456789, time.UTC)}
+	p := &datastore.PageRequest{Cursor: m.PageCursor()}
+	got, err := p.After()
+	if err != nil {
+		t.Fatalf("After() error = %v", err)
+	}
+	if got.UUID != m.UUID || !got.CreatedAt.Equal(m.CreatedAt) {
+		t.Errorf("After() = %v, want %v", got, m)
+	}
+
+	if got, err := (&datastore.PageRequest{}).After(); got != nil || err != nil {
+		t.Errorf("After() = %v, %v, want nil for the first page", got, err)
+	}
+
+	p = &datastore.PageRequest{Cursor: datastore.NewCursor("2010-10", m.UUID).Encode()}
+	if _, err := p.After(); err != datastore.ErrInvalidCursor {
+		t.Errorf("After() error = %v, want %v", err, datastore.ErrInvalidCursor)
+	}
+}
+
+func TestModel_Precedes(t *testing.T) {
+	now := time.Now()
+	first := &datastore.Model{UUID: uuid.FromStringOrNil("00000000-0000-4000-8000-000000000001"), CreatedAt: now}
+	second := &datastore.Model{UUID: uuid.FromStringOrNil("00000000-0000-4000-8000-000000000002"), CreatedAt: now}
+	later := &datastore.Model{UUID: uuid.FromStringOrNil("00000000-0000-4000-8000-000000000000"), CreatedAt: now.Add(time.Second)}
+	if !first.Precedes(second) || second.Precedes(first) {
+		t.Error("Records created at the same time must be ordered by UUID")
+	}
+	if !second.Precedes(later) || later.Precedes(first) {
+		t.Error("Records must be ordered by creation time")
+	}
+	if first.Precedes(first) {
+		t.Error("Record must not precede itself")
+	}
+}
@@ -0,0 +1,58 @@
+package datastore
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// TxManager runs units of work spanning several store operations atomically.
+type TxManager interface {
+	// InTx runs the function in a DB transaction propagated through the context.
+	// The transaction is committed if the function succeeds and rolled back otherwise.
+	// Nested calls join the transaction already active in the context.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// gormTxManager is GORM implementation of TxManager.
+type gormTxManager struct {
+	db *gorm.DB
+}
+
+// NewGormTxManager initializes GORM implementation of TxManager.
+// GORM stores pick up the transaction from the context with Conn.
+func NewGormTxManager(db *gorm.DB) TxManager {
+	return &gormTxManager{db: db}
+}
+
+func (m *gormTxManager) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// nopTxManager runs units of work as is.
+type nopTxManager struct{}
+
+// NewNopTxManager initializes TxManager for the stores that do not support transactions, like the in-memory ones.
+func NewNopTxManager() TxManager {
+	return nopTxManager{}
+}
+
+func (nopTxManager) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// Conn provides the DB transaction active in the context, or the DB itself when there is none.
+// Every operation of a GORM store must use it, otherwise it escapes the transaction
+// and may even block on the connection held by the transaction.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,79 @@
+package datastore_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type txRecord struct {
+	ID   uint
+	Name string
+}
+
+func countRecords(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&txRecord{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count records: %s", err)
+	}
+	return count
+}
+
+func TestGormTxManager(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&txRecord{}); err != nil {
+		t.Fatalf("Failed to create the table: %s", err)
+	}
+	txm := datastore.NewGormTxManager(db)
+	errFailed := fmt.Errorf("failed")
+
+	t.Run("commit", func(t *testing.T) {
+		err := txm.InTx(ctx, func(ctx context.Context) error {
+			if err := datastore.Conn(ctx, db).Create(&txRecord{Name: "first"}).Error; err != nil {
+				return err
+			}
+			return txm.InTx(ctx, func(ctx context.Context) error {
+				return datastore.Conn(ctx, db).Create(&txRecord{Name: "nested"}).Error
+			})
+		})
+		if err != nil {
+			t.Fatalf("InTx() error = %v", err)
+		}
+		if count := countRecords(t, db); count != 2 {
+			t.Errorf("Committed %d records, want 2", count)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		err := txm.InTx(ctx, func(ctx context.Context) error {
+			if err := datastore.Conn(ctx, db).Create(&txRecord{Name: "second"}).Error; err != nil {
+				return err
+			}
+			return txm.InTx(ctx, func(ctx context.Context) error {
+				return errFailed
+			})
+		})
+		if err != errFailed {
+			t.Errorf("InTx() error = %v, want %v", err, errFailed)
+		}
+		if count := countRecords(t, db); count != 2 {
+			t.Errorf("Found %d records after rollback, want 2", count)
+		}
+	})
+}
+
+func TestNopTxManager(t *testing.T) {
+	ctx := context.Background()
+	var called bool
+	err := datastore.NewNopTxManager().InTx(ctx, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Errorf("InTx() = %v, called %v", err, called)
+	}
+}
@@ -0,0 +1,81 @@
+package datastore
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"strings"
+)
+
+// StringList is a list of strings stored as an array in PostgreSQL and as a JSON array in other engines.
+type StringList []string
+
+// GormDataType provides the general data type of the list.
+func (StringList) GormDataType() string {
+	return "stringlist"
+}
+
+// GormDBDataType provides the column type for the DB engine.
+func (StringList) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if Driver(db) == DriverPostgres {
+		return "text[]"
+	}
+	return "text"
+}
+
+// GormValue encodes the list for the DB engine.
+func (l StringList) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	var v driver.Value
+	var err error
+	if Driver(db) == DriverPostgres {
+		v, err = pq.StringArray(l).Value()
+	} else {
+		v, err = l.Value()
+	}
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return clause.Expr{SQL: "?", Vars: []any{v}}
+}
+
+// Value encodes the list as a JSON array.
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan decodes the list from either a JSON or a PostgreSQL array.
+func (l *StringList) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot convert %T to StringList", src)
+	}
+	if strings.HasPrefix(s, "[") {
+		return json.Unmarshal([]byte(s), (*[]string)(l))
+	}
+	var a pq.StringArray
+	if err := a.Scan([]byte(s)); err != nil {
+		return err
+	}
+	*l = StringList(a)
+	return nil
+}
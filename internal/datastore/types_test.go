@@ -0,0 +1,42 @@
+package datastore_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"reflect"
+	"testing"
+)
+
+func TestStringList_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want datastore.StringList
+	}{
+		{name: "null", src: nil, want: nil},
+		{name: "json", src: `["food","home"]`, want: datastore.StringList{"food", "home"}},
+		{name: "json bytes", src: []byte(`["food"]`), want: datastore.StringList{"food"}},
+		{name: "postgres array", src: []byte(`{food,"home goods"}`), want: datastore.StringList{"food", "home goods"}},
+		{name: "empty postgres array", src: "{}", want: datastore.StringList{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got datastore.StringList
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringList_Value(t *testing.T) {
+	got, err := datastore.StringList{"food", "home"}.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if got != `["food","home"]` {
+		t.Errorf("Value() = %v, want %v", got, `["food","home"]`)
+	}
+}
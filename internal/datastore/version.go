@@ -0,0 +1,98 @@
+package datastore
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// ErrStaleRecord is returned on changing a record which has been changed by someone else since it was read.
+var ErrStaleRecord = fmt.Errorf("record has been modified")
+
+// Versioned is a record which version is incremented with every change,
+// so that changes made to a stale copy of the record are detected and rejected.
+// It is implemented by all the models embedding Model.
+type Versioned interface {
+	versioned() *Model
+}
+
+func (m *Model) versioned() *Model {
+	return m
+}
+
+// Save creates a new record, or updates all the fields of the existing one
+// unless it has been changed since it was read.
+// Records are new until they get their first version on creation.
+func Save(db *gorm.DB, r Versioned) error {
+	if r.versioned().Version == 0 {
+		return db.Create(r).Error
+	}
+	return update(db.Select("*"), r)
+}
+
+// Update updates non-zero fields of the existing record unless it has been changed since it was read.
+func Update(db *gorm.DB, r Versioned) error {
+	return update(db, r)
+}
+
+// Touch increments the version of the existing record unless it has been changed since it was read,
+// for the changes of the record stored apart from it.
+func Touch(db *gorm.DB, r Versioned) error {
+	return update(db.Select("version", "updated_at"), r)
+}
+
+func update(db *gorm.DB, r Versioned) error {
+	m := r.versioned()
+	version := m.Version
+	m.Version++
+	res := db.Model(r).Where("version = ?", version).Updates(r)
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = stale(db, r)
+	}
+	if res.Error != nil {
+		m.Version = version
+	}
+	return res.Error
+}
+
+// Delete deletes the record unless it has been changed since it was read.
+// Deleting missing records does nothing.
+func Delete(db *gorm.DB, r Versioned) error {
+	m := r.versioned()
+	if m.Version == 0 {
+		return db.Delete(r).Error
+	}
+	res := db.Where("version = ?", m.Version).Delete(r)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	if err := stale(db, r); err != ErrRecordNotFound {
+		return err
+	}
+	return nil
+}
+
+// stale explains why the record has not been changed, it is either stale or missing.
+func stale(db *gorm.DB, r Versioned) error {
+	var count int64
+	err := db.Session(&gorm.Session{NewDB: true}).Model(r).Where("uuid = ?", r.versioned().UUID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrStaleRecord
+	}
+	return ErrRecordNotFound
+}
+
+// Advance moves the record to the next version unless the stored copy of it has a different one,
+// for the stores not backed by gorm.
+func (m *Model) Advance(stored *Model) error {
+	if m.Version != stored.Version {
+		return ErrStaleRecord
+	}
+	m.Version++
+	return nil
+}
@@ -0,0 +1,131 @@
+package datastore_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+	"testing"
+)
+
+type versionRecord struct {
+	datastore.Model
+	Name string
+}
+
+func TestSave(t *testing.T) {
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&versionRecord{}); err != nil {
+		t.Fatalf("Failed to create the table: %s", err)
+	}
+
+	r := &versionRecord{Name: "first"}
+	if err := datastore.Save(db, r); err != nil {
+		t.Fatalf("Save() error = %v on new record", err)
+	}
+	if r.Version != 1 {
+		t.Errorf("Save() version = %d on new record, want 1", r.Version)
+	}
+
+	stale := *r
+	r.Name = "second"
+	if err := datastore.Save(db, r); err != nil {
+		t.Fatalf("Save() error = %v on existing record", err)
+	}
+	if r.Version != 2 {
+		t.Errorf("Save() version = %d on existing record, want 2", r.Version)
+	}
+
+	stale.Name = "stale"
+	if err := datastore.Save(db, &stale); err != datastore.ErrStaleRecord {
+		t.Errorf("Save() error = %v on stale record, want %v", err, datastore.ErrStaleRecord)
+	}
+	if stale.Version != 1 {
+		t.Errorf("Save() version = %d on stale record, want it kept 1", stale.Version)
+	}
+	found := &versionRecord{}
+	if err := db.First(found, "uuid = ?", r.UUID).Error; err != nil {
+		t.Fatalf("Failed to find the record: %s", err)
+	}
+	if found.Name != "second" || found.Version != 2 {
+		t.Errorf("Stored record = %q v%d, want %q v%d", found.Name, found.Version, "second", 2)
+	}
+
+	missing := &versionRecord{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4()), Version: 1}}
+	if err := datastore.Save(db, missing); err != datastore.ErrRecordNotFound {
+		t.Errorf("Save() error = %v on missing record, want %v", err, datastore.ErrRecordNotFound)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&versionRecord{}); err != nil {
+		t.Fatalf("Failed to create the table: %s", err)
+	}
+	r := &versionRecord{Name: "first"}
+	if err := db.Create(r).Error; err != nil {
+		t.Fatalf("Failed to create the record: %s", err)
+	}
+
+	update := &versionRecord{Model: datastore.Model{UUID: r.UUID, Version: r.Version}}
+	if err := datastore.Update(db, update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found := &versionRecord{}
+	if err := db.First(found, "uuid = ?", r.UUID).Error; err != nil {
+		t.Fatalf("Failed to find the record: %s", err)
+	}
+	if found.Name != "first" || found.Version != 2 {
+		t.Errorf("Stored record = %q v%d, want %q v%d", found.Name, found.Version, "first", 2)
+	}
+	if err := datastore.Update(db, r); err != datastore.ErrStaleRecord {
+		t.Errorf("Update() error = %v on stale record, want %v", err, datastore.ErrStaleRecord)
+	}
+}
+
+func TestTouch(t *testing.T) {
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&versionRecord{}); err != nil {
+		t.Fatalf("Failed to create the table: %s", err)
+	}
+	r := &versionRecord{Name: "first"}
+	if err := db.Create(r).Error; err != nil {
+		t.Fatalf("Failed to create the record: %s", err)
+	}
+
+	r.Name = "ignored"
+	if err := datastore.Touch(db, r); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+	found := &versionRecord{}
+	if err := db.First(found, "uuid = ?", r.UUID).Error; err != nil {
+		t.Fatalf("Failed to find the record: %s", err)
+	}
+	if found.Name != "first" || found.Version != 2 {
+		t.Errorf("Stored record = %q v%d, want %q v%d", found.Name, found.Version, "first", 2)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&versionRecord{}); err != nil {
+		t.Fatalf("Failed to create the table: %s", err)
+	}
+	r := &versionRecord{Name: "first"}
+	if err := db.Create(r).Error; err != nil {
+		t.Fatalf("Failed to create the record: %s", err)
+	}
+
+	stale := *r
+	stale.Version = 2
+	if err := datastore.Delete(db, &stale); err != datastore.ErrStaleRecord {
+		t.Errorf("Delete() error = %v on stale record, want %v", err, datastore.ErrStaleRecord)
+	}
+	if err := datastore.Delete(db, r); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := db.First(&versionRecord{}, "uuid = ?", r.UUID).Error; err == nil {
+		t.Errorf("Record was not deleted")
+	}
+	if err := datastore.Delete(db, r); err != nil {
+		t.Errorf("Delete() error = %v on missing record, want nil", err)
+	}
+}
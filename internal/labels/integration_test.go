@@ -0,0 +1,112 @@
+//go:build integration
+
+package labels_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type LabelsIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *labels.Service
+}
+
+func (ts *LabelsIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "lbl_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := labels.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = labels.NewService(store)
+
+	err = db.Migrator().AutoMigrate(&labels.Label{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *LabelsIntegrationTestSuite) TestCreateLabel() {
+	ws := ts.createTestingWorkspace()
+	lbl, err := ts.srv.CreateLabel(context.Background(), ws, "vacation-2024")
+	ts.Require().NoError(err, "Failed to create a label.")
+
+	foundLbl, err := ts.srv.GetLabel(context.Background(), lbl.UUID)
+	ts.Require().NoError(err, "Failed to find created label.")
+	ts.Equal(lbl.UUID, foundLbl.UUID)
+	ts.Equal("vacation-2024", foundLbl.Name)
+}
+
+func (ts *LabelsIntegrationTestSuite) TestUpdateLabel() {
+	ws := ts.createTestingWorkspace()
+	lbl := labels.NewLabel(ws, "vacation")
+	err := ts.db.Save(lbl).Error
+	ts.Require().NoError(err, "Failed to create testing label.")
+
+	lbl.Name = "vacation-2024"
+	err = ts.srv.UpdateLabel(context.Background(), lbl)
+	ts.Require().NoError(err, "Failed to update the label.")
+
+	foundLbl := &labels.Label{}
+	err = ts.db.First(foundLbl, "uuid = ?", lbl.UUID).Error
+	ts.Require().NoError(err, "Failed to find updated label.")
+	ts.Equal("vacation-2024", foundLbl.Name)
+}
+
+func (ts *LabelsIntegrationTestSuite) TestDeleteLabel() {
+	ws := ts.createTestingWorkspace()
+	lbl := labels.NewLabel(ws, "reimbursable")
+	err := ts.db.Save(lbl).Error
+	ts.Require().NoError(err, "Failed to create testing label.")
+
+	err = ts.srv.DeleteLabel(context.Background(), lbl)
+	ts.Require().NoError(err, "Failed to delete the label.")
+
+	_, err = ts.srv.GetLabel(context.Background(), lbl.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *LabelsIntegrationTestSuite) TestGetWorkspaceLabels() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
+	for _, lbl := range []*labels.Label{
+		labels.NewLabel(ws1, "vacation-2024"),
+		labels.NewLabel(ws1, "reimbursable"),
+		labels.NewLabel(ws2, "reimbursable"),
+	} {
+		err := ts.db.Save(lbl).Error
+		ts.Require().NoError(err, "Failed to create testing label.")
+	}
+
+	lbls, err := ts.srv.GetWorkspaceLabels(context.Background(), ws1)
+	ts.Require().NoError(err, "Failed to get user labels.")
+	ts.Require().Len(lbls, 2)
+	ts.Equal("reimbursable", lbls[0].Name)
+	ts.Equal("vacation-2024", lbls[1].Name)
+}
+
+func (ts *LabelsIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func TestLabelsIntegration(t *testing.T) {
+	suite.Run(t, new(LabelsIntegrationTestSuite))
+}
@@ -0,0 +1,23 @@
+package labels
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+)
+
+// Label represents a free-form label that can be attached to transactions.
+type Label struct {
+	datastore.Model
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string                `gorm:"notNull"`
+}
+
+// NewLabel initializes a new label.
+func NewLabel(ws *workspaces.Workspace, name string) *Label {
+	return &Label{WorkspaceUUID: ws.UUID, Name: name}
+}
+
+// LabelCollection represents a collection of label entities.
+type LabelCollection []*Label
@@ -0,0 +1,39 @@
+package labels
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+)
+
+type Service struct {
+	db Store
+}
+
+func NewService(db Store) *Service {
+	return &Service{db: db}
+}
+
+func (s *Service) CreateLabel(ctx context.Context, ws *workspaces.Workspace, name string) (*Label, error) {
+	lbl := NewLabel(ws, name)
+	if err := s.db.SaveLabel(ctx, lbl); err != nil {
+		return nil, err
+	}
+	return lbl, nil
+}
+
+func (s *Service) UpdateLabel(ctx context.Context, lbl *Label) error {
+	return s.db.SaveLabel(ctx, lbl)
+}
+
+func (s *Service) GetLabel(ctx context.Context, UUID uuid.UUID) (*Label, error) {
+	return s.db.GetLabel(ctx, UUID)
+}
+
+func (s *Service) DeleteLabel(ctx context.Context, lbl *Label) error {
+	return s.db.DeleteLabel(ctx, lbl)
+}
+
+func (s *Service) GetWorkspaceLabels(ctx context.Context, ws *workspaces.Workspace) (LabelCollection, error) {
+	return s.db.GetWorkspaceLabels(ctx, ws)
+}
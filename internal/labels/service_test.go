@@ -0,0 +1,72 @@
+package labels_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/labels"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type LabelsServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *labels.Service
+}
+
+func (ts *LabelsServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = labels.NewService(ts.store)
+}
+
+func (ts *LabelsServiceTestSuite) TestCreateLabel() {
+	ctx := context.Background()
+	ts.store.On("SaveLabel", ctx, mock.AnythingOfType("*labels.Label")).Return(nil)
+	ws := &workspaces.Workspace{}
+	lbl, err := ts.srv.CreateLabel(ctx, ws, "vacation-2024")
+	ts.Require().NoError(err, "Failed to create label.")
+	ts.Require().NotNil(lbl, "Received nil label.")
+	ts.Equal("vacation-2024", lbl.Name)
+}
+
+func (ts *LabelsServiceTestSuite) TestUpdateLabel() {
+	ctx := context.Background()
+	lbl := &labels.Label{}
+	ts.store.On("SaveLabel", ctx, lbl).Return(nil)
+	err := ts.srv.UpdateLabel(ctx, lbl)
+	ts.Require().NoError(err, "Failed to update label.")
+}
+
+func (ts *LabelsServiceTestSuite) TestGetLabel() {
+	ctx := context.Background()
+	UUID := uuid.Must(uuid.NewV4())
+	protoLbl := &labels.Label{}
+	ts.store.On("GetLabel", ctx, UUID).Return(protoLbl, nil)
+	lbl, err := ts.srv.GetLabel(ctx, UUID)
+	ts.Require().NoError(err, "Failed to get label.")
+	ts.Equal(protoLbl, lbl)
+}
+
+func (ts *LabelsServiceTestSuite) TestDeleteLabel() {
+	ctx := context.Background()
+	lbl := &labels.Label{}
+	ts.store.On("DeleteLabel", ctx, lbl).Return(nil)
+	err := ts.srv.DeleteLabel(ctx, lbl)
+	ts.Require().NoError(err, "Failed to delete label.")
+}
+
+func (ts *LabelsServiceTestSuite) TestGetWorkspaceLabels() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	ts.store.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{}, nil)
+	lbls, err := ts.srv.GetWorkspaceLabels(ctx, ws)
+	ts.Require().NoError(err, "Failed to get user labels.")
+	ts.Require().NotNil(lbls, "Got nil labels.")
+}
+
+func TestLabelsService(t *testing.T) {
+	suite.Run(t, new(LabelsServiceTestSuite))
+}
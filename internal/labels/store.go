@@ -0,0 +1,53 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+type Store interface {
+	SaveLabel(ctx context.Context, lbl *Label) error
+	DeleteLabel(ctx context.Context, lbl *Label) error
+	GetLabel(ctx context.Context, UUID uuid.UUID) (*Label, error)
+	GetWorkspaceLabels(ctx context.Context, ws *workspaces.Workspace) (LabelCollection, error)
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) SaveLabel(ctx context.Context, lbl *Label) error {
+	return datastore.Save(datastore.Conn(ctx, s.db), lbl)
+}
+
+func (s *gormStore) DeleteLabel(ctx context.Context, lbl *Label) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db), lbl)
+}
+
+func (s *gormStore) GetLabel(ctx context.Context, UUID uuid.UUID) (*Label, error) {
+	var lbl Label
+	err := datastore.Conn(ctx, s.db).Where("uuid = ?", UUID).First(&lbl).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lbl, nil
+}
+
+func (s *gormStore) GetWorkspaceLabels(ctx context.Context, ws *workspaces.Workspace) (LabelCollection, error) {
+	lbls := make(LabelCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID).Order("name").Find(&lbls).Error; err != nil {
+		return nil, err
+	}
+	return lbls, nil
+}
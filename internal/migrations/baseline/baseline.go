@@ -0,0 +1,216 @@
+// Package baseline keeps the models as of the baseline version of the schema.
+//
+// Databases created by AutoMigrate of the releases before migrations are brought to the baseline schema
+// with these models, so they do not change along with the models of the application.
+package baseline
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Models are all the models of the baseline schema.
+var Models = []any{
+	&Profile{},
+	&Token{},
+	&Workspace{},
+	&Member{},
+	&Invitation{},
+	&Account{},
+	&Amount{},
+	&Category{},
+	&Label{},
+	&Payee{},
+	&Alias{},
+	&Transaction{},
+	&Split{},
+	&Attachment{},
+	&Template{},
+	&Occurrence{},
+}
+
+// OwnedModels are the models that used to be owned by users before workspaces.
+var OwnedModels = []any{
+	&Account{},
+	&Category{},
+	&Label{},
+	&Payee{},
+	&Transaction{},
+	&Attachment{},
+	&Template{},
+}
+
+// Model defines fields common for most models.
+type Model struct {
+	UUID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+type User struct {
+	ID string `gorm:"notNull"`
+}
+
+type Settings struct {
+	DefaultCurrency string `gorm:"notNull"`
+	Locale          string `gorm:"notNull"`
+	FiscalYearStart string `gorm:"notNull"`
+}
+
+type Profile struct {
+	ID        string   `gorm:"primaryKey"`
+	Subject   string   `gorm:"notNull;index"`
+	Issuer    string   `gorm:"notNull"`
+	Email     string   `gorm:"notNull"`
+	Name      string   `gorm:"notNull"`
+	Settings  Settings `gorm:"embedded;embeddedPrefix:settings_"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Token struct {
+	Model
+	User       *User  `gorm:"embedded;embeddedPrefix:user_;notNull"`
+	Name       string `gorm:"notNull"`
+	Scope      string `gorm:"notNull"`
+	Hash       string `gorm:"notNull;uniqueIndex"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+type Workspace struct {
+	Model
+	Name           string   `gorm:"notNull"`
+	PersonalUserID *string  `gorm:"uniqueIndex"`
+	Members        []Member `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type Member struct {
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID        string     `gorm:"primaryKey"`
+	Role          string     `gorm:"notNull"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Invitation struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;notNull;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Role          string     `gorm:"notNull"`
+	TokenHash     string     `gorm:"notNull;uniqueIndex"`
+	InvitedBy     *User      `gorm:"embedded;embeddedPrefix:invited_by_;notNull"`
+	ExpiresAt     time.Time  `gorm:"notNull"`
+	AcceptedBy    *string
+	AcceptedAt    *time.Time
+}
+
+type Account struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string     `gorm:"notNull"`
+}
+
+type Amount struct {
+	AccountUUID  uuid.UUID `gorm:"primaryKey"`
+	Account      *Account  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	YearMonth    string    `gorm:"primaryKey;type:varchar(7);notNull"`
+	CurrencyCode string    `gorm:"primaryKey;notNull"`
+	Amount       float64
+}
+
+type Category struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string
+	Tags          datastore.StringList
+}
+
+type Label struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string     `gorm:"notNull"`
+}
+
+type Payee struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string     `gorm:"notNull"`
+	Aliases       []Alias    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type Alias struct {
+	PayeeUUID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"primaryKey"`
+}
+
+type Transaction struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Date          *time.Time `gorm:"type:date;index"`
+	YearMonth     string     `gorm:"index"`
+	Currency      string     `gorm:"index"`
+	Amount        float64    `gorm:"index"`
+	Description   string
+	CategoryUUID  *uuid.UUID `gorm:"index"`
+	Category      *Category  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	AccountUUID   *uuid.UUID `gorm:"index"`
+	Account       *Account   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	PayeeUUID     *uuid.UUID `gorm:"index"`
+	Payee         *Payee     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Splits        []Split    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Labels        []Label    `gorm:"many2many:transaction_labels;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type Split struct {
+	Model
+	TransactionUUID uuid.UUID  `gorm:"type:uuid;notNull;index"`
+	CategoryUUID    *uuid.UUID `gorm:"index"`
+	Category        *Category  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Amount          float64
+	Note            string
+}
+
+type Attachment struct {
+	Model
+	WorkspaceUUID   uuid.UUID    `gorm:"type:uuid;index"`
+	Workspace       *Workspace   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TransactionUUID uuid.UUID    `gorm:"type:uuid;notNull;index"`
+	Transaction     *Transaction `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name            string       `gorm:"notNull"`
+	ContentType     string       `gorm:"notNull"`
+	Size            int64        `gorm:"notNull"`
+}
+
+type Template struct {
+	Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Cadence       string     `gorm:"notNull"`
+	StartMonth    string     `gorm:"type:varchar(7);notNull"`
+	EndMonth      string     `gorm:"type:varchar(7)"`
+	Currency      string
+	Amount        float64
+	Description   string
+	CategoryUUID  *uuid.UUID `gorm:"index"`
+	Category      *Category  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	AccountUUID   *uuid.UUID `gorm:"index"`
+	Account       *Account   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+type Occurrence struct {
+	TemplateUUID    uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Template        *Template  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	YearMonth       string     `gorm:"primaryKey;type:varchar(7);notNull"`
+	TransactionUUID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time
+}
@@ -0,0 +1,60 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// MigrateUserOwnership moves records of the models that are still owned by users into personal workspaces of those users.
+// Tables that were already migrated are skipped, so it is safe to call it on every start.
+func MigrateUserOwnership(db *gorm.DB) error {
+	m := db.Migrator()
+	for _, model := range OwnedModels {
+		if !m.HasColumn(model, "user_id") {
+			continue
+		}
+		var IDs []string
+		err := db.Model(model).Unscoped().Where("workspace_uuid IS NULL").Distinct().Pluck("user_id", &IDs).Error
+		if err != nil {
+			return fmt.Errorf("failed to list owners: %w", err)
+		}
+		for _, ID := range IDs {
+			ws, err := personalWorkspace(db, ID)
+			if err != nil {
+				return fmt.Errorf("failed to get personal workspace: %w", err)
+			}
+			err = db.Model(model).Unscoped().
+				Where("user_id = ? AND workspace_uuid IS NULL", ID).
+				Update("workspace_uuid", ws.UUID).Error
+			if err != nil {
+				return fmt.Errorf("failed to move records into workspace: %w", err)
+			}
+		}
+		if err := m.DropColumn(model, "user_id"); err != nil {
+			return fmt.Errorf("failed to drop owner column: %w", err)
+		}
+	}
+	return nil
+}
+
+// personalWorkspace finds the personal workspace of the user, creating it with the user as the owner if needed.
+func personalWorkspace(db *gorm.DB, userID string) (*Workspace, error) {
+	ws := &Workspace{}
+	err := db.Where("personal_user_id = ?", userID).First(ws).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ws, err
+	}
+	UUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	ws = &Workspace{
+		Model:          Model{UUID: UUID},
+		Name:           "Personal",
+		PersonalUserID: &userID,
+		Members:        []Member{{UserID: userID, Role: "owner"}},
+	}
+	return ws, db.Create(ws).Error
+}
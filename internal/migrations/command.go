@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/log"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// Usage describes the arguments of the migrate command.
+const Usage = "usage: migrate [up | down [steps] | status]"
+
+// Run runs the migrate command of the application binaries.
+// Only up adopts the database created by the earlier releases, the other commands leave it as it is.
+func Run(ctx context.Context, db *gorm.DB, args []string) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	switch {
+	case command == "up" && len(args) <= 1:
+		if _, err := Adopt(ctx, db, m); err != nil {
+			return err
+		}
+		return Up(ctx, m)
+	case command == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			log.Infof("[MIGRATE] Reverted %d %s", mig.Version, mig.Name)
+		}
+		return err
+	case command == "status" && len(args) <= 1:
+		if Adoptable(ctx, db, m) {
+			log.Infof("[MIGRATE] Database is not under migrations yet, up will adopt it as of version %d, latest version %d", BaselineVersion, m.Latest())
+			return nil
+		}
+		version, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		log.Infof("[MIGRATE] Database version %d, latest version %d", version, m.Latest())
+		pending, err := m.Pending(ctx)
+		for _, mig := range pending {
+			log.Infof("[MIGRATE] Pending %d %s", mig.Version, mig.Name)
+		}
+		return err
+	default:
+		return fmt.Errorf(Usage)
+	}
+}
+
+// Up applies all the pending migrations, logging every applied one.
+func Up(ctx context.Context, m *datastore.Migrator) error {
+	applied, err := m.Up(ctx)
+	for _, mig := range applied {
+		log.Infof("[MIGRATE] Applied %d %s", mig.Version, mig.Name)
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+// Package migrations keeps the schema migrations of the application database.
+//
+// Every migration is a pair of SQL files named "<version>_<name>.up.sql" and "<version>_<name>.down.sql"
+// kept in the directory of each supported DB engine. Statements in the files are separated by semicolons
+// at the end of the line.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/migrations/baseline"
+	"gorm.io/gorm"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// BaselineVersion is the version of the schema created by AutoMigrate of the releases before migrations.
+const BaselineVersion = 1
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// NewMigrator initializes a migrator of the migrations for the DB engine the connection is using.
+func NewMigrator(db *gorm.DB) (*datastore.Migrator, error) {
+	migrations, err := Load(files, datastore.Driver(db))
+	if err != nil {
+		return nil, err
+	}
+	return datastore.NewMigrator(db, migrations), nil
+}
+
+// Load reads the migrations from the directory of the file system.
+func Load(fsys fs.FS, dir string) ([]*datastore.Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	migrations := make(map[uint]*datastore.Migration)
+	var versions []uint
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".sql")
+		if !ok {
+			continue
+		}
+		name, direction := strings.TrimSuffix(name, path.Ext(name)), strings.TrimPrefix(path.Ext(name), ".")
+		prefix, name, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, err := strconv.ParseUint(prefix, 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in file name %q", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration: %w", err)
+		}
+		mig, ok := migrations[uint(version)]
+		if !ok {
+			mig = &datastore.Migration{Version: uint(version), Name: name}
+			migrations[uint(version)] = mig
+			versions = append(versions, uint(version))
+		} else if mig.Name != name {
+			return nil, fmt.Errorf("conflicting names of migration %d: %q and %q", version, mig.Name, name)
+		}
+		switch direction {
+		case "up":
+			mig.Up = execStatements(string(content))
+		case "down":
+			mig.Down = execStatements(string(content))
+		default:
+			return nil, fmt.Errorf("invalid migration direction in file name %q", entry.Name())
+		}
+	}
+	list := make([]*datastore.Migration, 0, len(versions))
+	for _, version := range versions {
+		mig := migrations[version]
+		if mig.Up == nil || mig.Down == nil {
+			return nil, fmt.Errorf("migration %d %s must have both up and down files", mig.Version, mig.Name)
+		}
+		list = append(list, mig)
+	}
+	return list, nil
+}
+
+// execStatements provides a migration function executing the SQL statements one by one.
+func execStatements(content string) func(tx *gorm.DB) error {
+	var statements []string
+	for _, stmt := range strings.Split(content, ";\n") {
+		if stmt = strings.TrimSpace(stmt); len(stmt) > 0 {
+			statements = append(statements, strings.TrimSuffix(stmt, ";"))
+		}
+	}
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Adopt puts the database created by AutoMigrate of the earlier releases under control of the migrations.
+// The schema is brought to the baseline version first, user data which is not moved into workspaces yet
+// is moved into the personal workspaces of its users, and the schema is marked as of the baseline version.
+// It reports whether the database needed it.
+func Adopt(ctx context.Context, db *gorm.DB, m *datastore.Migrator) (bool, error) {
+	if !Adoptable(ctx, db, m) {
+		return false, nil
+	}
+	err := withoutForeignKeys(db.WithContext(ctx), func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(baseline.Models...); err != nil {
+				return fmt.Errorf("failed to create baseline schema: %w", err)
+			}
+			if err := baseline.MigrateUserOwnership(tx); err != nil {
+				return fmt.Errorf("failed to move user data into workspaces: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := m.Baseline(ctx, BaselineVersion); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Adoptable checks whether the database was created by AutoMigrate of the earlier releases
+// and is not under control of the migrations yet.
+func Adoptable(ctx context.Context, db *gorm.DB, m *datastore.Migrator) bool {
+	return !m.Initialized(ctx) && db.WithContext(ctx).Migrator().HasTable(&baseline.Account{})
+}
+
+// withoutForeignKeys runs the function with foreign keys disabled on SQLite.
+// SQLite changes tables by recreating them, and dropping the old tables would otherwise
+// cascade to the records referring to them.
+func withoutForeignKeys(db *gorm.DB, fc func(db *gorm.DB) error) error {
+	if datastore.Driver(db) != datastore.DriverSQLite {
+		return fc(db)
+	}
+	if err := db.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+		return err
+	}
+	defer db.Exec("PRAGMA foreign_keys = ON")
+	return fc(db)
+}
@@ -0,0 +1,394 @@
+package migrations_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/currencies"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/migrations"
+	"github.com/d-ashesss/mah-moneh/internal/migrations/baseline"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"os"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// baselineModels are the models persisted in the DB before versioned migrations.
+var baselineModels = []any{
+	&users.Profile{},
+	&tokens.Token{},
+	&workspaces.Workspace{},
+	&workspaces.Member{},
+	&workspaces.Invitation{},
+	&accounts.Account{},
+	&accounts.Amount{},
+	&categories.Category{},
+	&labels.Label{},
+	&payees.Payee{},
+	&payees.Alias{},
+	&transactions.Transaction{},
+	&transactions.Split{},
+	&attachments.Attachment{},
+	&recurring.Template{},
+	&recurring.Occurrence{},
+}
+
+// models are all the models persisted in the DB.
+var models = append(baselineModels, &currencies.Rate{}, &audit.Event{})
+
+func openMemoryDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := datastore.Open(&datastore.Config{Driver: datastore.DriverSQLite, SQLitePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to open the DB: %s", err)
+	}
+	return db
+}
+
+// assertSchema checks that the DB has the tables and the columns of all the models.
+func assertSchema(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	for _, model := range models {
+		s, err := schema.Parse(model, &sync.Map{}, db.NamingStrategy)
+		if err != nil {
+			t.Fatalf("Failed to parse model %T: %s", model, err)
+		}
+		if !db.Migrator().HasTable(s.Table) {
+			t.Errorf("Table %s of %T is not created", s.Table, model)
+			continue
+		}
+		for _, field := range s.Fields {
+			if len(field.DBName) > 0 && !db.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("Column %s.%s of %T is not created", s.Table, field.DBName, model)
+			}
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"db/0002_second.up.sql":   {Data: []byte("CREATE TABLE second (id integer);\nCREATE TABLE third (id integer);\n")},
+		"db/0002_second.down.sql": {Data: []byte("DROP TABLE third;\nDROP TABLE second;\n")},
+		"db/0001_first.up.sql":    {Data: []byte("CREATE TABLE first (id integer);\n")},
+		"db/0001_first.down.sql":  {Data: []byte("DROP TABLE first;\n")},
+		"db/README.md":            {Data: []byte("not a migration")},
+	}
+	got, err := migrations.Load(fsys, "db")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Version != 1 || got[0].Name != "first" || got[1].Version != 2 || got[1].Name != "second" {
+		t.Fatalf("Load() = %v", got)
+	}
+
+	db := openMemoryDB(t)
+	if err := got[1].Up(db); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if !db.Migrator().HasTable("second") || !db.Migrator().HasTable("third") {
+		t.Errorf("Up() did not run all the statements")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+	}{
+		{name: "no version", files: []string{"db/first.up.sql"}},
+		{name: "invalid version", files: []string{"db/v1_first.up.sql"}},
+		{name: "invalid direction", files: []string{"db/0001_first.sideways.sql"}},
+		{name: "no down", files: []string{"db/0001_first.up.sql"}},
+		{name: "conflicting names", files: []string{"db/0001_first.up.sql", "db/0001_other.down.sql"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for _, file := range tt.files {
+				fsys[file] = &fstest.MapFile{Data: []byte("SELECT 1;\n")}
+			}
+			if _, err := migrations.Load(fsys, "db"); err == nil {
+				t.Errorf("Load() expected error")
+			}
+		})
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	assertSchema(t, db)
+
+	if _, err := m.Down(ctx, int(m.Latest())); err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	for _, model := range models {
+		if db.Migrator().HasTable(model) {
+			t.Errorf("Table of %T is not dropped", model)
+		}
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	if adopted, err := migrations.Adopt(ctx, db, m); err != nil || adopted {
+		t.Errorf("Adopt() = %v, %v on empty DB, want false", adopted, err)
+	}
+
+	if err := db.AutoMigrate(baselineModels...); err != nil {
+		t.Fatalf("Failed to create legacy schema: %s", err)
+	}
+	for _, model := range baselineModels {
+		_, versioned := model.(datastore.Versioned)
+		_, member := model.(*workspaces.Member)
+		if !versioned && !member {
+			continue
+		}
+		if err := db.Migrator().DropColumn(model, "version"); err != nil {
+			t.Fatalf("Failed to create legacy schema: %s", err)
+		}
+	}
+	if adopted, err := migrations.Adopt(ctx, db, m); err != nil || !adopted {
+		t.Fatalf("Adopt() = %v, %v on legacy DB, want true", adopted, err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != migrations.BaselineVersion {
+		t.Errorf("Version() = %d, %v, want %d", version, err, migrations.BaselineVersion)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if !db.Migrator().HasTable(&currencies.Rate{}) {
+		t.Errorf("Up() did not create the rates table")
+	}
+	if adopted, err := migrations.Adopt(ctx, db, m); err != nil || adopted {
+		t.Errorf("Adopt() = %v, %v on migrated DB, want false", adopted, err)
+	}
+}
+
+func TestBaselineModels(t *testing.T) {
+	migs, err := migrations.Load(os.DirFS("."), datastore.DriverSQLite)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	migrated := openMemoryDB(t)
+	if err := migs[0].Up(migrated); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	created := openMemoryDB(t)
+	if err := created.AutoMigrate(baseline.Models...); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	tables, err := migrated.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("GetTables() error = %v", err)
+	}
+	createdTables, err := created.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("GetTables() error = %v", err)
+	}
+	if len(tables) != len(createdTables) {
+		t.Errorf("Baseline migration creates tables %v, baseline models create %v", tables, createdTables)
+	}
+	for _, table := range tables {
+		columns, err := migrated.Migrator().ColumnTypes(table)
+		if err != nil {
+			t.Fatalf("ColumnTypes() error = %v", err)
+		}
+		createdColumns, err := created.Migrator().ColumnTypes(table)
+		if err != nil {
+			t.Fatalf("ColumnTypes() error = %v", err)
+		}
+		if len(columns) != len(createdColumns) {
+			t.Errorf("Table %s has %d columns, baseline models create %d", table, len(columns), len(createdColumns))
+		}
+		for _, column := range columns {
+			if !created.Migrator().HasColumn(table, column.Name()) {
+				t.Errorf("Column %s.%s is not created by baseline models", table, column.Name())
+			}
+		}
+	}
+}
+
+// legacyAccount, legacyCategory and legacyTransaction are the models of the first release,
+// which had the records owned by users and no workspaces.
+type legacyAccount struct {
+	datastore.Model
+	UserID string `gorm:"notNull"`
+	Name   string `gorm:"notNull"`
+}
+
+func (legacyAccount) TableName() string { return "accounts" }
+
+type legacyCategory struct {
+	datastore.Model
+	UserID string `gorm:"notNull"`
+	Name   string
+	Tags   datastore.StringList
+}
+
+func (legacyCategory) TableName() string { return "categories" }
+
+type legacyTransaction struct {
+	datastore.Model
+	UserID       string `gorm:"notNull;index"`
+	YearMonth    string
+	Currency     string
+	Amount       float64
+	Description  string
+	CategoryUUID *uuid.UUID      `gorm:"index"`
+	Category     *legacyCategory `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (legacyTransaction) TableName() string { return "transactions" }
+
+func TestRun_StatusOfLegacyDB(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	if err := db.AutoMigrate(&legacyAccount{}); err != nil {
+		t.Fatalf("Failed to create legacy schema: %s", err)
+	}
+
+	if err := migrations.Run(ctx, db, []string{"status"}); err != nil {
+		t.Fatalf("Run(status) error = %v", err)
+	}
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if m.Initialized(ctx) {
+		t.Errorf("Run(status) put the database under migrations")
+	}
+	if !db.Migrator().HasColumn(&legacyAccount{}, "user_id") || db.Migrator().HasTable(&workspaces.Workspace{}) {
+		t.Errorf("Run(status) changed the legacy schema")
+	}
+	if !migrations.Adoptable(ctx, db, m) {
+		t.Errorf("Adoptable() = false on legacy DB, want true")
+	}
+}
+
+func TestAdopt_FirstRelease(t *testing.T) {
+	ctx := context.Background()
+	db := openMemoryDB(t)
+	m, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	if err := db.AutoMigrate(&legacyAccount{}, &accounts.Amount{}, &legacyCategory{}, &legacyTransaction{}); err != nil {
+		t.Fatalf("Failed to create legacy schema: %s", err)
+	}
+	for _, model := range []any{&legacyAccount{}, &legacyCategory{}, &legacyTransaction{}} {
+		if err := db.Migrator().DropColumn(model, "version"); err != nil {
+			t.Fatalf("Failed to create legacy schema: %s", err)
+		}
+	}
+	acc := &legacyAccount{UserID: "user1", Name: "Cash"}
+	otherAcc := &legacyAccount{UserID: "user2", Name: "Bank"}
+	cat := &legacyCategory{UserID: "user1", Name: "Food", Tags: datastore.StringList{"daily"}}
+	for _, record := range []any{acc, otherAcc, cat} {
+		if err := db.Omit("version").Create(record).Error; err != nil {
+			t.Fatalf("Failed to create legacy record: %s", err)
+		}
+	}
+	tx := &legacyTransaction{UserID: "user1", YearMonth: "2021-03", Currency: "usd", Amount: -5, CategoryUUID: &cat.UUID}
+	amount := &accounts.Amount{AccountUUID: acc.UUID, YearMonth: "2021-03", CurrencyCode: "usd", Amount: 10}
+	for _, record := range []any{tx, amount} {
+		if err := db.Omit("version").Create(record).Error; err != nil {
+			t.Fatalf("Failed to create legacy record: %s", err)
+		}
+	}
+
+	if adopted, err := migrations.Adopt(ctx, db, m); err != nil || !adopted {
+		t.Fatalf("Adopt() = %v, %v on legacy DB, want true", adopted, err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	assertSchema(t, db)
+
+	wsStore := workspaces.NewGormStore(db)
+	ws, err := wsStore.GetPersonalWorkspace(ctx, &users.User{ID: "user1"})
+	if err != nil {
+		t.Fatalf("GetPersonalWorkspace() error = %v", err)
+	}
+	if member, err := wsStore.GetMember(ctx, ws.UUID, &users.User{ID: "user1"}); err != nil || member.Role != workspaces.RoleOwner {
+		t.Errorf("GetMember() = %v, %v, want the owner", member, err)
+	}
+	otherWs, err := wsStore.GetPersonalWorkspace(ctx, &users.User{ID: "user2"})
+	if err != nil {
+		t.Fatalf("GetPersonalWorkspace() error = %v", err)
+	}
+
+	gotAccs, err := accounts.NewGormStore(db).GetWorkspaceAccounts(ctx, ws)
+	if err != nil || len(gotAccs) != 1 || gotAccs[0].UUID != acc.UUID {
+		t.Fatalf("GetWorkspaceAccounts() = %v, %v, want the account of the user", gotAccs, err)
+	}
+	gotOtherAccs, err := accounts.NewGormStore(db).GetWorkspaceAccounts(ctx, otherWs)
+	if err != nil || len(gotOtherAccs) != 1 || gotOtherAccs[0].UUID != otherAcc.UUID {
+		t.Errorf("GetWorkspaceAccounts() = %v, %v, want the account of the other user", gotOtherAccs, err)
+	}
+	gotAmounts, err := accounts.NewGormStore(db).GetAccountAmountHistory(ctx, gotAccs[0])
+	if err != nil || len(gotAmounts) != 1 || gotAmounts[0].Amount != 10 {
+		t.Errorf("GetAccountAmountHistory() = %v, %v, want the amount of the account", gotAmounts, err)
+	}
+	gotCat, err := categories.NewGormStore(db).GetCategory(ctx, cat.UUID)
+	if err != nil || gotCat.WorkspaceUUID != ws.UUID || len(gotCat.Tags) != 1 || gotCat.Version != 1 {
+		t.Errorf("GetCategory() = %v, %v, want the category moved into the workspace", gotCat, err)
+	}
+	gotTx, err := transactions.NewGormStore(db).GetTransaction(ctx, tx.UUID)
+	if err != nil || gotTx.WorkspaceUUID != ws.UUID || gotTx.CategoryUUID == nil || *gotTx.CategoryUUID != cat.UUID {
+		t.Errorf("GetTransaction() = %v, %v, want the transaction moved into the workspace", gotTx, err)
+	}
+	for _, model := range []any{&accounts.Account{}, &categories.Category{}, &transactions.Transaction{}} {
+		if db.Migrator().HasColumn(model, "user_id") {
+			t.Errorf("Owner column of %T is not dropped", model)
+		}
+	}
+}
+
+func TestLoad_Drivers(t *testing.T) {
+	pg, err := migrations.Load(os.DirFS("."), datastore.DriverPostgres)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	lite, err := migrations.Load(os.DirFS("."), datastore.DriverSQLite)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(pg) != len(lite) {
+		t.Fatalf("PostgreSQL has %d migrations, SQLite has %d", len(pg), len(lite))
+	}
+	for i := range pg {
+		if pg[i].Version != lite[i].Version || pg[i].Name != lite[i].Name {
+			t.Errorf("PostgreSQL migration %d %s does not match SQLite migration %d %s", pg[i].Version, pg[i].Name, lite[i].Version, lite[i].Name)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+CREATE TABLE "profiles" (
+    "id" text,
+    "subject" text NOT NULL,
+    "issuer" text NOT NULL,
+    "email" text NOT NULL,
+    "name" text NOT NULL,
+    "settings_default_currency" text NOT NULL,
+    "settings_locale" text NOT NULL,
+    "settings_fiscal_year_start" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_profiles_subject" ON "profiles" ("subject");
+CREATE TABLE "tokens" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "name" text NOT NULL,
+    "scope" text NOT NULL,
+    "hash" text NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    PRIMARY KEY ("uuid")
+);
+CREATE UNIQUE INDEX "idx_tokens_hash" ON "tokens" ("hash");
+CREATE TABLE "workspaces" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" text NOT NULL,
+    "personal_user_id" text,
+    PRIMARY KEY ("uuid")
+);
+CREATE UNIQUE INDEX "idx_workspaces_personal_user_id" ON "workspaces" ("personal_user_id");
+CREATE TABLE "members" (
+    "workspace_uuid" uuid,
+    "user_id" text,
+    "role" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("workspace_uuid","user_id"),
+    CONSTRAINT "fk_workspaces_members" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE TABLE "invitations" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid NOT NULL,
+    "role" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "invited_by_id" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_by" text,
+    "accepted_at" timestamptz,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_invitations_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_invitations_token_hash" ON "invitations" ("token_hash");
+CREATE INDEX "idx_invitations_workspace_uuid" ON "invitations" ("workspace_uuid");
+CREATE TABLE "accounts" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "name" text NOT NULL,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_accounts_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_accounts_workspace_uuid" ON "accounts" ("workspace_uuid");
+CREATE TABLE "amounts" (
+    "account_uuid" uuid,
+    "year_month" varchar(7) NOT NULL,
+    "currency_code" text NOT NULL,
+    "amount" decimal,
+    PRIMARY KEY ("account_uuid","year_month","currency_code"),
+    CONSTRAINT "fk_amounts_account" FOREIGN KEY ("account_uuid") REFERENCES "accounts"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE TABLE "categories" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "name" text,
+    "tags" text[],
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_categories_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_categories_workspace_uuid" ON "categories" ("workspace_uuid");
+CREATE TABLE "labels" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "name" text NOT NULL,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_labels_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_labels_workspace_uuid" ON "labels" ("workspace_uuid");
+CREATE TABLE "payees" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "name" text NOT NULL,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_payees_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_payees_workspace_uuid" ON "payees" ("workspace_uuid");
+CREATE TABLE "aliases" (
+    "payee_uuid" uuid,
+    "name" text,
+    PRIMARY KEY ("payee_uuid","name"),
+    CONSTRAINT "fk_payees_aliases" FOREIGN KEY ("payee_uuid") REFERENCES "payees"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE TABLE "transactions" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "date" date,
+    "year_month" text,
+    "currency" text,
+    "amount" decimal,
+    "description" text,
+    "category_uuid" uuid,
+    "account_uuid" uuid,
+    "payee_uuid" uuid,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_transactions_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_transactions_category" FOREIGN KEY ("category_uuid") REFERENCES "categories"("uuid") ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT "fk_transactions_account" FOREIGN KEY ("account_uuid") REFERENCES "accounts"("uuid") ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT "fk_transactions_payee" FOREIGN KEY ("payee_uuid") REFERENCES "payees"("uuid") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX "idx_transactions_currency" ON "transactions" ("currency");
+CREATE INDEX "idx_transactions_year_month" ON "transactions" ("year_month");
+CREATE INDEX "idx_transactions_date" ON "transactions" ("date");
+CREATE INDEX "idx_transactions_workspace_uuid" ON "transactions" ("workspace_uuid");
+CREATE INDEX "idx_transactions_payee_uuid" ON "transactions" ("payee_uuid");
+CREATE INDEX "idx_transactions_account_uuid" ON "transactions" ("account_uuid");
+CREATE INDEX "idx_transactions_category_uuid" ON "transactions" ("category_uuid");
+CREATE INDEX "idx_transactions_amount" ON "transactions" ("amount");
+CREATE TABLE "transaction_labels" (
+    "transaction_uuid" uuid,
+    "label_uuid" uuid,
+    PRIMARY KEY ("transaction_uuid","label_uuid"),
+    CONSTRAINT "fk_transaction_labels_transaction" FOREIGN KEY ("transaction_uuid") REFERENCES "transactions"("uuid") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_transaction_labels_label" FOREIGN KEY ("label_uuid") REFERENCES "labels"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE TABLE "splits" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "transaction_uuid" uuid NOT NULL,
+    "category_uuid" uuid,
+    "amount" decimal,
+    "note" text,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_splits_category" FOREIGN KEY ("category_uuid") REFERENCES "categories"("uuid") ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT "fk_transactions_splits" FOREIGN KEY ("transaction_uuid") REFERENCES "transactions"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_splits_category_uuid" ON "splits" ("category_uuid");
+CREATE INDEX "idx_splits_transaction_uuid" ON "splits" ("transaction_uuid");
+CREATE TABLE "attachments" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "transaction_uuid" uuid NOT NULL,
+    "name" text NOT NULL,
+    "content_type" text NOT NULL,
+    "size" bigint NOT NULL,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_attachments_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_attachments_transaction" FOREIGN KEY ("transaction_uuid") REFERENCES "transactions"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_attachments_transaction_uuid" ON "attachments" ("transaction_uuid");
+CREATE INDEX "idx_attachments_workspace_uuid" ON "attachments" ("workspace_uuid");
+CREATE TABLE "templates" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "cadence" text NOT NULL,
+    "start_month" varchar(7) NOT NULL,
+    "end_month" varchar(7),
+    "currency" text,
+    "amount" decimal,
+    "description" text,
+    "category_uuid" uuid,
+    "account_uuid" uuid,
+    PRIMARY KEY ("uuid"),
+    CONSTRAINT "fk_templates_account" FOREIGN KEY ("account_uuid") REFERENCES "accounts"("uuid") ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT "fk_templates_workspace" FOREIGN KEY ("workspace_uuid") REFERENCES "workspaces"("uuid") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_templates_category" FOREIGN KEY ("category_uuid") REFERENCES "categories"("uuid") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX "idx_templates_workspace_uuid" ON "templates" ("workspace_uuid");
+CREATE INDEX "idx_templates_account_uuid" ON "templates" ("account_uuid");
+CREATE INDEX "idx_templates_category_uuid" ON "templates" ("category_uuid");
+CREATE TABLE "occurrences" (
+    "template_uuid" uuid,
+    "year_month" varchar(7) NOT NULL,
+    "transaction_uuid" uuid,
+    "created_at" timestamptz,
+    PRIMARY KEY ("template_uuid","year_month"),
+    CONSTRAINT "fk_occurrences_template" FOREIGN KEY ("template_uuid") REFERENCES "templates"("uuid") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_transactions_description" ON "transactions" USING gin (to_tsvector('simple', "description"));
@@ -0,0 +1,7 @@
+CREATE TABLE "rates" (
+    "base" text,
+    "target" text,
+    "year_month" text,
+    "rate" decimal,
+    PRIMARY KEY ("base","target","year_month")
+);
@@ -0,0 +1,16 @@
+CREATE TABLE "events" (
+    "uuid" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "workspace_uuid" uuid,
+    "user_id" text NOT NULL,
+    "action" text NOT NULL,
+    "entity" text NOT NULL,
+    "entity_id" text NOT NULL,
+    "before" text NOT NULL,
+    "after" text NOT NULL,
+    PRIMARY KEY ("uuid")
+);
+CREATE INDEX "idx_events_workspace_uuid" ON "events" ("workspace_uuid");
+CREATE INDEX "idx_events_entity" ON "events" ("entity");
@@ -0,0 +1,12 @@
+ALTER TABLE "events" DROP COLUMN "version";
+ALTER TABLE "templates" DROP COLUMN "version";
+ALTER TABLE "attachments" DROP COLUMN "version";
+ALTER TABLE "splits" DROP COLUMN "version";
+ALTER TABLE "transactions" DROP COLUMN "version";
+ALTER TABLE "payees" DROP COLUMN "version";
+ALTER TABLE "labels" DROP COLUMN "version";
+ALTER TABLE "categories" DROP COLUMN "version";
+ALTER TABLE "accounts" DROP COLUMN "version";
+ALTER TABLE "invitations" DROP COLUMN "version";
+ALTER TABLE "workspaces" DROP COLUMN "version";
+ALTER TABLE "tokens" DROP COLUMN "version";
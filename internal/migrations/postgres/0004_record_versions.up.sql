@@ -0,0 +1,12 @@
+ALTER TABLE "tokens" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "workspaces" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "invitations" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "accounts" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "categories" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "labels" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "payees" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "transactions" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "splits" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "attachments" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "templates" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "events" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+ALTER TABLE "members" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
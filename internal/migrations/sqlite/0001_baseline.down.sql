@@ -0,0 +1,17 @@
+DROP TABLE `occurrences`;
+DROP TABLE `templates`;
+DROP TABLE `attachments`;
+DROP TABLE `splits`;
+DROP TABLE `transaction_labels`;
+DROP TABLE `transactions`;
+DROP TABLE `aliases`;
+DROP TABLE `payees`;
+DROP TABLE `labels`;
+DROP TABLE `categories`;
+DROP TABLE `amounts`;
+DROP TABLE `accounts`;
+DROP TABLE `invitations`;
+DROP TABLE `members`;
+DROP TABLE `workspaces`;
+DROP TABLE `tokens`;
+DROP TABLE `profiles`;
@@ -0,0 +1,12 @@
+ALTER TABLE `tokens` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `workspaces` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `invitations` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `accounts` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `categories` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `labels` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `payees` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `transactions` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `splits` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `attachments` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `templates` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `events` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
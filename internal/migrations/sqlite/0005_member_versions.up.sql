@@ -0,0 +1 @@
+ALTER TABLE `members` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
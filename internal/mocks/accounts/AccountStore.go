@@ -7,11 +7,13 @@ import (
 
 	accounts "github.com/d-ashesss/mah-moneh/internal/accounts"
 
-	mock "github.com/stretchr/testify/mock"
+	datastore "github.com/d-ashesss/mah-moneh/internal/datastore"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/gofrs/uuid"
+
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // AccountStore is an autogenerated mock type for the AccountStore type
@@ -73,6 +75,32 @@ func (_m *AccountStore) GetAccount(ctx context.Context, UUID uuid.UUID) (*accoun
 	return r0, r1
 }
 
+// GetAccountAmountHistory provides a mock function with given fields: ctx, acc
+func (_m *AccountStore) GetAccountAmountHistory(ctx context.Context, acc *accounts.Account) (accounts.AmountCollection, error) {
+	ret := _m.Called(ctx, acc)
+
+	var r0 accounts.AmountCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account) (accounts.AmountCollection, error)); ok {
+		return rf(ctx, acc)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account) accounts.AmountCollection); ok {
+		r0 = rf(ctx, acc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(accounts.AmountCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *accounts.Account) error); ok {
+		r1 = rf(ctx, acc)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountAmounts provides a mock function with given fields: ctx, acc, month
 func (_m *AccountStore) GetAccountAmounts(ctx context.Context, acc *accounts.Account, month string) (accounts.AmountCollection, error) {
 	ret := _m.Called(ctx, acc, month)
@@ -99,25 +127,51 @@ func (_m *AccountStore) GetAccountAmounts(ctx context.Context, acc *accounts.Acc
 	return r0, r1
 }
 
-// GetUserAccounts provides a mock function with given fields: ctx, u
-func (_m *AccountStore) GetUserAccounts(ctx context.Context, u *users.User) (accounts.AccountCollection, error) {
-	ret := _m.Called(ctx, u)
+// GetWorkspaceAccounts provides a mock function with given fields: ctx, ws
+func (_m *AccountStore) GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (accounts.AccountCollection, error) {
+	ret := _m.Called(ctx, ws)
 
 	var r0 accounts.AccountCollection
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) (accounts.AccountCollection, error)); ok {
-		return rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) (accounts.AccountCollection, error)); ok {
+		return rf(ctx, ws)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) accounts.AccountCollection); ok {
-		r0 = rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) accounts.AccountCollection); ok {
+		r0 = rf(ctx, ws)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(accounts.AccountCollection)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
-		r1 = rf(ctx, u)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace) error); ok {
+		r1 = rf(ctx, ws)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceAccountsPage provides a mock function with given fields: ctx, ws, p
+func (_m *AccountStore) GetWorkspaceAccountsPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*accounts.Page, error) {
+	ret := _m.Called(ctx, ws, p)
+
+	var r0 *accounts.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) (*accounts.Page, error)); ok {
+		return rf(ctx, ws, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) *accounts.Page); ok {
+		r0 = rf(ctx, ws, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*accounts.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) error); ok {
+		r1 = rf(ctx, ws, p)
 	} else {
 		r1 = ret.Error(1)
 	}
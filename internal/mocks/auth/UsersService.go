@@ -14,25 +14,25 @@ type UsersService struct {
 	mock.Mock
 }
 
-// GetUser provides a mock function with given fields: ctx, ID
-func (_m *UsersService) GetUser(ctx context.Context, ID string) (*users.User, error) {
-	ret := _m.Called(ctx, ID)
+// EnsureUser provides a mock function with given fields: ctx, claims
+func (_m *UsersService) EnsureUser(ctx context.Context, claims *users.Claims) (*users.User, error) {
+	ret := _m.Called(ctx, claims)
 
 	var r0 *users.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*users.User, error)); ok {
-		return rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, *users.Claims) (*users.User, error)); ok {
+		return rf(ctx, claims)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *users.User); ok {
-		r0 = rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, *users.Claims) *users.User); ok {
+		r0 = rf(ctx, claims)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*users.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, ID)
+	if rf, ok := ret.Get(1).(func(context.Context, *users.Claims) error); ok {
+		r1 = rf(ctx, claims)
 	} else {
 		r1 = ret.Error(1)
 	}
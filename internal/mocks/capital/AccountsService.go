@@ -9,7 +9,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // AccountsService is an autogenerated mock type for the AccountsService type
@@ -43,25 +43,25 @@ func (_m *AccountsService) GetAccountAmounts(ctx context.Context, acc *accounts.
 	return r0, r1
 }
 
-// GetUserAccounts provides a mock function with given fields: ctx, u
-func (_m *AccountsService) GetUserAccounts(ctx context.Context, u *users.User) (accounts.AccountCollection, error) {
-	ret := _m.Called(ctx, u)
+// GetWorkspaceAccounts provides a mock function with given fields: ctx, ws
+func (_m *AccountsService) GetWorkspaceAccounts(ctx context.Context, ws *workspaces.Workspace) (accounts.AccountCollection, error) {
+	ret := _m.Called(ctx, ws)
 
 	var r0 accounts.AccountCollection
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) (accounts.AccountCollection, error)); ok {
-		return rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) (accounts.AccountCollection, error)); ok {
+		return rf(ctx, ws)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) accounts.AccountCollection); ok {
-		r0 = rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) accounts.AccountCollection); ok {
+		r0 = rf(ctx, ws)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(accounts.AccountCollection)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
-		r1 = rf(ctx, u)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace) error); ok {
+		r1 = rf(ctx, ws)
 	} else {
 		r1 = ret.Error(1)
 	}
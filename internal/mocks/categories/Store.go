@@ -7,11 +7,13 @@ import (
 
 	categories "github.com/d-ashesss/mah-moneh/internal/categories"
 
-	mock "github.com/stretchr/testify/mock"
+	datastore "github.com/d-ashesss/mah-moneh/internal/datastore"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/gofrs/uuid"
+
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -59,25 +61,51 @@ func (_m *Store) GetCategory(ctx context.Context, _a1 uuid.UUID) (*categories.Ca
 	return r0, r1
 }
 
-// GetUserCategories provides a mock function with given fields: ctx, u
-func (_m *Store) GetUserCategories(ctx context.Context, u *users.User) ([]*categories.Category, error) {
-	ret := _m.Called(ctx, u)
+// GetWorkspaceCategories provides a mock function with given fields: ctx, ws
+func (_m *Store) GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*categories.Category, error) {
+	ret := _m.Called(ctx, ws)
 
 	var r0 []*categories.Category
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) ([]*categories.Category, error)); ok {
-		return rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) ([]*categories.Category, error)); ok {
+		return rf(ctx, ws)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) []*categories.Category); ok {
-		r0 = rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) []*categories.Category); ok {
+		r0 = rf(ctx, ws)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*categories.Category)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
-		r1 = rf(ctx, u)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace) error); ok {
+		r1 = rf(ctx, ws)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceCategoriesPage provides a mock function with given fields: ctx, ws, p
+func (_m *Store) GetWorkspaceCategoriesPage(ctx context.Context, ws *workspaces.Workspace, p *datastore.PageRequest) (*categories.Page, error) {
+	ret := _m.Called(ctx, ws, p)
+
+	var r0 *categories.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) (*categories.Page, error)); ok {
+		return rf(ctx, ws, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) *categories.Page); ok {
+		r0 = rf(ctx, ws, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*categories.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, *datastore.PageRequest) error); ok {
+		r1 = rf(ctx, ws, p)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	accounts "github.com/d-ashesss/mah-moneh/internal/accounts"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type CurrencyService struct {
 	mock.Mock
 }
 
-// GetRate provides a mock function with given fields: base, target, month
-func (_m *CurrencyService) GetRate(base accounts.Currency, target accounts.Currency, month string) float64 {
-	ret := _m.Called(base, target, month)
+// GetRate provides a mock function with given fields: ctx, base, target, month
+func (_m *CurrencyService) GetRate(ctx context.Context, base accounts.Currency, target accounts.Currency, month string) float64 {
+	ret := _m.Called(ctx, base, target, month)
 
 	var r0 float64
-	if rf, ok := ret.Get(0).(func(accounts.Currency, accounts.Currency, string) float64); ok {
-		r0 = rf(base, target, month)
+	if rf, ok := ret.Get(0).(func(context.Context, accounts.Currency, accounts.Currency, string) float64); ok {
+		r0 = rf(ctx, base, target, month)
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	accounts "github.com/d-ashesss/mah-moneh/internal/accounts"
+
 	currencies "github.com/d-ashesss/mah-moneh/internal/currencies"
 
 	mock "github.com/stretchr/testify/mock"
@@ -14,25 +17,51 @@ type Store struct {
 	mock.Mock
 }
 
-// GetRate provides a mock function with given fields: base, target, month
-func (_m *Store) GetRate(base accounts.Currency, target accounts.Currency, month string) (*currencies.Rate, error) {
-	ret := _m.Called(base, target, month)
+// GetRate provides a mock function with given fields: ctx, base, target, month
+func (_m *Store) GetRate(ctx context.Context, base accounts.Currency, target accounts.Currency, month string) (*currencies.Rate, error) {
+	ret := _m.Called(ctx, base, target, month)
 
 	var r0 *currencies.Rate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(accounts.Currency, accounts.Currency, string) (*currencies.Rate, error)); ok {
-		return rf(base, target, month)
+	if rf, ok := ret.Get(0).(func(context.Context, accounts.Currency, accounts.Currency, string) (*currencies.Rate, error)); ok {
+		return rf(ctx, base, target, month)
 	}
-	if rf, ok := ret.Get(0).(func(accounts.Currency, accounts.Currency, string) *currencies.Rate); ok {
-		r0 = rf(base, target, month)
+	if rf, ok := ret.Get(0).(func(context.Context, accounts.Currency, accounts.Currency, string) *currencies.Rate); ok {
+		r0 = rf(ctx, base, target, month)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*currencies.Rate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(accounts.Currency, accounts.Currency, string) error); ok {
-		r1 = rf(base, target, month)
+	if rf, ok := ret.Get(1).(func(context.Context, accounts.Currency, accounts.Currency, string) error); ok {
+		r1 = rf(ctx, base, target, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRates provides a mock function with given fields: ctx
+func (_m *Store) GetRates(ctx context.Context) (currencies.RateCollection, error) {
+	ret := _m.Called(ctx)
+
+	var r0 currencies.RateCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (currencies.RateCollection, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) currencies.RateCollection); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(currencies.RateCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,13 +69,13 @@ func (_m *Store) GetRate(base accounts.Currency, target accounts.Currency, month
 	return r0, r1
 }
 
-// SetRate provides a mock function with given fields: base, target, month, rate
-func (_m *Store) SetRate(base accounts.Currency, target accounts.Currency, month string, rate float64) error {
-	ret := _m.Called(base, target, month, rate)
+// SetRate provides a mock function with given fields: ctx, base, target, month, rate
+func (_m *Store) SetRate(ctx context.Context, base accounts.Currency, target accounts.Currency, month string, rate float64) error {
+	ret := _m.Called(ctx, base, target, month, rate)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(accounts.Currency, accounts.Currency, string, float64) error); ok {
-		r0 = rf(base, target, month, rate)
+	if rf, ok := ret.Get(0).(func(context.Context, accounts.Currency, accounts.Currency, string, float64) error); ok {
+		r0 = rf(ctx, base, target, month, rate)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -9,7 +9,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // CapitalService is an autogenerated mock type for the CapitalService type
@@ -17,25 +17,25 @@ type CapitalService struct {
 	mock.Mock
 }
 
-// GetCapital provides a mock function with given fields: ctx, u, month
-func (_m *CapitalService) GetCapital(ctx context.Context, u *users.User, month string) (*capital.Capital, error) {
-	ret := _m.Called(ctx, u, month)
+// GetCapital provides a mock function with given fields: ctx, ws, month
+func (_m *CapitalService) GetCapital(ctx context.Context, ws *workspaces.Workspace, month string) (*capital.Capital, error) {
+	ret := _m.Called(ctx, ws, month)
 
 	var r0 *capital.Capital
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) (*capital.Capital, error)); ok {
-		return rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) (*capital.Capital, error)); ok {
+		return rf(ctx, ws, month)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) *capital.Capital); ok {
-		r0 = rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) *capital.Capital); ok {
+		r0 = rf(ctx, ws, month)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*capital.Capital)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User, string) error); ok {
-		r1 = rf(ctx, u, month)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, string) error); ok {
+		r1 = rf(ctx, ws, month)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -9,7 +9,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // CategoryService is an autogenerated mock type for the CategoryService type
@@ -17,25 +17,25 @@ type CategoryService struct {
 	mock.Mock
 }
 
-// GetUserCategories provides a mock function with given fields: ctx, u
-func (_m *CategoryService) GetUserCategories(ctx context.Context, u *users.User) ([]*categories.Category, error) {
-	ret := _m.Called(ctx, u)
+// GetWorkspaceCategories provides a mock function with given fields: ctx, ws
+func (_m *CategoryService) GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*categories.Category, error) {
+	ret := _m.Called(ctx, ws)
 
 	var r0 []*categories.Category
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) ([]*categories.Category, error)); ok {
-		return rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) ([]*categories.Category, error)); ok {
+		return rf(ctx, ws)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User) []*categories.Category); ok {
-		r0 = rf(ctx, u)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace) []*categories.Category); ok {
+		r0 = rf(ctx, ws)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*categories.Category)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
-		r1 = rf(ctx, u)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace) error); ok {
+		r1 = rf(ctx, ws)
 	} else {
 		r1 = ret.Error(1)
 	}
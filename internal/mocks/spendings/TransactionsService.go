@@ -9,7 +9,7 @@ import (
 
 	transactions "github.com/d-ashesss/mah-moneh/internal/transactions"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // TransactionsService is an autogenerated mock type for the TransactionsService type
@@ -17,25 +17,51 @@ type TransactionsService struct {
 	mock.Mock
 }
 
-// GetUserTransactions provides a mock function with given fields: ctx, u, month
-func (_m *TransactionsService) GetUserTransactions(ctx context.Context, u *users.User, month string) (transactions.TransactionCollection, error) {
-	ret := _m.Called(ctx, u, month)
+// GetPeriodTransactions provides a mock function with given fields: ctx, ws, from, to
+func (_m *TransactionsService) GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from string, to string) (transactions.TransactionCollection, error) {
+	ret := _m.Called(ctx, ws, from, to)
 
 	var r0 transactions.TransactionCollection
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) (transactions.TransactionCollection, error)); ok {
-		return rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string, string) (transactions.TransactionCollection, error)); ok {
+		return rf(ctx, ws, from, to)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) transactions.TransactionCollection); ok {
-		r0 = rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string, string) transactions.TransactionCollection); ok {
+		r0 = rf(ctx, ws, from, to)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(transactions.TransactionCollection)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User, string) error); ok {
-		r1 = rf(ctx, u, month)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, string, string) error); ok {
+		r1 = rf(ctx, ws, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkspaceTransactions provides a mock function with given fields: ctx, ws, month
+func (_m *TransactionsService) GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (transactions.TransactionCollection, error) {
+	ret := _m.Called(ctx, ws, month)
+
+	var r0 transactions.TransactionCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) (transactions.TransactionCollection, error)); ok {
+		return rf(ctx, ws, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) transactions.TransactionCollection); ok {
+		r0 = rf(ctx, ws, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(transactions.TransactionCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, string) error); ok {
+		r1 = rf(ctx, ws, month)
 	} else {
 		r1 = ret.Error(1)
 	}
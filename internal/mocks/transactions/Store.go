@@ -5,12 +5,14 @@ package mocks
 import (
 	context "context"
 
-	transactions "github.com/d-ashesss/mah-moneh/internal/transactions"
+	labels "github.com/d-ashesss/mah-moneh/internal/labels"
 	mock "github.com/stretchr/testify/mock"
 
-	users "github.com/d-ashesss/mah-moneh/internal/users"
+	transactions "github.com/d-ashesss/mah-moneh/internal/transactions"
 
 	uuid "github.com/gofrs/uuid"
+
+	workspaces "github.com/d-ashesss/mah-moneh/internal/workspaces"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -32,6 +34,32 @@ func (_m *Store) DeleteTransaction(ctx context.Context, tx *transactions.Transac
 	return r0
 }
 
+// GetPeriodTransactions provides a mock function with given fields: ctx, ws, from, to
+func (_m *Store) GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from string, to string) (transactions.TransactionCollection, error) {
+	ret := _m.Called(ctx, ws, from, to)
+
+	var r0 transactions.TransactionCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string, string) (transactions.TransactionCollection, error)); ok {
+		return rf(ctx, ws, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string, string) transactions.TransactionCollection); ok {
+		r0 = rf(ctx, ws, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(transactions.TransactionCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, string, string) error); ok {
+		r1 = rf(ctx, ws, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransaction provides a mock function with given fields: ctx, _a1
 func (_m *Store) GetTransaction(ctx context.Context, _a1 uuid.UUID) (*transactions.Transaction, error) {
 	ret := _m.Called(ctx, _a1)
@@ -58,25 +86,25 @@ func (_m *Store) GetTransaction(ctx context.Context, _a1 uuid.UUID) (*transactio
 	return r0, r1
 }
 
-// GetUserTransactions provides a mock function with given fields: ctx, u, month
-func (_m *Store) GetUserTransactions(ctx context.Context, u *users.User, month string) (transactions.TransactionCollection, error) {
-	ret := _m.Called(ctx, u, month)
+// GetWorkspaceTransactions provides a mock function with given fields: ctx, ws, month
+func (_m *Store) GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (transactions.TransactionCollection, error) {
+	ret := _m.Called(ctx, ws, month)
 
 	var r0 transactions.TransactionCollection
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) (transactions.TransactionCollection, error)); ok {
-		return rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) (transactions.TransactionCollection, error)); ok {
+		return rf(ctx, ws, month)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *users.User, string) transactions.TransactionCollection); ok {
-		r0 = rf(ctx, u, month)
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, string) transactions.TransactionCollection); ok {
+		r0 = rf(ctx, ws, month)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(transactions.TransactionCollection)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *users.User, string) error); ok {
-		r1 = rf(ctx, u, month)
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, string) error); ok {
+		r1 = rf(ctx, ws, month)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,6 +126,46 @@ func (_m *Store) SaveTransaction(ctx context.Context, tx *transactions.Transacti
 	return r0
 }
 
+// SearchTransactions provides a mock function with given fields: ctx, ws, f
+func (_m *Store) SearchTransactions(ctx context.Context, ws *workspaces.Workspace, f *transactions.Filter) (*transactions.Page, error) {
+	ret := _m.Called(ctx, ws, f)
+
+	var r0 *transactions.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *transactions.Filter) (*transactions.Page, error)); ok {
+		return rf(ctx, ws, f)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *workspaces.Workspace, *transactions.Filter) *transactions.Page); ok {
+		r0 = rf(ctx, ws, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *workspaces.Workspace, *transactions.Filter) error); ok {
+		r1 = rf(ctx, ws, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTransactionLabels provides a mock function with given fields: ctx, tx, lbls
+func (_m *Store) SetTransactionLabels(ctx context.Context, tx *transactions.Transaction, lbls labels.LabelCollection) error {
+	ret := _m.Called(ctx, tx, lbls)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.Transaction, labels.LabelCollection) error); ok {
+		r0 = rf(ctx, tx, lbls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
@@ -0,0 +1,106 @@
+//go:build integration
+
+package payees_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type PayeesIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *payees.Service
+}
+
+func (ts *PayeesIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "pay_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := payees.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = payees.NewService(store)
+
+	err = db.Migrator().AutoMigrate(&payees.Payee{}, &payees.Alias{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *PayeesIntegrationTestSuite) TestCreatePayee() {
+	ws := ts.createTestingWorkspace()
+	p, err := ts.srv.CreatePayee(context.Background(), ws, "Amazon", []string{"AMAZON MKTPLACE", "AMZN"})
+	ts.Require().NoError(err, "Failed to create a payee.")
+
+	found, err := ts.srv.GetPayee(context.Background(), p.UUID)
+	ts.Require().NoError(err, "Failed to find created payee.")
+	ts.Equal("Amazon", found.Name)
+	ts.ElementsMatch([]string{"AMAZON MKTPLACE", "AMZN"}, found.Aliases.Names())
+}
+
+func (ts *PayeesIntegrationTestSuite) TestUpdatePayee() {
+	ws := ts.createTestingWorkspace()
+	p, err := ts.srv.CreatePayee(context.Background(), ws, "Amazon", []string{"AMAZON MKTPLACE", "AMZN"})
+	ts.Require().NoError(err, "Failed to create a payee.")
+
+	p.Name = "Amazon.com"
+	p.SetAliases([]string{"AMZN", "AMAZON.COM"})
+	err = ts.srv.UpdatePayee(context.Background(), p)
+	ts.Require().NoError(err, "Failed to update the payee.")
+
+	found, err := ts.srv.GetPayee(context.Background(), p.UUID)
+	ts.Require().NoError(err, "Failed to find updated payee.")
+	ts.Equal("Amazon.com", found.Name)
+	ts.ElementsMatch([]string{"AMZN", "AMAZON.COM"}, found.Aliases.Names())
+}
+
+func (ts *PayeesIntegrationTestSuite) TestDeletePayee() {
+	ws := ts.createTestingWorkspace()
+	p, err := ts.srv.CreatePayee(context.Background(), ws, "Corner shop", nil)
+	ts.Require().NoError(err, "Failed to create a payee.")
+
+	err = ts.srv.DeletePayee(context.Background(), p)
+	ts.Require().NoError(err, "Failed to delete the payee.")
+
+	_, err = ts.srv.GetPayee(context.Background(), p.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *PayeesIntegrationTestSuite) TestResolvePayee() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
+	amazon, err := ts.srv.CreatePayee(context.Background(), ws1, "Amazon", []string{"AMZN"})
+	ts.Require().NoError(err, "Failed to create a payee.")
+	_, err = ts.srv.CreatePayee(context.Background(), ws2, "Amazon", []string{"AMZN"})
+	ts.Require().NoError(err, "Failed to create a payee.")
+
+	p, err := ts.srv.ResolvePayee(context.Background(), ws1, "AMZN Digital")
+	ts.Require().NoError(err, "Failed to resolve payee.")
+	ts.Require().NotNil(p)
+	ts.Equal(amazon.UUID, p.UUID)
+}
+
+func (ts *PayeesIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func TestPayeesIntegration(t *testing.T) {
+	suite.Run(t, new(PayeesIntegrationTestSuite))
+}
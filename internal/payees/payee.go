@@ -0,0 +1,91 @@
+package payees
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"strings"
+)
+
+// Payee represents a merchant or any other counterparty of transactions.
+type Payee struct {
+	datastore.Model
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name          string                `gorm:"notNull"`
+	Aliases       AliasCollection       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// NewPayee initializes a new payee with the provided aliases.
+func NewPayee(ws *workspaces.Workspace, name string, aliases []string) *Payee {
+	p := &Payee{WorkspaceUUID: ws.UUID, Name: name}
+	p.SetAliases(aliases)
+	return p
+}
+
+// SetAliases replaces aliases of the payee, ignoring empty and duplicate ones.
+func (p *Payee) SetAliases(aliases []string) {
+	p.Aliases = make(AliasCollection, 0, len(aliases))
+	seen := make(map[string]bool, len(aliases))
+	for _, name := range aliases {
+		name = strings.TrimSpace(name)
+		key := normalize(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		p.Aliases = append(p.Aliases, &Alias{PayeeUUID: p.UUID, Name: name})
+	}
+}
+
+// Match calculates how well the payee matches a transaction description.
+// The description matches when it contains the payee name or any of its aliases, case-insensitively.
+// The length of the longest matching name is returned, so that more specific matches can be preferred, or 0 if there is no match.
+func (p *Payee) Match(desc string) int {
+	desc = normalize(desc)
+	best := 0
+	for _, name := range append([]string{p.Name}, p.Aliases.Names()...) {
+		name = normalize(name)
+		if len(name) > best && strings.Contains(desc, name) {
+			best = len(name)
+		}
+	}
+	return best
+}
+
+// PayeeCollection represents a collection of payee entities.
+type PayeeCollection []*Payee
+
+// Resolve finds the payee best matching the transaction description, or nil if none of them matches.
+func (c PayeeCollection) Resolve(desc string) *Payee {
+	var found *Payee
+	best := 0
+	for _, p := range c {
+		if m := p.Match(desc); m > best {
+			found, best = p, m
+		}
+	}
+	return found
+}
+
+// Alias represents an alternative name of the payee as it appears in transaction descriptions.
+type Alias struct {
+	PayeeUUID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string    `gorm:"primaryKey"`
+}
+
+// AliasCollection represents a collection of payee aliases.
+type AliasCollection []*Alias
+
+// Names lists names of the aliases.
+func (c AliasCollection) Names() []string {
+	names := make([]string, 0, len(c))
+	for _, a := range c {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,38 @@
+package payees_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PayeeTestSuite struct {
+	suite.Suite
+}
+
+func (ts *PayeeTestSuite) TestSetAliases() {
+	p := payees.NewPayee(&workspaces.Workspace{}, "Amazon", []string{"AMAZON MKTPLACE", " amazon  mktplace ", "", "AMZN"})
+	ts.Equal([]string{"AMAZON MKTPLACE", "AMZN"}, p.Aliases.Names())
+}
+
+func (ts *PayeeTestSuite) TestMatch() {
+	p := payees.NewPayee(&workspaces.Workspace{}, "Amazon", []string{"AMAZON MKTPLACE", "AMZN"})
+	ts.Equal(15, p.Match("AMAZON  MKTPLACE PMTS 123"))
+	ts.Equal(6, p.Match("amazon prime"))
+	ts.Equal(4, p.Match("AMZN Digital"))
+	ts.Equal(0, p.Match("Corner shop"))
+}
+
+func (ts *PayeeTestSuite) TestResolve() {
+	amazon := payees.NewPayee(&workspaces.Workspace{}, "Amazon", nil)
+	marketplace := payees.NewPayee(&workspaces.Workspace{}, "Amazon Marketplace", []string{"AMAZON MKTPLACE"})
+	ps := payees.PayeeCollection{amazon, marketplace}
+	ts.Equal(marketplace, ps.Resolve("AMAZON MKTPLACE PMTS"))
+	ts.Equal(amazon, ps.Resolve("Amazon Prime"))
+	ts.Nil(ps.Resolve("Corner shop"))
+}
+
+func TestPayee(t *testing.T) {
+	suite.Run(t, new(PayeeTestSuite))
+}
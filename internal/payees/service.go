@@ -0,0 +1,48 @@
+package payees
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+)
+
+type Service struct {
+	db Store
+}
+
+func NewService(db Store) *Service {
+	return &Service{db: db}
+}
+
+func (s *Service) CreatePayee(ctx context.Context, ws *workspaces.Workspace, name string, aliases []string) (*Payee, error) {
+	p := NewPayee(ws, name, aliases)
+	if err := s.db.SavePayee(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) UpdatePayee(ctx context.Context, p *Payee) error {
+	return s.db.SavePayee(ctx, p)
+}
+
+func (s *Service) GetPayee(ctx context.Context, UUID uuid.UUID) (*Payee, error) {
+	return s.db.GetPayee(ctx, UUID)
+}
+
+func (s *Service) DeletePayee(ctx context.Context, p *Payee) error {
+	return s.db.DeletePayee(ctx, p)
+}
+
+func (s *Service) GetWorkspacePayees(ctx context.Context, ws *workspaces.Workspace) (PayeeCollection, error) {
+	return s.db.GetWorkspacePayees(ctx, ws)
+}
+
+// ResolvePayee finds user's payee matching the transaction description, or nil if there is none.
+func (s *Service) ResolvePayee(ctx context.Context, ws *workspaces.Workspace, desc string) (*Payee, error) {
+	ps, err := s.db.GetWorkspacePayees(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	return ps.Resolve(desc), nil
+}
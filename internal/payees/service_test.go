@@ -0,0 +1,79 @@
+package payees_test
+
+import (
+	"context"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/payees"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PayeesServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *payees.Service
+}
+
+func (ts *PayeesServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = payees.NewService(ts.store)
+}
+
+func (ts *PayeesServiceTestSuite) TestCreatePayee() {
+	ctx := context.Background()
+	ts.store.On("SavePayee", ctx, mock.AnythingOfType("*payees.Payee")).Return(nil)
+	ws := &workspaces.Workspace{}
+	p, err := ts.srv.CreatePayee(ctx, ws, "Amazon", []string{"AMAZON MKTPLACE"})
+	ts.Require().NoError(err, "Failed to create payee.")
+	ts.Require().NotNil(p, "Received nil payee.")
+	ts.Equal("Amazon", p.Name)
+	ts.Equal([]string{"AMAZON MKTPLACE"}, p.Aliases.Names())
+}
+
+func (ts *PayeesServiceTestSuite) TestUpdatePayee() {
+	ctx := context.Background()
+	p := &payees.Payee{}
+	ts.store.On("SavePayee", ctx, p).Return(nil)
+	err := ts.srv.UpdatePayee(ctx, p)
+	ts.Require().NoError(err, "Failed to update payee.")
+}
+
+func (ts *PayeesServiceTestSuite) TestGetPayee() {
+	ctx := context.Background()
+	UUID := uuid.Must(uuid.NewV4())
+	protoPayee := &payees.Payee{}
+	ts.store.On("GetPayee", ctx, UUID).Return(protoPayee, nil)
+	p, err := ts.srv.GetPayee(ctx, UUID)
+	ts.Require().NoError(err, "Failed to get payee.")
+	ts.Equal(protoPayee, p)
+}
+
+func (ts *PayeesServiceTestSuite) TestDeletePayee() {
+	ctx := context.Background()
+	p := &payees.Payee{}
+	ts.store.On("DeletePayee", ctx, p).Return(nil)
+	err := ts.srv.DeletePayee(ctx, p)
+	ts.Require().NoError(err, "Failed to delete payee.")
+}
+
+func (ts *PayeesServiceTestSuite) TestResolvePayee() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	amazon := payees.NewPayee(ws, "Amazon", []string{"AMZN"})
+	ts.store.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{amazon}, nil)
+
+	p, err := ts.srv.ResolvePayee(ctx, ws, "AMZN Digital")
+	ts.Require().NoError(err, "Failed to resolve payee.")
+	ts.Equal(amazon, p)
+
+	p, err = ts.srv.ResolvePayee(ctx, ws, "Corner shop")
+	ts.Require().NoError(err, "Failed to resolve payee.")
+	ts.Nil(p)
+}
+
+func TestPayeesService(t *testing.T) {
+	suite.Run(t, new(PayeesServiceTestSuite))
+}
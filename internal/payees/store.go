@@ -0,0 +1,68 @@
+package payees
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+type Store interface {
+	SavePayee(ctx context.Context, p *Payee) error
+	DeletePayee(ctx context.Context, p *Payee) error
+	GetPayee(ctx context.Context, UUID uuid.UUID) (*Payee, error)
+	GetWorkspacePayees(ctx context.Context, ws *workspaces.Workspace) (PayeeCollection, error)
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// SavePayee saves the payee replacing all of its previously saved aliases.
+func (s *gormStore) SavePayee(ctx context.Context, p *Payee) error {
+	return datastore.Conn(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		if err := datastore.Save(tx.Omit("Aliases"), p); err != nil {
+			return err
+		}
+		if err := tx.Where("payee_uuid = ?", p.UUID).Delete(&Alias{}).Error; err != nil {
+			return err
+		}
+		if len(p.Aliases) == 0 {
+			return nil
+		}
+		for _, a := range p.Aliases {
+			a.PayeeUUID = p.UUID
+		}
+		return tx.Create(&p.Aliases).Error
+	})
+}
+
+func (s *gormStore) DeletePayee(ctx context.Context, p *Payee) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db), p)
+}
+
+func (s *gormStore) GetPayee(ctx context.Context, UUID uuid.UUID) (*Payee, error) {
+	var p Payee
+	err := datastore.Conn(ctx, s.db).Preload("Aliases").Where("uuid = ?", UUID).First(&p).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *gormStore) GetWorkspacePayees(ctx context.Context, ws *workspaces.Workspace) (PayeeCollection, error) {
+	ps := make(PayeeCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Preload("Aliases").Where("workspace_uuid = ?", ws.UUID).Order("name").Find(&ps).Error; err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
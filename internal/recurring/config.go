@@ -0,0 +1,16 @@
+package recurring
+
+import (
+	"github.com/joeshaw/envdecode"
+	"time"
+)
+
+type Config struct {
+	SchedulerInterval time.Duration `env:"RECURRING_SCHEDULER_INTERVAL,default=1h"`
+}
+
+func NewConfig() *Config {
+	cfg := Config{}
+	_ = envdecode.Decode(&cfg)
+	return &cfg
+}
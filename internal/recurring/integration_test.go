@@ -0,0 +1,89 @@
+//go:build integration
+
+package recurring_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type RecurringIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *recurring.Service
+}
+
+func (ts *RecurringIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "rcr_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	transactionsStore := transactions.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	payeesStore := payees.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	txManager := datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true}))
+	transactionsService := transactions.NewService(transactionsStore, txManager, payees.NewService(payeesStore), audit.Discard)
+	store := recurring.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = recurring.NewService(store, txManager, transactionsService)
+
+	err = db.Migrator().AutoMigrate(&payees.Payee{}, &payees.Alias{}, &transactions.Transaction{}, &transactions.Split{}, &recurring.Template{}, &recurring.Occurrence{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *RecurringIntegrationTestSuite) TestGenerate() {
+	ws := ts.createTestingWorkspace()
+	tpl, err := ts.srv.CreateTemplate(context.Background(), ws, recurring.CadenceMonthly, "2010-01", "2010-03", "usd", -500, "rent", nil, nil)
+	ts.Require().NoError(err, "Failed to create template.")
+
+	occs, err := ts.srv.Preview(context.Background(), ws, "2010-02")
+	ts.Require().NoError(err, "Failed to preview recurring transactions.")
+	ts.Len(occs, 2)
+
+	_, err = ts.srv.Generate(context.Background(), "2010-02")
+	ts.Require().NoError(err, "Failed to generate recurring transactions.")
+	_, err = ts.srv.Generate(context.Background(), "2010-05")
+	ts.Require().NoError(err, "Failed to generate recurring transactions.")
+	_, err = ts.srv.Generate(context.Background(), "2010-05")
+	ts.Require().NoError(err, "Failed to generate recurring transactions.")
+
+	var count int64
+	err = ts.db.Model(&transactions.Transaction{}).Where("workspace_uuid = ?", ws.UUID).Count(&count).Error
+	ts.Require().NoError(err, "Failed to count generated transactions.")
+	ts.Equal(int64(3), count)
+
+	occs, err = ts.srv.Preview(context.Background(), ws, "2010-05")
+	ts.Require().NoError(err, "Failed to preview recurring transactions.")
+	ts.Empty(occs)
+
+	err = ts.srv.DeleteTemplate(context.Background(), tpl)
+	ts.Require().NoError(err, "Failed to delete template.")
+}
+
+func (ts *RecurringIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+func TestRecurringIntegration(t *testing.T) {
+	suite.Run(t, new(RecurringIntegrationTestSuite))
+}
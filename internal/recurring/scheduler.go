@@ -0,0 +1,50 @@
+package recurring
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/log"
+	"time"
+)
+
+// Scheduler periodically materializes recurring transactions for the current month.
+type Scheduler struct {
+	srv      *Service
+	interval time.Duration
+}
+
+// NewScheduler initializes a new recurring transactions scheduler.
+func NewScheduler(cfg *Config, srv *Service) *Scheduler {
+	interval := cfg.SchedulerInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Scheduler{srv: srv, interval: interval}
+}
+
+// Run runs the scheduler until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	log.Infof("[RECURRING] Starting scheduler with %s interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.generate(ctx)
+		select {
+		case <-ctx.Done():
+			log.Infof("[RECURRING] Stopping scheduler")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) generate(ctx context.Context) {
+	month := time.Now().Format(accounts.FmtYearMonth)
+	n, err := s.srv.Generate(ctx, month)
+	if err != nil {
+		log.Errorf("[RECURRING] Failed to generate transactions for %s: %s", month, err)
+	}
+	if n > 0 {
+		log.Infof("[RECURRING] Generated %d transactions for %s", n, month)
+	}
+}
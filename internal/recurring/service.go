@@ -0,0 +1,121 @@
+package recurring
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+)
+
+type TransactionsService interface {
+	SaveTransaction(ctx context.Context, tx *transactions.Transaction) error
+}
+
+// Service is a service responsible for managing recurring transactions.
+type Service struct {
+	db           Store
+	txm          datastore.TxManager
+	transactions TransactionsService
+}
+
+// NewService initializes a new recurring transactions service.
+func NewService(db Store, txm datastore.TxManager, transSrv TransactionsService) *Service {
+	return &Service{db: db, txm: txm, transactions: transSrv}
+}
+
+func (s *Service) CreateTemplate(ctx context.Context, ws *workspaces.Workspace, cadence Cadence, start, end string, currency accounts.Currency, amt float64, desc string, cat *categories.Category, acc *accounts.Account) (*Template, error) {
+	tpl := NewTemplate(ws, cadence, start, end, currency, amt, desc, cat, acc)
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.db.SaveTemplate(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+func (s *Service) DeleteTemplate(ctx context.Context, tpl *Template) error {
+	return s.db.DeleteTemplate(ctx, tpl)
+}
+
+func (s *Service) GetTemplate(ctx context.Context, UUID uuid.UUID) (*Template, error) {
+	return s.db.GetTemplate(ctx, UUID)
+}
+
+func (s *Service) GetWorkspaceTemplates(ctx context.Context, ws *workspaces.Workspace) (TemplateCollection, error) {
+	return s.db.GetWorkspaceTemplates(ctx, ws)
+}
+
+// Preview lists user's template occurrences up to the specified month that were not materialized yet.
+func (s *Service) Preview(ctx context.Context, ws *workspaces.Workspace, month string) (OccurrenceCollection, error) {
+	tpls, err := s.db.GetWorkspaceTemplates(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	return s.getPendingOccurrences(ctx, tpls, month)
+}
+
+// Generate materializes all pending template occurrences up to the specified month into transactions.
+// Occurrences that were already materialized are skipped, so it is safe to call it repeatedly.
+// Each transaction is saved atomically with its occurrence, so a failure does not leave a duplicate behind.
+func (s *Service) Generate(ctx context.Context, month string) (int, error) {
+	tpls, err := s.db.GetActiveTemplates(ctx, month)
+	if err != nil {
+		return 0, err
+	}
+	occs, err := s.getPendingOccurrences(ctx, tpls, month)
+	if err != nil {
+		return 0, err
+	}
+	for i, occ := range occs {
+		if err := s.txm.InTx(ctx, func(ctx context.Context) error { return s.materialize(ctx, occ) }); err != nil {
+			return i, err
+		}
+	}
+	return len(occs), nil
+}
+
+// materialize saves the transaction of the occurrence and marks the occurrence as materialized.
+func (s *Service) materialize(ctx context.Context, occ *Occurrence) error {
+	tx := occ.Transaction()
+	if err := s.transactions.SaveTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	occ.TransactionUUID = &tx.UUID
+	if err := s.db.SaveOccurrence(ctx, occ); err != nil {
+		return fmt.Errorf("failed to save occurrence: %w", err)
+	}
+	return nil
+}
+
+// getPendingOccurrences lists occurrences of provided templates up to the specified month that were not materialized yet.
+func (s *Service) getPendingOccurrences(ctx context.Context, tpls TemplateCollection, month string) (OccurrenceCollection, error) {
+	occs := make(OccurrenceCollection, 0)
+	for _, tpl := range tpls {
+		due, err := tpl.DueMonths(tpl.StartMonth, month)
+		if err != nil {
+			return nil, err
+		}
+		if len(due) == 0 {
+			continue
+		}
+		done, err := s.db.GetTemplateMonths(ctx, tpl)
+		if err != nil {
+			return nil, err
+		}
+		generated := make(map[string]bool, len(done))
+		for _, m := range done {
+			generated[m] = true
+		}
+		for _, m := range due {
+			if !generated[m] {
+				occs = append(occs, NewOccurrence(tpl, m))
+			}
+		}
+	}
+	return occs, nil
+}
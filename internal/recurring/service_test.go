@@ -0,0 +1,92 @@
+package recurring_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RecurringServiceTestSuite struct {
+	suite.Suite
+	store        *mocks.Store
+	transactions *mocks.TransactionsService
+	srv          *recurring.Service
+}
+
+func (ts *RecurringServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.transactions = mocks.NewTransactionsService(ts.T())
+	ts.srv = recurring.NewService(ts.store, datastore.NewNopTxManager(), ts.transactions)
+}
+
+func (ts *RecurringServiceTestSuite) TestCreateTemplate() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	ts.store.On("SaveTemplate", ctx, mock.AnythingOfType("*recurring.Template")).
+		Return(nil).Once()
+
+	tpl, err := ts.srv.CreateTemplate(ctx, ws, recurring.CadenceMonthly, "2010-01", "", "usd", -500, "rent", nil, nil)
+	ts.Require().NoError(err, "Failed to create template.")
+	ts.Require().NotNil(tpl)
+}
+
+func (ts *RecurringServiceTestSuite) TestCreateTemplate_Invalid() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+
+	tpl, err := ts.srv.CreateTemplate(ctx, ws, "weekly", "2010-01", "", "usd", -500, "rent", nil, nil)
+	ts.ErrorIs(err, recurring.ErrInvalidCadence)
+	ts.Nil(tpl)
+}
+
+func (ts *RecurringServiceTestSuite) TestPreview() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	tpl := &recurring.Template{
+		Model:      datastore.Model{UUID: uuid.Must(uuid.NewV4())},
+		Cadence:    recurring.CadenceMonthly,
+		StartMonth: "2010-01",
+		Amount:     -500,
+	}
+	ts.store.On("GetWorkspaceTemplates", ctx, ws).Return(recurring.TemplateCollection{tpl}, nil).Once()
+	ts.store.On("GetTemplateMonths", ctx, tpl).Return([]string{"2010-01", "2010-02"}, nil).Once()
+
+	occs, err := ts.srv.Preview(ctx, ws, "2010-04")
+	ts.Require().NoError(err, "Failed to preview recurring transactions.")
+	ts.Require().Len(occs, 2)
+	ts.Equal("2010-03", occs[0].YearMonth)
+	ts.Equal("2010-04", occs[1].YearMonth)
+	ts.Equal(tpl.UUID, occs[0].TemplateUUID)
+}
+
+func (ts *RecurringServiceTestSuite) TestGenerate() {
+	ctx := context.Background()
+	tpl := &recurring.Template{
+		Model:         datastore.Model{UUID: uuid.Must(uuid.NewV4())},
+		WorkspaceUUID: uuid.Must(uuid.NewV4()),
+		Cadence:       recurring.CadenceQuarterly,
+		StartMonth:    "2010-01",
+		Amount:        -500,
+	}
+	ts.store.On("GetActiveTemplates", ctx, "2010-07").Return(recurring.TemplateCollection{tpl}, nil).Once()
+	ts.store.On("GetTemplateMonths", ctx, tpl).Return([]string{"2010-01"}, nil).Once()
+	ts.transactions.On("SaveTransaction", ctx, mock.MatchedBy(func(tx *transactions.Transaction) bool {
+		return tx.YearMonth == "2010-04" || tx.YearMonth == "2010-07"
+	})).Return(nil).Twice()
+	ts.store.On("SaveOccurrence", ctx, mock.AnythingOfType("*recurring.Occurrence")).Return(nil).Twice()
+
+	n, err := ts.srv.Generate(ctx, "2010-07")
+	ts.Require().NoError(err, "Failed to generate recurring transactions.")
+	ts.Equal(2, n)
+}
+
+func TestRecurringService(t *testing.T) {
+	suite.Run(t, new(RecurringServiceTestSuite))
+}
@@ -0,0 +1,92 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// Store is an interface for recurring transactions DB API.
+type Store interface {
+	// SaveTemplate saves template entity into the DB.
+	SaveTemplate(ctx context.Context, tpl *Template) error
+	// DeleteTemplate deletes template entity from the DB.
+	DeleteTemplate(ctx context.Context, tpl *Template) error
+	// GetTemplate retrieves template by its UUID.
+	GetTemplate(ctx context.Context, UUID uuid.UUID) (*Template, error)
+	// GetWorkspaceTemplates retrieves all workspace templates.
+	GetWorkspaceTemplates(ctx context.Context, ws *workspaces.Workspace) (TemplateCollection, error)
+	// GetActiveTemplates retrieves templates of all users that started at or before the specified month.
+	GetActiveTemplates(ctx context.Context, month string) (TemplateCollection, error)
+	// GetTemplateMonths retrieves months in which the template was already materialized.
+	GetTemplateMonths(ctx context.Context, tpl *Template) ([]string, error)
+	// SaveOccurrence records materialized template occurrence.
+	SaveOccurrence(ctx context.Context, occ *Occurrence) error
+}
+
+// gormStore is GORM implementation of Store.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore initializes GORM implementation of Store.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) SaveTemplate(ctx context.Context, tpl *Template) error {
+	return datastore.Save(datastore.Conn(ctx, s.db), tpl)
+}
+
+func (s *gormStore) DeleteTemplate(ctx context.Context, tpl *Template) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db), tpl)
+}
+
+func (s *gormStore) GetTemplate(ctx context.Context, UUID uuid.UUID) (*Template, error) {
+	tpl := &Template{}
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").First(tpl, "uuid = ?", UUID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+func (s *gormStore) GetWorkspaceTemplates(ctx context.Context, ws *workspaces.Workspace) (TemplateCollection, error) {
+	tpls := make(TemplateCollection, 0)
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Where("workspace_uuid = ?", ws.UUID).Find(&tpls).Error
+	if err != nil {
+		return nil, err
+	}
+	return tpls, nil
+}
+
+func (s *gormStore) GetActiveTemplates(ctx context.Context, month string) (TemplateCollection, error) {
+	tpls := make(TemplateCollection, 0)
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Where("start_month <= ?", month).Find(&tpls).Error
+	if err != nil {
+		return nil, err
+	}
+	return tpls, nil
+}
+
+func (s *gormStore) GetTemplateMonths(ctx context.Context, tpl *Template) ([]string, error) {
+	var months []string
+	err := datastore.Conn(ctx, s.db).
+		Model(&Occurrence{}).
+		Where("template_uuid = ?", tpl.UUID).
+		Pluck("year_month", &months).Error
+	if err != nil {
+		return nil, err
+	}
+	return months, nil
+}
+
+func (s *gormStore) SaveOccurrence(ctx context.Context, occ *Occurrence) error {
+	return datastore.Conn(ctx, s.db).Create(occ).Error
+}
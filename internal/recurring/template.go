@@ -0,0 +1,139 @@
+package recurring
+
+import (
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+var (
+	ErrInvalidCadence = errors.New("invalid cadence")
+	ErrInvalidPeriod  = errors.New("end month is before start month")
+)
+
+// Cadence defines how often a recurring transaction happens.
+type Cadence string
+
+const (
+	CadenceMonthly   Cadence = "monthly"
+	CadenceQuarterly Cadence = "quarterly"
+	CadenceYearly    Cadence = "yearly"
+)
+
+// Interval provides the number of months between two occurrences.
+func (c Cadence) Interval() int {
+	switch c {
+	case CadenceMonthly:
+		return 1
+	case CadenceQuarterly:
+		return 3
+	case CadenceYearly:
+		return 12
+	}
+	return 0
+}
+
+// Template represents a recurring transaction template.
+type Template struct {
+	datastore.Model
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Cadence       Cadence               `gorm:"notNull"`
+	StartMonth    string                `gorm:"type:varchar(7);notNull"`
+	EndMonth      string                `gorm:"type:varchar(7)"`
+	Currency      accounts.Currency
+	Amount        float64
+	Description   string
+	CategoryUUID  *uuid.UUID           `gorm:"index"`
+	Category      *categories.Category `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	AccountUUID   *uuid.UUID           `gorm:"index"`
+	Account       *accounts.Account    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+// NewTemplate initializes a new recurring transaction template.
+func NewTemplate(ws *workspaces.Workspace, cadence Cadence, start, end string, currency accounts.Currency, amt float64, desc string, cat *categories.Category, acc *accounts.Account) *Template {
+	return &Template{
+		WorkspaceUUID: ws.UUID,
+		Cadence:       cadence,
+		StartMonth:    start,
+		EndMonth:      end,
+		Currency:      currency,
+		Amount:        amt,
+		Description:   desc,
+		Category:      cat,
+		Account:       acc,
+	}
+}
+
+// Validate checks that template cadence and period are valid.
+func (t *Template) Validate() error {
+	if t.Cadence.Interval() == 0 {
+		return ErrInvalidCadence
+	}
+	if t.EndMonth != "" && t.EndMonth < t.StartMonth {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
+
+// DueMonths lists months between from and to (inclusive) in which the template should be materialized.
+func (t *Template) DueMonths(from, to string) ([]string, error) {
+	interval := t.Cadence.Interval()
+	if interval == 0 {
+		return nil, ErrInvalidCadence
+	}
+	d, err := time.Parse(accounts.FmtYearMonth, t.StartMonth)
+	if err != nil {
+		return nil, err
+	}
+	months := make([]string, 0)
+	for month := t.StartMonth; month <= to; month = d.Format(accounts.FmtYearMonth) {
+		if t.EndMonth != "" && month > t.EndMonth {
+			break
+		}
+		if month >= from {
+			months = append(months, month)
+		}
+		d = d.AddDate(0, interval, 0)
+	}
+	return months, nil
+}
+
+// TemplateCollection represents a collection of recurring transaction templates.
+type TemplateCollection []*Template
+
+// Occurrence represents a month in which a template is or will be materialized into a transaction.
+type Occurrence struct {
+	TemplateUUID    uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Template        *Template  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	YearMonth       string     `gorm:"primaryKey;type:varchar(7);notNull"`
+	TransactionUUID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time
+}
+
+// NewOccurrence initializes a new occurrence of the template.
+func NewOccurrence(tpl *Template, month string) *Occurrence {
+	return &Occurrence{
+		TemplateUUID: tpl.UUID,
+		Template:     tpl,
+		YearMonth:    month,
+	}
+}
+
+// Transaction builds the transaction the occurrence materializes into.
+func (o *Occurrence) Transaction() *transactions.Transaction {
+	t := o.Template
+	tx := transactions.NewTransaction(workspaces.Ref(t.WorkspaceUUID), o.YearMonth, t.Currency, t.Amount, t.Description, t.Category)
+	tx.CategoryUUID = t.CategoryUUID
+	tx.AccountUUID = t.AccountUUID
+	tx.Account = t.Account
+	return tx
+}
+
+// OccurrenceCollection represents a collection of template occurrences.
+type OccurrenceCollection []*Occurrence
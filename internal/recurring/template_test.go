@@ -0,0 +1,99 @@
+package recurring_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TemplateTestSuite struct {
+	suite.Suite
+}
+
+func (ts *TemplateTestSuite) TestValidate() {
+	tests := []struct {
+		name string
+		tpl  *recurring.Template
+		err  error
+	}{
+		{
+			name: "valid",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceMonthly, StartMonth: "2010-01", EndMonth: "2010-12"},
+		},
+		{
+			name: "open-ended",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceYearly, StartMonth: "2010-01"},
+		},
+		{
+			name: "invalid cadence",
+			tpl:  &recurring.Template{Cadence: "weekly", StartMonth: "2010-01"},
+			err:  recurring.ErrInvalidCadence,
+		},
+		{
+			name: "end before start",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceMonthly, StartMonth: "2010-05", EndMonth: "2010-04"},
+			err:  recurring.ErrInvalidPeriod,
+		},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			ts.ErrorIs(tt.tpl.Validate(), tt.err)
+		})
+	}
+}
+
+func (ts *TemplateTestSuite) TestDueMonths() {
+	tests := []struct {
+		name     string
+		tpl      *recurring.Template
+		from, to string
+		want     []string
+	}{
+		{
+			name: "monthly",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceMonthly, StartMonth: "2010-11"},
+			from: "2010-01",
+			to:   "2011-02",
+			want: []string{"2010-11", "2010-12", "2011-01", "2011-02"},
+		},
+		{
+			name: "monthly/ended",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceMonthly, StartMonth: "2010-11", EndMonth: "2010-12"},
+			from: "2010-01",
+			to:   "2011-02",
+			want: []string{"2010-11", "2010-12"},
+		},
+		{
+			name: "quarterly/from",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceQuarterly, StartMonth: "2010-02"},
+			from: "2010-06",
+			to:   "2011-02",
+			want: []string{"2010-08", "2010-11", "2011-02"},
+		},
+		{
+			name: "yearly",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceYearly, StartMonth: "2010-03"},
+			from: "2010-01",
+			to:   "2012-02",
+			want: []string{"2010-03", "2011-03"},
+		},
+		{
+			name: "not started",
+			tpl:  &recurring.Template{Cadence: recurring.CadenceMonthly, StartMonth: "2010-03"},
+			from: "2010-01",
+			to:   "2010-02",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			got, err := tt.tpl.DueMonths(tt.from, tt.to)
+			ts.Require().NoError(err)
+			ts.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	suite.Run(t, new(TemplateTestSuite))
+}
@@ -5,21 +5,32 @@ import (
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/capital"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"time"
 )
 
 type CapitalService interface {
-	GetCapital(ctx context.Context, u *users.User, month string) (*capital.Capital, error)
+	GetCapital(ctx context.Context, ws *workspaces.Workspace, month string) (*capital.Capital, error)
 }
 
 type TransactionsService interface {
-	GetUserTransactions(ctx context.Context, u *users.User, month string) (transactions.TransactionCollection, error)
+	GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (transactions.TransactionCollection, error)
+	GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from, to string) (transactions.TransactionCollection, error)
 }
 
 type CategoryService interface {
-	GetUserCategories(ctx context.Context, u *users.User) ([]*categories.Category, error)
+	GetWorkspaceCategories(ctx context.Context, ws *workspaces.Workspace) ([]*categories.Category, error)
+}
+
+type LabelsService interface {
+	GetWorkspaceLabels(ctx context.Context, ws *workspaces.Workspace) (labels.LabelCollection, error)
+}
+
+type PayeesService interface {
+	GetWorkspacePayees(ctx context.Context, ws *workspaces.Workspace) (payees.PayeeCollection, error)
 }
 
 // Service is a service responsible for calculating spendings.
@@ -27,20 +38,22 @@ type Service struct {
 	capital      CapitalService
 	transactions TransactionsService
 	categories   CategoryService
+	labels       LabelsService
+	payees       PayeesService
 }
 
 // NewService initializes the spendings service.
-func NewService(capSrv CapitalService, transSrv TransactionsService, catSrv CategoryService) *Service {
-	return &Service{capital: capSrv, transactions: transSrv, categories: catSrv}
+func NewService(capSrv CapitalService, transSrv TransactionsService, catSrv CategoryService, lblSrv LabelsService, payeeSrv PayeesService) *Service {
+	return &Service{capital: capSrv, transactions: transSrv, categories: catSrv, labels: lblSrv, payees: payeeSrv}
 }
 
 // GetMonthSpendings calculates funds spent during specified month.
-func (s *Service) GetMonthSpendings(ctx context.Context, u *users.User, month string) (Spendings, error) {
-	spent, err := s.getTransactionSummary(ctx, u, month)
+func (s *Service) GetMonthSpendings(ctx context.Context, ws *workspaces.Workspace, month string) (Spendings, error) {
+	spent, err := s.getTransactionSummary(ctx, ws, month)
 	if err != nil {
 		return nil, err
 	}
-	capt, err := s.getCapitalDiff(ctx, u, month)
+	capt, err := s.getCapitalDiff(ctx, ws, month)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +62,72 @@ func (s *Service) GetMonthSpendings(ctx context.Context, u *users.User, month st
 	return spent, nil
 }
 
+// GetLabelSpendings calculates funds spent per label during the period between specified months (inclusive).
+// Transactions with multiple labels are counted towards each of them, transactions without labels are counted as uncategorized.
+func (s *Service) GetLabelSpendings(ctx context.Context, ws *workspaces.Workspace, from, to string) (Spendings, error) {
+	lbls, err := s.labels.GetWorkspaceLabels(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	cats := make([]*categories.Category, 0, len(lbls))
+	for _, lbl := range lbls {
+		cats = append(cats, LabelCategory(lbl))
+	}
+	spent := NewSpendings(cats)
+
+	txs, err := s.transactions.GetPeriodTransactions(ctx, ws, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if len(tx.Labels) == 0 {
+			spent.AddAmount(nil, tx.Currency, tx.Amount)
+			continue
+		}
+		for _, lbl := range tx.Labels {
+			spent.AddAmount(LabelCategory(lbl), tx.Currency, tx.Amount)
+		}
+	}
+	return spent, nil
+}
+
+// GetPayeeSpendings calculates funds spent per payee during the period between specified months (inclusive).
+// Transactions without a payee are counted as uncategorized.
+func (s *Service) GetPayeeSpendings(ctx context.Context, ws *workspaces.Workspace, from, to string) (Spendings, error) {
+	ps, err := s.payees.GetWorkspacePayees(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	cats := make([]*categories.Category, 0, len(ps))
+	for _, p := range ps {
+		cats = append(cats, PayeeCategory(p))
+	}
+	spent := NewSpendings(cats)
+
+	txs, err := s.transactions.GetPeriodTransactions(ctx, ws, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if tx.Payee == nil {
+			spent.AddAmount(nil, tx.Currency, tx.Amount)
+			continue
+		}
+		spent.AddAmount(PayeeCategory(tx.Payee), tx.Currency, tx.Amount)
+	}
+	return spent, nil
+}
+
+// LabelCategory represents the label as a category to aggregate spendings by labels.
+func LabelCategory(lbl *labels.Label) *categories.Category {
+	return &categories.Category{Model: lbl.Model, WorkspaceUUID: lbl.WorkspaceUUID, Name: lbl.Name}
+}
+
+// PayeeCategory represents the payee as a category to aggregate spendings by payees.
+func PayeeCategory(p *payees.Payee) *categories.Category {
+	return &categories.Category{Model: p.Model, WorkspaceUUID: p.WorkspaceUUID, Name: p.Name}
+}
+
 // getPrevMonth calculates YYYY-MM representation of month previous to the provided.
 func getPrevMonth(month string) (string, error) {
 	d, err := time.Parse(accounts.FmtYearMonth, month)
@@ -59,8 +138,8 @@ func getPrevMonth(month string) (string, error) {
 }
 
 // getCapitalDiff calculates the difference between specified month and previous month capitals.
-func (s *Service) getCapitalDiff(ctx context.Context, u *users.User, month string) (accounts.CurrencyAmounts, error) {
-	currentCapital, err := s.capital.GetCapital(ctx, u, month)
+func (s *Service) getCapitalDiff(ctx context.Context, ws *workspaces.Workspace, month string) (accounts.CurrencyAmounts, error) {
+	currentCapital, err := s.capital.GetCapital(ctx, ws, month)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +147,7 @@ func (s *Service) getCapitalDiff(ctx context.Context, u *users.User, month strin
 	if err != nil {
 		return nil, err
 	}
-	prevCapital, err := s.capital.GetCapital(ctx, u, prevMonth)
+	prevCapital, err := s.capital.GetCapital(ctx, ws, prevMonth)
 	if err != nil {
 		return nil, err
 	}
@@ -76,14 +155,14 @@ func (s *Service) getCapitalDiff(ctx context.Context, u *users.User, month strin
 }
 
 // getTransactionSummary calculates the sum of transactions recorded during given month.
-func (s *Service) getTransactionSummary(ctx context.Context, u *users.User, month string) (Spendings, error) {
-	cats, err := s.categories.GetUserCategories(ctx, u)
+func (s *Service) getTransactionSummary(ctx context.Context, ws *workspaces.Workspace, month string) (Spendings, error) {
+	cats, err := s.categories.GetWorkspaceCategories(ctx, ws)
 	if err != nil {
 		return nil, err
 	}
 	spent := NewSpendings(cats)
 
-	txs, err := s.transactions.GetUserTransactions(ctx, u, month)
+	txs, err := s.transactions.GetWorkspaceTransactions(ctx, ws, month)
 	if err != nil {
 		return nil, err
 	}
@@ -6,10 +6,12 @@ import (
 	"github.com/d-ashesss/mah-moneh/internal/capital"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -20,6 +22,8 @@ type SpendingsServiceTestSuite struct {
 	capital      *mocks.CapitalService
 	transactions *mocks.TransactionsService
 	categories   *mocks.CategoryService
+	labels       *mocks.LabelsService
+	payees       *mocks.PayeesService
 	srv          *spendings.Service
 }
 
@@ -27,7 +31,9 @@ func (ts *SpendingsServiceTestSuite) SetupTest() {
 	ts.capital = mocks.NewCapitalService(ts.T())
 	ts.transactions = mocks.NewTransactionsService(ts.T())
 	ts.categories = mocks.NewCategoryService(ts.T())
-	ts.srv = spendings.NewService(ts.capital, ts.transactions, ts.categories)
+	ts.labels = mocks.NewLabelsService(ts.T())
+	ts.payees = mocks.NewPayeesService(ts.T())
+	ts.srv = spendings.NewService(ts.capital, ts.transactions, ts.categories, ts.labels, ts.payees)
 }
 
 func newCategory(UUID string) *categories.Category {
@@ -40,7 +46,7 @@ func newCategory(UUID string) *categories.Category {
 
 func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings() {
 	ctx := context.Background()
-	u := &users.User{}
+	ws := &workspaces.Workspace{}
 	prevCap := &capital.Capital{Amounts: accounts.CurrencyAmounts{
 		"usd": 13,
 		"eur": 20,
@@ -51,15 +57,15 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings() {
 		"eur": 12,
 		"btc": 2,
 	}}
-	ts.capital.On("GetCapital", ctx, u, "2009-12").Return(prevCap, nil)
-	ts.capital.On("GetCapital", ctx, u, "2010-01").Return(currentCap, nil)
+	ts.capital.On("GetCapital", ctx, ws, "2009-12").Return(prevCap, nil)
+	ts.capital.On("GetCapital", ctx, ws, "2010-01").Return(currentCap, nil)
 	catIncomeUUID := "7070e309-af27-445a-9b15-3f9db12a5377"
 	catIncome := newCategory(catIncomeUUID)
 	catEmptyUUID := "01fbfdc3-c399-4cdf-bbf8-37b3422e6466"
 	catEmpty := newCategory(catEmptyUUID)
 	catSomethingUUID := "8d4357a0-d20b-410b-a520-cf7d575c402f"
 	catSomething := newCategory(catSomethingUUID)
-	ts.categories.On("GetUserCategories", ctx, u).Return([]*categories.Category{newCategory(catIncomeUUID), newCategory(catEmptyUUID), newCategory(catSomethingUUID)}, nil)
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{newCategory(catIncomeUUID), newCategory(catEmptyUUID), newCategory(catSomethingUUID)}, nil)
 	txs := transactions.TransactionCollection{
 		&transactions.Transaction{Amount: -8, Currency: "usd"},
 		&transactions.Transaction{Amount: 5, Currency: "usd", Category: newCategory(catIncomeUUID)},
@@ -68,8 +74,8 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings() {
 		&transactions.Transaction{Amount: -4, Currency: "eth"},
 		&transactions.Transaction{Amount: 2, Currency: "btc", Category: newCategory(catIncomeUUID)},
 	}
-	ts.transactions.On("GetUserTransactions", ctx, u, "2010-01").Return(txs, nil)
-	spending, err := ts.srv.GetMonthSpendings(ctx, u, "2010-01")
+	ts.transactions.On("GetWorkspaceTransactions", ctx, ws, "2010-01").Return(txs, nil)
+	spending, err := ts.srv.GetMonthSpendings(ctx, ws, "2010-01")
 	ts.Require().NoError(err, "Failed to get spendings.")
 
 	ts.InDelta(5.0, spending.GetAmount(catIncome, "usd"), 0.001)
@@ -102,7 +108,7 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings() {
 
 func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings_NoChangeInCapital() {
 	ctx := context.Background()
-	u := &users.User{}
+	ws := &workspaces.Workspace{}
 	prevCap := &capital.Capital{Amounts: accounts.CurrencyAmounts{
 		"usd": 13,
 		"eur": 20,
@@ -113,15 +119,15 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings_NoChangeInCapital() {
 		"eur": 20,
 		"eth": 4,
 	}}
-	ts.capital.On("GetCapital", ctx, u, "2009-12").Return(prevCap, nil)
-	ts.capital.On("GetCapital", ctx, u, "2010-01").Return(currentCap, nil)
+	ts.capital.On("GetCapital", ctx, ws, "2009-12").Return(prevCap, nil)
+	ts.capital.On("GetCapital", ctx, ws, "2010-01").Return(currentCap, nil)
 	catIncomeUUID := "7070e309-af27-445a-9b15-3f9db12a5377"
 	catIncome := newCategory(catIncomeUUID)
 	catEmptyUUID := "01fbfdc3-c399-4cdf-bbf8-37b3422e6466"
 	catEmpty := newCategory(catEmptyUUID)
 	catSomethingUUID := "8d4357a0-d20b-410b-a520-cf7d575c402f"
 	catSomething := newCategory(catSomethingUUID)
-	ts.categories.On("GetUserCategories", ctx, u).Return([]*categories.Category{newCategory(catIncomeUUID), newCategory(catEmptyUUID), newCategory(catSomethingUUID)}, nil)
+	ts.categories.On("GetWorkspaceCategories", ctx, ws).Return([]*categories.Category{newCategory(catIncomeUUID), newCategory(catEmptyUUID), newCategory(catSomethingUUID)}, nil)
 	txs := transactions.TransactionCollection{
 		&transactions.Transaction{Amount: -8, Currency: "usd"},
 		&transactions.Transaction{Amount: 5, Currency: "usd", Category: newCategory(catIncomeUUID)},
@@ -130,8 +136,8 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings_NoChangeInCapital() {
 		&transactions.Transaction{Amount: -4, Currency: "eth"},
 		&transactions.Transaction{Amount: 2, Currency: "btc", Category: newCategory(catIncomeUUID)},
 	}
-	ts.transactions.On("GetUserTransactions", ctx, u, "2010-01").Return(txs, nil)
-	spending, err := ts.srv.GetMonthSpendings(ctx, u, "2010-01")
+	ts.transactions.On("GetWorkspaceTransactions", ctx, ws, "2010-01").Return(txs, nil)
+	spending, err := ts.srv.GetMonthSpendings(ctx, ws, "2010-01")
 	ts.Require().NoError(err, "Failed to get spendings.")
 
 	ts.InDelta(5.0, spending.GetAmount(catIncome, "usd"), 0.001)
@@ -162,6 +168,48 @@ func (ts *SpendingsServiceTestSuite) TestGetMonthSpendings_NoChangeInCapital() {
 	ts.InDelta(-2.0, unacctAmount["btc"], 0.001)
 }
 
+func (ts *SpendingsServiceTestSuite) TestGetLabelSpendings() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	lblTrip := &labels.Label{Model: datastore.Model{UUID: uuid.FromStringOrNil("5d0e0f51-6c1c-4c39-9f4d-5e1d8a1b6d01")}}
+	lblWork := &labels.Label{Model: datastore.Model{UUID: uuid.FromStringOrNil("9a3b1b0e-3f0a-4bb8-8d2b-0a4bb2f4a702")}}
+	ts.labels.On("GetWorkspaceLabels", ctx, ws).Return(labels.LabelCollection{lblTrip, lblWork}, nil)
+	ts.transactions.On("GetPeriodTransactions", ctx, ws, "2010-01", "2010-02").Return(transactions.TransactionCollection{
+		&transactions.Transaction{Amount: -8, Currency: "usd", Labels: labels.LabelCollection{lblTrip}},
+		&transactions.Transaction{Amount: -3, Currency: "usd"},
+		&transactions.Transaction{Amount: -2, Currency: "eur", Labels: labels.LabelCollection{lblTrip, lblWork}},
+	}, nil)
+
+	spending, err := ts.srv.GetLabelSpendings(ctx, ws, "2010-01", "2010-02")
+	ts.Require().NoError(err, "Failed to get label spendings.")
+
+	ts.InDelta(-8.0, spending.GetAmount(spendings.LabelCategory(lblTrip), "usd"), 0.001)
+	ts.InDelta(-2.0, spending.GetAmount(spendings.LabelCategory(lblTrip), "eur"), 0.001)
+	ts.InDelta(0.0, spending.GetAmount(spendings.LabelCategory(lblWork), "usd"), 0.001)
+	ts.InDelta(-2.0, spending.GetAmount(spendings.LabelCategory(lblWork), "eur"), 0.001)
+	ts.InDelta(-3.0, spending.GetUncategorized()["usd"], 0.001)
+}
+
+func (ts *SpendingsServiceTestSuite) TestGetPayeeSpendings() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	amazon := &payees.Payee{Model: datastore.Model{UUID: uuid.FromStringOrNil("3c1f4b0e-8a9d-4a43-9f27-6d2f1c9e7b11")}}
+	shop := &payees.Payee{Model: datastore.Model{UUID: uuid.FromStringOrNil("e2a7b6c4-1d5f-4c0a-b3e8-9f6d4a2c1b22")}}
+	ts.payees.On("GetWorkspacePayees", ctx, ws).Return(payees.PayeeCollection{amazon, shop}, nil)
+	ts.transactions.On("GetPeriodTransactions", ctx, ws, "2010-01", "2010-02").Return(transactions.TransactionCollection{
+		&transactions.Transaction{Amount: -8, Currency: "usd", Payee: amazon},
+		&transactions.Transaction{Amount: -3, Currency: "usd"},
+		&transactions.Transaction{Amount: -12, Currency: "usd", Payee: amazon},
+	}, nil)
+
+	spending, err := ts.srv.GetPayeeSpendings(ctx, ws, "2010-01", "2010-02")
+	ts.Require().NoError(err, "Failed to get payee spendings.")
+
+	ts.InDelta(-20.0, spending.GetAmount(spendings.PayeeCategory(amazon), "usd"), 0.001)
+	ts.InDelta(0.0, spending.GetAmount(spendings.PayeeCategory(shop), "usd"), 0.001)
+	ts.InDelta(-3.0, spending.GetUncategorized()["usd"], 0.001)
+}
+
 func TestSpendingsService(t *testing.T) {
 	suite.Run(t, new(SpendingsServiceTestSuite))
 }
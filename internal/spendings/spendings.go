@@ -77,8 +77,15 @@ func (s spendings) GetAmounts(cat *categories.Category) accounts.CurrencyAmounts
 	return accounts.NewCurrencyAmounts()
 }
 
+// AddTransaction adds transaction amount to its category, or distributes it across categories of its splits.
 func (s spendings) AddTransaction(tx *transactions.Transaction) {
-	s.AddAmount(tx.Category, tx.Currency, tx.Amount)
+	if len(tx.Splits) == 0 {
+		s.AddAmount(tx.Category, tx.Currency, tx.Amount)
+		return
+	}
+	for _, split := range tx.Splits {
+		s.AddAmount(split.Category, tx.Currency, split.Amount)
+	}
 }
 
 func (s spendings) GetUncategorized() accounts.CurrencyAmounts {
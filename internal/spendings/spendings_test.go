@@ -4,6 +4,7 @@ import (
 	"github.com/d-ashesss/mah-moneh/internal/categories"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
 	"github.com/d-ashesss/mah-moneh/internal/spendings"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/suite"
 	"testing"
@@ -51,6 +52,25 @@ func (ts *SpendingsTestSuite) TestAddAmount() {
 	ts.InDelta(8.5, gotTotal["usd"], 0.001)
 }
 
+func (ts *SpendingsTestSuite) TestAddTransaction_Splits() {
+	tx := &transactions.Transaction{
+		Currency: "usd",
+		Amount:   -10,
+		Category: ts.category,
+		Splits: transactions.SplitCollection{
+			transactions.NewSplit(ts.category, -7, ""),
+			transactions.NewSplit(nil, -3, ""),
+		},
+	}
+	ts.spendings.AddTransaction(tx)
+	gotCat := ts.spendings.GetAmount(ts.category, "usd")
+	ts.InDelta(-7, gotCat, 0.001)
+	gotUncat := ts.spendings.GetUncategorized()
+	ts.InDelta(-3, gotUncat["usd"], 0.001)
+	gotTotal := ts.spendings.GetTotal()
+	ts.InDelta(-10, gotTotal["usd"], 0.001)
+}
+
 func TestSpendings(t *testing.T) {
 	suite.Run(t, new(SpendingsTestSuite))
 }
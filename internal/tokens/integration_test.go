@@ -0,0 +1,78 @@
+//go:build integration
+
+package tokens_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type TokensIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *tokens.Service
+}
+
+func (ts *TokensIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "tok_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := tokens.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = tokens.NewService(store)
+
+	err = db.Migrator().AutoMigrate(&tokens.Token{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *TokensIntegrationTestSuite) TestToken() {
+	ctx := context.Background()
+	u := &users.User{ID: uuid.Must(uuid.NewV4()).String()}
+	t, secret, err := ts.srv.CreateToken(ctx, u, "cron", tokens.ScopeReadOnly, nil)
+	ts.Require().NoError(err, "Failed to create token.")
+
+	foundToken := &tokens.Token{}
+	err = ts.db.First(foundToken, "uuid = ?", t.UUID).Error
+	ts.Require().NoError(err, "Failed to find created token.")
+	ts.NotEqual(secret, foundToken.Hash, "Secret must not be stored.")
+
+	authToken, err := ts.srv.AuthenticateToken(ctx, secret)
+	ts.Require().NoError(err, "Failed to authenticate token.")
+	ts.Equal(t.UUID, authToken.UUID)
+	ts.Equal(u.ID, authToken.User.ID)
+	ts.NotNil(authToken.LastUsedAt)
+	usedToken := &tokens.Token{}
+	err = ts.db.First(usedToken, "uuid = ?", t.UUID).Error
+	ts.Require().NoError(err, "Failed to find used token.")
+	ts.NotNil(usedToken.LastUsedAt, "Usage must be recorded.")
+	ts.Equal(foundToken.Version, usedToken.Version, "Recording usage must not change the token.")
+	ts.True(foundToken.UpdatedAt.Equal(usedToken.UpdatedAt), "Recording usage must not change the token.")
+
+	userTokens, err := ts.srv.GetUserTokens(ctx, u)
+	ts.Require().NoError(err, "Failed to get user tokens.")
+	ts.Len(userTokens, 1)
+
+	err = ts.srv.RevokeToken(ctx, t)
+	ts.Require().NoError(err, "Failed to revoke token.")
+	_, err = ts.srv.AuthenticateToken(ctx, secret)
+	ts.ErrorIs(err, tokens.ErrInvalidToken)
+}
+
+func TestTokensIntegration(t *testing.T) {
+	suite.Run(t, new(TokensIntegrationTestSuite))
+}
@@ -0,0 +1,70 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type Service struct {
+	db Store
+}
+
+func NewService(db Store) *Service {
+	return &Service{db: db}
+}
+
+// CreateToken issues a new token for the user. The returned secret cannot be recovered later.
+func (s *Service) CreateToken(ctx context.Context, u *users.User, name string, scope Scope, expiresAt *time.Time) (*Token, string, error) {
+	if !scope.Valid() {
+		return nil, "", ErrInvalidScope
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidExpiry
+	}
+	t, secret, err := NewToken(u, name, scope, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.db.SaveToken(ctx, t); err != nil {
+		return nil, "", err
+	}
+	return t, secret, nil
+}
+
+func (s *Service) GetToken(ctx context.Context, UUID uuid.UUID) (*Token, error) {
+	return s.db.GetToken(ctx, UUID)
+}
+
+func (s *Service) GetUserTokens(ctx context.Context, u *users.User) (TokenCollection, error) {
+	return s.db.GetUserTokens(ctx, u)
+}
+
+// RevokeToken makes the token unusable.
+func (s *Service) RevokeToken(ctx context.Context, t *Token) error {
+	return s.db.DeleteToken(ctx, t)
+}
+
+// AuthenticateToken finds the active token matching the secret and records its usage, at most once a minute.
+func (s *Service) AuthenticateToken(ctx context.Context, secret string) (*Token, error) {
+	t, err := s.db.GetTokenByHash(ctx, HashSecret(secret))
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if t.Expired(now) {
+		return nil, ErrInvalidToken
+	}
+	if t.Use(now) {
+		if err := s.db.SetTokenLastUsedAt(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,101 @@
+package tokens_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/tokens"
+	"github.com/d-ashesss/mah-moneh/internal/tokens"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type TokensServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *tokens.Service
+}
+
+func (ts *TokensServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = tokens.NewService(ts.store)
+}
+
+func (ts *TokensServiceTestSuite) TestCreateToken() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	ts.store.On("SaveToken", ctx, mock.AnythingOfType("*tokens.Token")).Return(nil)
+
+	t, secret, err := ts.srv.CreateToken(ctx, u, "cron", tokens.ScopeReadOnly, nil)
+	ts.Require().NoError(err, "Failed to create token.")
+	ts.True(tokens.IsSecret(secret))
+	ts.Equal(tokens.HashSecret(secret), t.Hash)
+	ts.Equal("cron", t.Name)
+	ts.Equal(tokens.ScopeReadOnly, t.Scope)
+	ts.Equal(u, t.User)
+	ts.Nil(t.ExpiresAt)
+}
+
+func (ts *TokensServiceTestSuite) TestCreateToken_Invalid() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+
+	_, _, err := ts.srv.CreateToken(ctx, u, "cron", "admin", nil)
+	ts.ErrorIs(err, tokens.ErrInvalidScope)
+
+	past := time.Now().Add(-time.Hour)
+	_, _, err = ts.srv.CreateToken(ctx, u, "cron", tokens.ScopeReadWrite, &past)
+	ts.ErrorIs(err, tokens.ErrInvalidExpiry)
+}
+
+func (ts *TokensServiceTestSuite) TestAuthenticateToken() {
+	ctx := context.Background()
+	t, secret, err := tokens.NewToken(&users.User{ID: "user1"}, "cron", tokens.ScopeReadWrite, nil)
+	ts.Require().NoError(err)
+	ts.store.On("GetTokenByHash", ctx, tokens.HashSecret(secret)).Return(t, nil)
+	ts.store.On("SetTokenLastUsedAt", ctx, t).Return(nil).Once()
+
+	foundToken, err := ts.srv.AuthenticateToken(ctx, secret)
+	ts.Require().NoError(err, "Failed to authenticate token.")
+	ts.Equal(t, foundToken)
+	ts.NotNil(foundToken.LastUsedAt)
+}
+
+func (ts *TokensServiceTestSuite) TestAuthenticateToken_RecentlyUsed() {
+	ctx := context.Background()
+	t, secret, err := tokens.NewToken(&users.User{ID: "user1"}, "cron", tokens.ScopeReadWrite, nil)
+	ts.Require().NoError(err)
+	lastUsedAt := time.Now().Add(-10 * time.Second)
+	t.LastUsedAt = &lastUsedAt
+	ts.store.On("GetTokenByHash", ctx, tokens.HashSecret(secret)).Return(t, nil)
+
+	_, err = ts.srv.AuthenticateToken(ctx, secret)
+	ts.Require().NoError(err, "Failed to authenticate token.")
+	ts.Equal(lastUsedAt, *t.LastUsedAt, "Recent usage must not be recorded again.")
+}
+
+func (ts *TokensServiceTestSuite) TestAuthenticateToken_Expired() {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(-time.Minute)
+	t, secret, err := tokens.NewToken(&users.User{ID: "user1"}, "cron", tokens.ScopeReadWrite, &expiresAt)
+	ts.Require().NoError(err)
+	ts.store.On("GetTokenByHash", ctx, tokens.HashSecret(secret)).Return(t, nil)
+
+	_, err = ts.srv.AuthenticateToken(ctx, secret)
+	ts.ErrorIs(err, tokens.ErrInvalidToken)
+}
+
+func (ts *TokensServiceTestSuite) TestAuthenticateToken_Unknown() {
+	ctx := context.Background()
+	secret := tokens.Prefix + "forged"
+	ts.store.On("GetTokenByHash", ctx, tokens.HashSecret(secret)).Return(nil, datastore.ErrRecordNotFound)
+
+	_, err := ts.srv.AuthenticateToken(ctx, secret)
+	ts.ErrorIs(err, tokens.ErrInvalidToken)
+}
+
+func TestTokensService(t *testing.T) {
+	suite.Run(t, new(TokensServiceTestSuite))
+}
@@ -0,0 +1,68 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+type Store interface {
+	SaveToken(ctx context.Context, t *Token) error
+	DeleteToken(ctx context.Context, t *Token) error
+	SetTokenLastUsedAt(ctx context.Context, t *Token) error
+	GetToken(ctx context.Context, UUID uuid.UUID) (*Token, error)
+	GetTokenByHash(ctx context.Context, hash string) (*Token, error)
+	GetUserTokens(ctx context.Context, u *users.User) (TokenCollection, error)
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) SaveToken(ctx context.Context, t *Token) error {
+	return datastore.Conn(ctx, s.db).Save(t).Error
+}
+
+func (s *gormStore) DeleteToken(ctx context.Context, t *Token) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db), t)
+}
+
+// SetTokenLastUsedAt records the usage of the token without touching the rest of the record.
+func (s *gormStore) SetTokenLastUsedAt(ctx context.Context, t *Token) error {
+	return datastore.Conn(ctx, s.db).Model(t).UpdateColumn("last_used_at", t.LastUsedAt).Error
+}
+
+func (s *gormStore) GetToken(ctx context.Context, UUID uuid.UUID) (*Token, error) {
+	return s.getToken(ctx, "uuid = ?", UUID)
+}
+
+func (s *gormStore) GetTokenByHash(ctx context.Context, hash string) (*Token, error) {
+	return s.getToken(ctx, "hash = ?", hash)
+}
+
+func (s *gormStore) getToken(ctx context.Context, query string, args ...any) (*Token, error) {
+	var t Token
+	err := datastore.Conn(ctx, s.db).Where(query, args...).First(&t).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *gormStore) GetUserTokens(ctx context.Context, u *users.User) (TokenCollection, error) {
+	ts := make(TokenCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Where("user_id = ?", u.ID).Order("created_at").Find(&ts).Error; err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
@@ -0,0 +1,103 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"strings"
+	"time"
+)
+
+// Prefix distinguishes personal access tokens from other kinds of credentials, like JWTs.
+const Prefix = "mmpat_"
+
+// usageInterval is how often the usage of a token is recorded, so that every request does not write to the database.
+const usageInterval = time.Minute
+
+var (
+	ErrInvalidToken  = errors.New("invalid access token")
+	ErrInvalidScope  = errors.New("invalid token scope")
+	ErrInvalidExpiry = errors.New("token expiry must be in the future")
+)
+
+// Scope defines what the holder of a token is allowed to do on behalf of the user.
+type Scope string
+
+const (
+	// ScopeReadOnly only allows reading the data.
+	ScopeReadOnly Scope = "read-only"
+	// ScopeReadWrite allows both reading and modifying the data.
+	ScopeReadWrite Scope = "read-write"
+)
+
+// Valid checks that the scope is a known one.
+func (s Scope) Valid() bool {
+	return s == ScopeReadOnly || s == ScopeReadWrite
+}
+
+// AllowsWrite checks whether the scope permits modifying the data.
+func (s Scope) AllowsWrite() bool {
+	return s == ScopeReadWrite
+}
+
+// Token is a personal access token allowing scripts and integrations to act on behalf of the user.
+type Token struct {
+	datastore.Model
+	User  *users.User `gorm:"embedded;embeddedPrefix:user_;notNull"`
+	Name  string      `gorm:"notNull"`
+	Scope Scope       `gorm:"notNull"`
+	// Hash is a hash of the token secret, the secret itself is never stored.
+	Hash       string `gorm:"notNull;uniqueIndex"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// NewToken initializes a new token along with its secret.
+func NewToken(u *users.User, name string, scope Scope, expiresAt *time.Time) (*Token, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	secret := Prefix + base64.RawURLEncoding.EncodeToString(buf)
+	t := &Token{
+		User:      u,
+		Name:      name,
+		Scope:     scope,
+		Hash:      HashSecret(secret),
+		ExpiresAt: expiresAt,
+	}
+	return t, secret, nil
+}
+
+// IsSecret checks whether the credential looks like a personal access token.
+func IsSecret(credential string) bool {
+	return strings.HasPrefix(credential, Prefix)
+}
+
+// HashSecret provides the hash under which the token secret is stored.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Expired checks whether the token can no longer be used.
+func (t *Token) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// Use marks the token as used at the time, reporting whether the usage needs to be recorded.
+// Usage is only recorded once per usageInterval.
+func (t *Token) Use(now time.Time) bool {
+	if t.LastUsedAt != nil && now.Sub(*t.LastUsedAt) < usageInterval {
+		return false
+	}
+	t.LastUsedAt = &now
+	return true
+}
+
+// TokenCollection represents a collection of tokens.
+type TokenCollection []*Token
@@ -0,0 +1,70 @@
+package transactions
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// SortField defines by which field the transactions are ordered.
+type SortField string
+
+const (
+	SortByMonth     SortField = "month"
+	SortByDate      SortField = "date"
+	SortByAmount    SortField = "amount"
+	SortByCreatedAt SortField = "created_at"
+)
+
+// Filter defines criteria for searching user transactions.
+// Transactions without a date are treated as happening on the first day of their month when filtering or sorting by date.
+type Filter struct {
+	FromMonth     string
+	ToMonth       string
+	FromDate      *time.Time
+	ToDate        *time.Time
+	CategoryUUID  *uuid.UUID
+	Uncategorized bool
+	Currency      accounts.Currency
+	MinAmount     *float64
+	MaxAmount     *float64
+	Query         string
+	AccountUUID   *uuid.UUID
+	LabelUUID     *uuid.UUID
+	PayeeUUID     *uuid.UUID
+	Sort          SortField
+	Descending    bool
+	Limit         int
+	Cursor        string
+}
+
+// GetLimit provides the page size within allowed range.
+func (f *Filter) GetLimit() int {
+	if f.Limit <= 0 {
+		return DefaultLimit
+	}
+	if f.Limit > MaxLimit {
+		return MaxLimit
+	}
+	return f.Limit
+}
+
+// GetSort provides the sort field, defaulting to month.
+func (f *Filter) GetSort() SortField {
+	switch f.Sort {
+	case SortByDate, SortByAmount, SortByCreatedAt:
+		return f.Sort
+	}
+	return SortByMonth
+}
+
+// Page represents a page of transactions search results.
+type Page struct {
+	Transactions TransactionCollection
+	NextCursor   string
+}
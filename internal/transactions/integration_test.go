@@ -4,14 +4,17 @@ package transactions_test
 
 import (
 	"context"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
-	"github.com/d-ashesss/mah-moneh/internal/users"
-	"github.com/gofrs/uuid"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 type TransactionsIntegrationTestSuite struct {
@@ -33,17 +36,21 @@ func (ts *TransactionsIntegrationTestSuite) SetupSuite() {
 
 	ts.db = db.Session(&gorm.Session{NewDB: true})
 	store := transactions.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
-	ts.srv = transactions.NewService(store)
+	payeesStore := payees.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = transactions.NewService(store, datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true})), payees.NewService(payeesStore), audit.Discard)
 
-	err = db.Migrator().AutoMigrate(&transactions.Transaction{})
+	err = db.Migrator().AutoMigrate(&labels.Label{}, &payees.Payee{}, &payees.Alias{}, &transactions.Transaction{}, &transactions.Split{})
 	if err != nil {
 		ts.T().Fatalf("Failed to migrate required tables: %s", err)
 	}
+	if err := transactions.MigrateSearchIndex(db); err != nil {
+		ts.T().Fatalf("Failed to create search index: %s", err)
+	}
 }
 
 func (ts *TransactionsIntegrationTestSuite) TestCreateTransaction() {
-	u := ts.createTestingUser()
-	tx, err := ts.srv.CreateTransaction(context.Background(), u, "2010-10", "usd", 10, "test add income", nil)
+	ws := ts.createTestingWorkspace()
+	tx, err := ts.srv.CreateTransaction(context.Background(), ws, "2010-10", "usd", 10, "test add income", nil)
 	ts.Require().NoError(err, "Failed to create income transaction.")
 	ts.Require().NotNil(tx, "Failed to create income transaction.")
 
@@ -58,12 +65,12 @@ func (ts *TransactionsIntegrationTestSuite) TestCreateTransaction() {
 }
 
 func (ts *TransactionsIntegrationTestSuite) TestCreateTransactionWithCategory() {
-	u := ts.createTestingUser()
-	cat := categories.NewCategory(u, "test-category")
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "test-category")
 	err := ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to save testing category.")
 
-	tx, err := ts.srv.CreateTransaction(context.Background(), u, "2010-10", "usd", 10, "test add income", cat)
+	tx, err := ts.srv.CreateTransaction(context.Background(), ws, "2010-10", "usd", 10, "test add income", cat)
 	ts.Require().NoError(err, "Failed to create income transaction.")
 	ts.Require().NotNil(tx, "Failed to create income transaction.")
 
@@ -78,9 +85,37 @@ func (ts *TransactionsIntegrationTestSuite) TestCreateTransactionWithCategory()
 	ts.Equal(cat.UUID.String(), foundTx.CategoryUUID.String())
 }
 
+func (ts *TransactionsIntegrationTestSuite) TestCreateSplitTransaction() {
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "test-category")
+	err := ts.db.Save(cat).Error
+	ts.Require().NoError(err, "Failed to save testing category.")
+
+	splits := transactions.SplitCollection{
+		transactions.NewSplit(cat, -7, "groceries"),
+		transactions.NewSplit(nil, -3, "household"),
+	}
+	tx, err := ts.srv.CreateSplitTransaction(context.Background(), ws, "2010-10", "usd", -10, "test split tx", splits)
+	ts.Require().NoError(err, "Failed to create split transaction.")
+
+	foundTx, err := ts.srv.GetTransaction(context.Background(), tx.UUID)
+	ts.Require().NoError(err, "Failed to find created transaction")
+	ts.Require().Len(foundTx.Splits, 2)
+	ts.InDelta(-10, foundTx.Splits.GetTotal(), 0.001)
+	for _, split := range foundTx.Splits {
+		if split.Category != nil {
+			ts.Equal(cat.UUID, split.Category.UUID)
+			ts.InDelta(-7, split.Amount, 0.001)
+			ts.Equal("groceries", split.Note)
+		} else {
+			ts.InDelta(-3, split.Amount, 0.001)
+		}
+	}
+}
+
 func (ts *TransactionsIntegrationTestSuite) TestDeleteTransaction() {
-	u := ts.createTestingUser()
-	tx := transactions.NewTransaction(u, "2010-10", "usd", 10, "test delete tx", nil)
+	ws := ts.createTestingWorkspace()
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", 10, "test delete tx", nil)
 	err := ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
@@ -93,8 +128,8 @@ func (ts *TransactionsIntegrationTestSuite) TestDeleteTransaction() {
 }
 
 func (ts *TransactionsIntegrationTestSuite) TestGetTransaction() {
-	u := ts.createTestingUser()
-	tx := transactions.NewTransaction(u, "2010-10", "usd", 10, "test get tx", nil)
+	ws := ts.createTestingWorkspace()
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", 10, "test get tx", nil)
 	err := ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
@@ -108,12 +143,12 @@ func (ts *TransactionsIntegrationTestSuite) TestGetTransaction() {
 }
 
 func (ts *TransactionsIntegrationTestSuite) TestGetTransactionWithCategory() {
-	u := ts.createTestingUser()
-	cat := categories.NewCategory(u, "test-category")
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "test-category")
 	err := ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to save testing category.")
 
-	tx := transactions.NewTransaction(u, "2010-10", "usd", 10, "test get tx", cat)
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", 10, "test get tx", cat)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
@@ -127,58 +162,244 @@ func (ts *TransactionsIntegrationTestSuite) TestGetTransactionWithCategory() {
 	ts.Equal(cat.UUID.String(), foundTx.CategoryUUID.String())
 }
 
-func (ts *TransactionsIntegrationTestSuite) TestGetUserTransactions() {
-	u1 := ts.createTestingUser()
-	u2 := ts.createTestingUser()
+func (ts *TransactionsIntegrationTestSuite) TestGetWorkspaceTransactions() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
 	var (
 		tx  *transactions.Transaction
 		txs transactions.TransactionCollection
 		err error
 	)
 
-	cat := categories.NewCategory(u1, "test-category-u1")
+	cat := categories.NewCategory(ws1, "test-category-u1")
 	err = ts.db.Save(cat).Error
 	ts.Require().NoError(err, "Failed to save testing category.")
 
-	tx = transactions.NewTransaction(u1, "2010-11", "usd", 10, "test tx", nil)
+	tx = transactions.NewTransaction(ws1, "2010-11", "usd", 10, "test tx", nil)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
-	tx = transactions.NewTransaction(u1, "2010-10", "usd", 10, "test tx", cat)
+	tx = transactions.NewTransaction(ws1, "2010-10", "usd", 10, "test tx", cat)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
-	tx = transactions.NewTransaction(u1, "2010-10", "usd", 10, "test tx", nil)
+	tx = transactions.NewTransaction(ws1, "2010-10", "usd", 10, "test tx", nil)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
-	tx = transactions.NewTransaction(u1, "2010-09", "usd", 10, "test tx", cat)
+	tx = transactions.NewTransaction(ws1, "2010-09", "usd", 10, "test tx", cat)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
-	tx = transactions.NewTransaction(u2, "2010-10", "usd", 10, "test tx", nil)
+	tx = transactions.NewTransaction(ws2, "2010-10", "usd", 10, "test tx", nil)
 	err = ts.db.Save(tx).Error
 	ts.Require().NoError(err, "Failed to save the transaction.")
 
-	txs, err = ts.srv.GetUserTransactions(context.Background(), u1, "2010-10")
+	txs, err = ts.srv.GetWorkspaceTransactions(context.Background(), ws1, "2010-10")
 	ts.Require().NoError(err, "Failed to get user's transactions.")
 	ts.Len(txs, 2)
 	ts.NotEmpty(txs[0].Category)
 	ts.Nil(txs[1].Category)
 
-	txs, err = ts.srv.GetUserTransactions(context.Background(), u1, "2010-09")
+	txs, err = ts.srv.GetWorkspaceTransactions(context.Background(), ws1, "2010-09")
 	ts.Require().NoError(err, "Failed to get user's transactions.")
 	ts.Len(txs, 1)
 	ts.NotEmpty(txs[0].Category)
 
 }
 
-func (ts *TransactionsIntegrationTestSuite) createTestingUser() *users.User {
+func (ts *TransactionsIntegrationTestSuite) TestSearchTransactions() {
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "test-category")
+	err := ts.db.Save(cat).Error
+	ts.Require().NoError(err, "Failed to save testing category.")
+
+	for _, tx := range []*transactions.Transaction{
+		transactions.NewTransaction(ws, "2010-09", "usd", -10, "weekly groceries", cat),
+		transactions.NewTransaction(ws, "2010-10", "usd", -20, "monthly rent", nil),
+		transactions.NewTransaction(ws, "2010-10", "eur", -30, "groceries abroad", cat),
+		transactions.NewTransaction(ws, "2010-11", "usd", 100, "salary", nil),
+	} {
+		err = ts.db.Save(tx).Error
+		ts.Require().NoError(err, "Failed to save the transaction.")
+	}
+	ctx := context.Background()
+	minAmount := -25.0
+
+	tests := []struct {
+		name   string
+		filter *transactions.Filter
+		count  int
+	}{
+		{name: "all", filter: &transactions.Filter{}, count: 4},
+		{name: "month range", filter: &transactions.Filter{FromMonth: "2010-10", ToMonth: "2010-10"}, count: 2},
+		{name: "category", filter: &transactions.Filter{CategoryUUID: &cat.UUID}, count: 2},
+		{name: "uncategorized", filter: &transactions.Filter{Uncategorized: true}, count: 2},
+		{name: "currency", filter: &transactions.Filter{Currency: "usd"}, count: 3},
+		{name: "amount", filter: &transactions.Filter{MinAmount: &minAmount}, count: 3},
+		{name: "query", filter: &transactions.Filter{Query: "groceries"}, count: 2},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			page, err := ts.srv.SearchTransactions(ctx, ws, tt.filter)
+			ts.Require().NoError(err, "Failed to search transactions.")
+			ts.Len(page.Transactions, tt.count)
+			ts.Empty(page.NextCursor)
+		})
+	}
+
+	ts.Run("pagination", func() {
+		f := &transactions.Filter{Sort: transactions.SortByAmount, Descending: true, Limit: 3}
+		page, err := ts.srv.SearchTransactions(ctx, ws, f)
+		ts.Require().NoError(err, "Failed to search transactions.")
+		ts.Require().Len(page.Transactions, 3)
+		ts.InDelta(100, page.Transactions[0].Amount, 0.001)
+		ts.Require().NotEmpty(page.NextCursor)
+
+		f.Cursor = page.NextCursor
+		page, err = ts.srv.SearchTransactions(ctx, ws, f)
+		ts.Require().NoError(err, "Failed to search transactions.")
+		ts.Require().Len(page.Transactions, 1)
+		ts.InDelta(-30, page.Transactions[0].Amount, 0.001)
+		ts.Empty(page.NextCursor)
+	})
+}
+
+func (ts *TransactionsIntegrationTestSuite) TestSearchTransactionsByDate() {
+	ws := ts.createTestingWorkspace()
+	dated := func(day int) *transactions.Transaction {
+		tx := transactions.NewTransaction(ws, "", "usd", -10, "dated tx", nil)
+		tx.SetDate(time.Date(2010, time.October, day, 0, 0, 0, 0, time.UTC))
+		return tx
+	}
+	for _, tx := range []*transactions.Transaction{
+		dated(20),
+		dated(5),
+		transactions.NewTransaction(ws, "2010-10", "usd", -10, "undated tx", nil),
+		dated(12),
+	} {
+		err := ts.db.Save(tx).Error
+		ts.Require().NoError(err, "Failed to save the transaction.")
+	}
+	ctx := context.Background()
+
+	from := time.Date(2010, time.October, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2010, time.October, 12, 0, 0, 0, 0, time.UTC)
+	page, err := ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{FromDate: &from, ToDate: &to})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Len(page.Transactions, 2)
+
+	page, err = ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{Sort: transactions.SortByDate, Limit: 2})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Require().Len(page.Transactions, 2)
+	ts.Nil(page.Transactions[0].Date)
+	ts.Equal(5, page.Transactions[1].Date.Day())
+
+	page, err = ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{Sort: transactions.SortByDate, Limit: 2, Cursor: page.NextCursor})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Require().Len(page.Transactions, 2)
+	ts.Equal(12, page.Transactions[0].Date.Day())
+	ts.Equal(20, page.Transactions[1].Date.Day())
+
+	txs, err := ts.srv.GetWorkspaceTransactions(ctx, ws, "2010-10")
+	ts.Require().NoError(err, "Failed to get user's transactions.")
+	ts.Len(txs, 4)
+}
+
+func (ts *TransactionsIntegrationTestSuite) TestSetTransactionLabels() {
+	ws := ts.createTestingWorkspace()
+	lblTrip := labels.NewLabel(ws, "trip")
+	lblWork := labels.NewLabel(ws, "work")
+	for _, lbl := range []*labels.Label{lblTrip, lblWork} {
+		err := ts.db.Save(lbl).Error
+		ts.Require().NoError(err, "Failed to save testing label.")
+	}
+	ctx := context.Background()
+	tx := transactions.NewTransaction(ws, "2010-12", "usd", -10, "train tickets", nil)
+	tx.Labels = labels.LabelCollection{lblTrip}
+	err := ts.srv.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save the transaction.")
+	err = ts.srv.SaveTransaction(ctx, transactions.NewTransaction(ws, "2010-12", "usd", -5, "coffee", nil))
+	ts.Require().NoError(err, "Failed to save the transaction.")
+
+	page, err := ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{LabelUUID: &lblTrip.UUID})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Require().Len(page.Transactions, 1)
+	ts.Len(page.Transactions[0].Labels, 1)
+
+	err = ts.srv.SetTransactionLabels(ctx, tx, labels.LabelCollection{lblWork})
+	ts.Require().NoError(err, "Failed to set transaction labels.")
+
+	page, err = ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{LabelUUID: &lblTrip.UUID})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Len(page.Transactions, 0)
+
+	found, err := ts.srv.GetTransaction(ctx, tx.UUID)
+	ts.Require().NoError(err, "Failed to get the transaction.")
+	ts.Require().Len(found.Labels, 1)
+	ts.Equal(lblWork.UUID, found.Labels[0].UUID)
+}
+
+func (ts *TransactionsIntegrationTestSuite) TestResolvePayee() {
+	ws := ts.createTestingWorkspace()
+	amazon := payees.NewPayee(ws, "Amazon", []string{"AMAZON MKTPLACE"})
+	err := ts.db.Save(amazon).Error
+	ts.Require().NoError(err, "Failed to save testing payee.")
+	ctx := context.Background()
+
+	tx, err := ts.srv.CreateTransaction(ctx, ws, "2010-12", "usd", -30, "AMAZON MKTPLACE PMTS", nil)
+	ts.Require().NoError(err, "Failed to create the transaction.")
+	ts.Require().NotNil(tx.Payee)
+	ts.Equal(amazon.UUID, tx.Payee.UUID)
+	_, err = ts.srv.CreateTransaction(ctx, ws, "2010-12", "usd", -5, "coffee", nil)
+	ts.Require().NoError(err, "Failed to create the transaction.")
+
+	page, err := ts.srv.SearchTransactions(ctx, ws, &transactions.Filter{PayeeUUID: &amazon.UUID})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Require().Len(page.Transactions, 1)
+	ts.Require().NotNil(page.Transactions[0].Payee)
+	ts.Equal("Amazon", page.Transactions[0].Payee.Name)
+}
+
+func (ts *TransactionsIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
 	ts.T().Helper()
-	UUID, _ := uuid.NewV4()
-	return &users.User{ID: UUID.String()}
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
 }
 
 func TestTransactionsIntegration(t *testing.T) {
 	suite.Run(t, new(TransactionsIntegrationTestSuite))
 }
+
+func TestGormStore(t *testing.T) {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		t.Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "tx_store_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to the DB: %s", err)
+	}
+	err = db.Migrator().AutoMigrate(&labels.Label{}, &payees.Payee{}, &payees.Alias{}, &transactions.Transaction{}, &transactions.Split{})
+	if err != nil {
+		t.Fatalf("Failed to migrate required tables: %s", err)
+	}
+	if err := transactions.MigrateSearchIndex(db); err != nil {
+		t.Fatalf("Failed to create search index: %s", err)
+	}
+	suite.Run(t, &StoreContractSuite{
+		Store: transactions.NewGormStore(db.Session(&gorm.Session{NewDB: true})),
+		Persist: func(records ...any) error {
+			for _, r := range records {
+				if err := db.Create(r).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
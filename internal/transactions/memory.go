@@ -0,0 +1,381 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStore is in-memory implementation of Store.
+// Related entities are kept as they were when the transaction was saved instead of being loaded anew.
+type memoryStore struct {
+	mu  sync.RWMutex
+	txs []*Transaction
+}
+
+// NewMemoryStore initializes in-memory implementation of Store.
+func NewMemoryStore() Store {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) SaveTransaction(_ context.Context, tx *Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if err := tx.Stamp(now); err != nil {
+		return err
+	}
+	if tx.Category != nil {
+		tx.CategoryUUID = &tx.Category.UUID
+	}
+	if tx.Account != nil {
+		tx.AccountUUID = &tx.Account.UUID
+	}
+	if tx.Payee != nil {
+		tx.PayeeUUID = &tx.Payee.UUID
+	}
+	for _, sp := range tx.Splits {
+		if err := sp.Stamp(now); err != nil {
+			return err
+		}
+		sp.TransactionUUID = tx.UUID
+		if sp.Category != nil {
+			sp.CategoryUUID = &sp.Category.UUID
+		}
+	}
+
+	stored := copyTransaction(tx)
+	for i, t := range s.txs {
+		if t.UUID != tx.UUID {
+			continue
+		}
+		if t.DeletedAt.Valid {
+			return datastore.ErrRecordExists
+		}
+		if err := tx.Advance(&t.Model); err != nil {
+			return err
+		}
+		stored.Version = tx.Version
+		// Like associations saved by gorm, splits and labels missing from the transaction are kept.
+		stored.Splits = mergeSplits(t.Splits, stored.Splits)
+		stored.Labels = mergeLabels(t.Labels, stored.Labels)
+		s.txs[i] = stored
+		return nil
+	}
+	s.txs = append(s.txs, stored)
+	return nil
+}
+
+func (s *memoryStore) DeleteTransaction(_ context.Context, tx *Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.find(tx.UUID)
+	if stored == nil {
+		return nil
+	}
+	if tx.Version != 0 && tx.Version != stored.Version {
+		return datastore.ErrStaleRecord
+	}
+	tx.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	stored.DeletedAt = tx.DeletedAt
+	return nil
+}
+
+func (s *memoryStore) GetTransaction(_ context.Context, UUID uuid.UUID) (*Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.find(UUID)
+	if stored == nil {
+		return nil, datastore.ErrRecordNotFound
+	}
+	return copyTransaction(stored), nil
+}
+
+func (s *memoryStore) GetWorkspaceTransactions(_ context.Context, ws *workspaces.Workspace, month string) (TransactionCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make(TransactionCollection, 0)
+	for _, t := range s.txs {
+		if t.WorkspaceUUID == ws.UUID && t.YearMonth == month && !t.DeletedAt.Valid {
+			txs = append(txs, copyTransaction(t))
+		}
+	}
+	sortByMonthAndDate(txs)
+	return txs, nil
+}
+
+func (s *memoryStore) GetPeriodTransactions(_ context.Context, ws *workspaces.Workspace, from, to string) (TransactionCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make(TransactionCollection, 0)
+	for _, t := range s.txs {
+		if t.WorkspaceUUID == ws.UUID && t.YearMonth >= from && t.YearMonth <= to && !t.DeletedAt.Valid {
+			txs = append(txs, copyTransaction(t))
+		}
+	}
+	sortByMonthAndDate(txs)
+	return txs, nil
+}
+
+// sortByMonthAndDate orders transactions by month, then by date and then by UUID, the same as the pages sorted by date.
+func sortByMonthAndDate(txs TransactionCollection) {
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].YearMonth != txs[j].YearMonth {
+			return txs[i].YearMonth < txs[j].YearMonth
+		}
+		return compareTransactions(SortByDate, txs[i], txs[j]) < 0
+	})
+}
+
+func (s *memoryStore) SearchTransactions(_ context.Context, ws *workspaces.Workspace, f *Filter) (*Page, error) {
+	field := f.GetSort()
+	var cursor *Transaction
+	if f.Cursor != "" {
+		c, err := datastore.DecodeCursor(f.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursorValue(field, c)
+		if err != nil {
+			return nil, err
+		}
+		cursor = cursorTransaction(field, value, c.UUID)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make(TransactionCollection, 0)
+	for _, t := range s.txs {
+		if t.WorkspaceUUID != ws.UUID || t.DeletedAt.Valid || !f.matches(t) {
+			continue
+		}
+		if cursor != nil {
+			cmp := compareTransactions(field, t, cursor)
+			if f.Descending && cmp >= 0 || !f.Descending && cmp <= 0 {
+				continue
+			}
+		}
+		txs = append(txs, t)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		cmp := compareTransactions(field, txs[i], txs[j])
+		if f.Descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	limit := f.GetLimit()
+	page := &Page{Transactions: make(TransactionCollection, 0, limit)}
+	for _, t := range txs {
+		if len(page.Transactions) == limit {
+			last := page.Transactions[limit-1]
+			page.NextCursor = datastore.NewCursor(sortValue(field, last), last.UUID).Encode()
+			break
+		}
+		page.Transactions = append(page.Transactions, copyTransaction(t))
+	}
+	return page, nil
+}
+
+func (s *memoryStore) SetTransactionLabels(_ context.Context, tx *Transaction, lbls labels.LabelCollection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := s.find(tx.UUID)
+	if stored == nil {
+		return datastore.ErrRecordNotFound
+	}
+	if err := tx.Advance(&stored.Model); err != nil {
+		return err
+	}
+	stored.Version = tx.Version
+	stored.Labels = append(labels.LabelCollection{}, lbls...)
+	return nil
+}
+
+// find looks up the stored transaction that is not deleted.
+func (s *memoryStore) find(UUID uuid.UUID) *Transaction {
+	for _, t := range s.txs {
+		if t.UUID == UUID && !t.DeletedAt.Valid {
+			return t
+		}
+	}
+	return nil
+}
+
+// matches checks whether the transaction meets the filter criteria.
+func (f *Filter) matches(tx *Transaction) bool {
+	if f.FromMonth != "" && tx.YearMonth < f.FromMonth {
+		return false
+	}
+	if f.ToMonth != "" && tx.YearMonth > f.ToMonth {
+		return false
+	}
+	if f.FromDate != nil && effectiveDate(tx) < f.FromDate.Format(fmtDate) {
+		return false
+	}
+	if f.ToDate != nil && effectiveDate(tx) > f.ToDate.Format(fmtDate) {
+		return false
+	}
+	if f.Uncategorized && !tx.uncategorized() {
+		return false
+	}
+	if !f.Uncategorized && f.CategoryUUID != nil && !tx.inCategory(*f.CategoryUUID) {
+		return false
+	}
+	if f.Currency != "" && tx.Currency != f.Currency {
+		return false
+	}
+	if f.MinAmount != nil && tx.Amount < *f.MinAmount {
+		return false
+	}
+	if f.MaxAmount != nil && tx.Amount > *f.MaxAmount {
+		return false
+	}
+	desc := strings.ToLower(tx.Description)
+	for _, word := range strings.Fields(strings.ToLower(f.Query)) {
+		if !strings.Contains(desc, word) {
+			return false
+		}
+	}
+	if f.AccountUUID != nil && (tx.AccountUUID == nil || *tx.AccountUUID != *f.AccountUUID) {
+		return false
+	}
+	if f.PayeeUUID != nil && (tx.PayeeUUID == nil || *tx.PayeeUUID != *f.PayeeUUID) {
+		return false
+	}
+	if f.LabelUUID != nil && !tx.hasLabel(*f.LabelUUID) {
+		return false
+	}
+	return true
+}
+
+// uncategorized checks whether the transaction or any of its splits has no category.
+func (tx *Transaction) uncategorized() bool {
+	if len(tx.Splits) == 0 {
+		return tx.CategoryUUID == nil
+	}
+	for _, sp := range tx.Splits {
+		if sp.CategoryUUID == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// inCategory checks whether the transaction or any of its splits is in the category.
+func (tx *Transaction) inCategory(UUID uuid.UUID) bool {
+	if tx.CategoryUUID != nil && *tx.CategoryUUID == UUID {
+		return true
+	}
+	for _, sp := range tx.Splits {
+		if sp.CategoryUUID != nil && *sp.CategoryUUID == UUID {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *Transaction) hasLabel(UUID uuid.UUID) bool {
+	for _, lbl := range tx.Labels {
+		if lbl.UUID == UUID {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveDate provides the date of transaction, falling back to the first day of its month.
+func effectiveDate(tx *Transaction) string {
+	if tx.Date != nil {
+		return tx.Date.Format(fmtDate)
+	}
+	return tx.YearMonth + "-01"
+}
+
+// compareTransactions compares transactions by the sort field and then by UUID.
+func compareTransactions(field SortField, a, b *Transaction) int {
+	cmp := 0
+	switch field {
+	case SortByDate:
+		cmp = strings.Compare(effectiveDate(a), effectiveDate(b))
+	case SortByAmount:
+		if a.Amount < b.Amount {
+			cmp = -1
+		} else if a.Amount > b.Amount {
+			cmp = 1
+		}
+	case SortByCreatedAt:
+		cmp = a.CreatedAt.Compare(b.CreatedAt)
+	default:
+		cmp = strings.Compare(a.YearMonth, b.YearMonth)
+	}
+	if cmp != 0 {
+		return cmp
+	}
+	return bytes.Compare(a.UUID.Bytes(), b.UUID.Bytes())
+}
+
+// cursorTransaction provides a transaction positioned where the cursor points to.
+func cursorTransaction(field SortField, value any, UUID uuid.UUID) *Transaction {
+	tx := &Transaction{Model: datastore.Model{UUID: UUID}}
+	switch field {
+	case SortByDate:
+		d := value.(time.Time)
+		tx.Date = &d
+	case SortByAmount:
+		tx.Amount = value.(float64)
+	case SortByCreatedAt:
+		tx.CreatedAt = value.(time.Time)
+	default:
+		tx.YearMonth = value.(string)
+	}
+	return tx
+}
+
+func copyTransaction(tx *Transaction) *Transaction {
+	t := *tx
+	t.Splits = make(SplitCollection, 0, len(tx.Splits))
+	for _, sp := range tx.Splits {
+		split := *sp
+		t.Splits = append(t.Splits, &split)
+	}
+	t.Labels = append(labels.LabelCollection{}, tx.Labels...)
+	return &t
+}
+
+func mergeSplits(old, splits SplitCollection) SplitCollection {
+	merged := append(SplitCollection{}, splits...)
+	for _, o := range old {
+		found := false
+		for _, sp := range splits {
+			found = found || sp.UUID == o.UUID
+		}
+		if !found {
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
+
+func mergeLabels(old, lbls labels.LabelCollection) labels.LabelCollection {
+	merged := append(labels.LabelCollection{}, lbls...)
+	for _, o := range old {
+		found := false
+		for _, lbl := range lbls {
+			found = found || lbl.UUID == o.UUID
+		}
+		if !found {
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
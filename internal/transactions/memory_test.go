@@ -0,0 +1,11 @@
+package transactions_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	suite.Run(t, &StoreContractSuite{Store: transactions.NewMemoryStore()})
+}
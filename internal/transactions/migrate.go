@@ -0,0 +1,24 @@
+package transactions
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateSearchIndex creates the full-text search index of transaction descriptions.
+// Only PostgreSQL supports it, other engines search without an index.
+func MigrateSearchIndex(db *gorm.DB) error {
+	if datastore.Driver(db) != datastore.DriverPostgres {
+		return nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&Transaction{}); err != nil {
+		return err
+	}
+	return db.Exec(
+		"CREATE INDEX IF NOT EXISTS ? ON ? USING gin (to_tsvector('simple', description))",
+		clause.Column{Name: db.NamingStrategy.IndexName(stmt.Table, "description")},
+		clause.Table{Name: stmt.Table},
+	).Error
+}
@@ -2,36 +2,131 @@ package transactions
 
 import (
 	"context"
+	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 )
 
+type PayeesService interface {
+	ResolvePayee(ctx context.Context, ws *workspaces.Workspace, desc string) (*payees.Payee, error)
+}
+
 type Service struct {
-	db Store
+	db     Store
+	txm    datastore.TxManager
+	payees PayeesService
+	audit  audit.Recorder
 }
 
-func NewService(db Store) *Service {
-	return &Service{db: db}
+func NewService(db Store, txm datastore.TxManager, payeeSrv PayeesService, rec audit.Recorder) *Service {
+	return &Service{db: db, txm: txm, payees: payeeSrv, audit: rec}
 }
 
-func (s *Service) CreateTransaction(ctx context.Context, u *users.User, month string, currency accounts.Currency, amt float64, desc string, cat *categories.Category) (*Transaction, error) {
-	tx := NewTransaction(u, month, currency, amt, desc, cat)
-	if err := s.db.SaveTransaction(ctx, tx); err != nil {
+func (s *Service) CreateTransaction(ctx context.Context, ws *workspaces.Workspace, month string, currency accounts.Currency, amt float64, desc string, cat *categories.Category) (*Transaction, error) {
+	tx := NewTransaction(ws, month, currency, amt, desc, cat)
+	if err := s.SaveTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SaveTransaction validates and saves a prepared transaction.
+// New transactions without a payee get one resolved from their description,
+// both are saved atomically along with the audit log record.
+func (s *Service) SaveTransaction(ctx context.Context, tx *Transaction) error {
+	if err := tx.ValidateSplits(); err != nil {
+		return err
+	}
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		if tx.UUID.IsNil() {
+			if err := s.resolvePayee(ctx, tx); err != nil {
+				return err
+			}
+			if err := s.db.SaveTransaction(ctx, tx); err != nil {
+				return err
+			}
+			return s.audit.Record(ctx, audit.Created(audit.EntityTransaction, tx.UUID.String(), &tx.WorkspaceUUID, tx))
+		}
+		before, err := s.db.GetTransaction(ctx, tx.UUID)
+		if err != nil {
+			return err
+		}
+		if err := s.db.SaveTransaction(ctx, tx); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Updated(audit.EntityTransaction, tx.UUID.String(), &tx.WorkspaceUUID, before, tx))
+	})
+}
+
+func (s *Service) resolvePayee(ctx context.Context, tx *Transaction) error {
+	if tx.Payee != nil || tx.PayeeUUID != nil || tx.Description == "" {
+		return nil
+	}
+	p, err := s.payees.ResolvePayee(ctx, workspaces.Ref(tx.WorkspaceUUID), tx.Description)
+	if err != nil {
+		return fmt.Errorf("failed to resolve payee: %w", err)
+	}
+	tx.Payee = p
+	return nil
+}
+
+// CreateSplitTransaction creates a transaction with its amount distributed across several categories.
+func (s *Service) CreateSplitTransaction(ctx context.Context, ws *workspaces.Workspace, month string, currency accounts.Currency, amt float64, desc string, splits SplitCollection) (*Transaction, error) {
+	tx := NewTransaction(ws, month, currency, amt, desc, nil)
+	tx.Splits = splits
+	if err := s.SaveTransaction(ctx, tx); err != nil {
 		return nil, err
 	}
 	return tx, nil
 }
 
 func (s *Service) DeleteTransaction(ctx context.Context, tx *Transaction) error {
-	return s.db.DeleteTransaction(ctx, tx)
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.DeleteTransaction(ctx, tx); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Deleted(audit.EntityTransaction, tx.UUID.String(), &tx.WorkspaceUUID, tx))
+	})
 }
 
 func (s *Service) GetTransaction(ctx context.Context, uuid uuid.UUID) (*Transaction, error) {
 	return s.db.GetTransaction(ctx, uuid)
 }
 
-func (s *Service) GetUserTransactions(ctx context.Context, u *users.User, month string) (TransactionCollection, error) {
-	return s.db.GetUserTransactions(ctx, u, month)
+func (s *Service) GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (TransactionCollection, error) {
+	return s.db.GetWorkspaceTransactions(ctx, ws, month)
+}
+
+// GetPeriodTransactions lists workspace transactions recorded during the period between specified months (inclusive).
+func (s *Service) GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from, to string) (TransactionCollection, error) {
+	return s.db.GetPeriodTransactions(ctx, ws, from, to)
+}
+
+// SearchTransactions finds user transactions matching the filter.
+func (s *Service) SearchTransactions(ctx context.Context, ws *workspaces.Workspace, f *Filter) (*Page, error) {
+	return s.db.SearchTransactions(ctx, ws, f)
+}
+
+// SetTransactionLabels replaces labels attached to the transaction.
+func (s *Service) SetTransactionLabels(ctx context.Context, tx *Transaction, lbls labels.LabelCollection) error {
+	before := *tx
+	err := s.txm.InTx(ctx, func(ctx context.Context) error {
+		if err := s.db.SetTransactionLabels(ctx, tx, lbls); err != nil {
+			return err
+		}
+		after := *tx
+		after.Labels = lbls
+		return s.audit.Record(ctx, audit.Updated(audit.EntityTransaction, tx.UUID.String(), &tx.WorkspaceUUID, &before, &after))
+	})
+	if err != nil {
+		return err
+	}
+	tx.Labels = lbls
+	return nil
 }
@@ -2,9 +2,14 @@ package transactions_test
 
 import (
 	"context"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
 	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
 	"github.com/d-ashesss/mah-moneh/internal/transactions"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -13,33 +18,142 @@ import (
 
 type TransactionsServiceTestSuite struct {
 	suite.Suite
-	store *mocks.Store
-	srv   *transactions.Service
+	store  *mocks.Store
+	payees *mocks.PayeesService
+	audit  *auditmocks.Recorder
+	srv    *transactions.Service
 }
 
 func (ts *TransactionsServiceTestSuite) SetupTest() {
 	ts.store = mocks.NewStore(ts.T())
-	ts.srv = transactions.NewService(ts.store)
+	ts.payees = mocks.NewPayeesService(ts.T())
+	ts.audit = auditmocks.NewRecorder(ts.T())
+	ts.srv = transactions.NewService(ts.store, datastore.NewNopTxManager(), ts.payees, ts.audit)
+}
+
+// expectChange expects the change of the transaction to be recorded to the audit log.
+func (ts *TransactionsServiceTestSuite) expectChange(action audit.Action) *mock.Call {
+	return ts.audit.On("Record", mock.Anything, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == action && ch.Entity == audit.EntityTransaction
+	})).Return(nil).Once()
 }
 
 func (ts *TransactionsServiceTestSuite) TestCreateTransaction() {
 	ctx := context.Background()
-	u := &users.User{}
+	ws := &workspaces.Workspace{}
+	ts.payees.On("ResolvePayee", ctx, ws, "test income transaction").Return(nil, nil)
 	ts.store.On("SaveTransaction", ctx, mock.AnythingOfType("*transactions.Transaction")).
 		Return(nil)
-	tx, err := ts.srv.CreateTransaction(ctx, u, "2010-10", "usd", 10, "test income transaction", nil)
+	ts.expectChange(audit.ActionCreate)
+	tx, err := ts.srv.CreateTransaction(ctx, ws, "2010-10", "usd", 10, "test income transaction", nil)
 	ts.Require().NoError(err, "Failed to add income transaction.")
 	ts.Require().NotNil(tx)
 }
 
+func (ts *TransactionsServiceTestSuite) TestSaveTransaction() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{Amount: 10}
+	ts.store.On("SaveTransaction", ctx, tx).Return(nil).Once()
+	ts.expectChange(audit.ActionCreate)
+	err := ts.srv.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save the transaction.")
+}
+
+func (ts *TransactionsServiceTestSuite) TestSaveTransaction_Update() {
+	ctx := context.Background()
+	UUID, _ := uuid.NewV4()
+	before := &transactions.Transaction{Amount: 10}
+	tx := &transactions.Transaction{Amount: 20}
+	tx.UUID = UUID
+	ts.store.On("GetTransaction", ctx, UUID).Return(before, nil).Once()
+	ts.store.On("SaveTransaction", ctx, tx).Return(nil).Once()
+	ts.expectChange(audit.ActionUpdate).Run(func(args mock.Arguments) {
+		ch := args.Get(1).(*audit.Change)
+		ts.Equal(before, ch.Before)
+		ts.Equal(tx, ch.After)
+	})
+	err := ts.srv.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save the transaction.")
+}
+
+func (ts *TransactionsServiceTestSuite) TestSaveTransaction_ResolvePayee() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	amazon := payees.NewPayee(ws, "Amazon", []string{"AMZN"})
+	ts.payees.On("ResolvePayee", ctx, ws, "AMZN Digital").Return(amazon, nil)
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", -10, "AMZN Digital", nil)
+	ts.store.On("SaveTransaction", ctx, tx).Return(nil).Once()
+	ts.expectChange(audit.ActionCreate)
+	err := ts.srv.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save the transaction.")
+	ts.Equal(amazon, tx.Payee)
+}
+
+func (ts *TransactionsServiceTestSuite) TestSaveTransaction_KeepPayee() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", -10, "AMZN Digital", nil)
+	tx.Payee = payees.NewPayee(ws, "Amazon Digital", nil)
+	ts.store.On("SaveTransaction", ctx, tx).Return(nil).Once()
+	ts.expectChange(audit.ActionCreate)
+	err := ts.srv.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save the transaction.")
+	ts.Equal("Amazon Digital", tx.Payee.Name)
+}
+
+func (ts *TransactionsServiceTestSuite) TestCreateSplitTransaction() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	ts.payees.On("ResolvePayee", ctx, ws, "test split transaction").Return(nil, nil)
+	ts.store.On("SaveTransaction", ctx, mock.AnythingOfType("*transactions.Transaction")).
+		Return(nil)
+	ts.expectChange(audit.ActionCreate)
+	splits := transactions.SplitCollection{
+		transactions.NewSplit(nil, -7, "groceries"),
+		transactions.NewSplit(nil, -3, "household"),
+	}
+	tx, err := ts.srv.CreateSplitTransaction(ctx, ws, "2010-10", "usd", -10, "test split transaction", splits)
+	ts.Require().NoError(err, "Failed to add split transaction.")
+	ts.Require().NotNil(tx)
+	ts.Len(tx.Splits, 2)
+}
+
+func (ts *TransactionsServiceTestSuite) TestCreateSplitTransaction_AmountMismatch() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	splits := transactions.SplitCollection{
+		transactions.NewSplit(nil, -7, "groceries"),
+		transactions.NewSplit(nil, -2, "household"),
+	}
+	tx, err := ts.srv.CreateSplitTransaction(ctx, ws, "2010-10", "usd", -10, "test split transaction", splits)
+	ts.Require().ErrorIs(err, transactions.ErrSplitsAmountMismatch)
+	ts.Nil(tx)
+}
+
 func (ts *TransactionsServiceTestSuite) TestDeleteTransaction() {
 	ctx := context.Background()
 	tx := &transactions.Transaction{}
 	ts.store.On("DeleteTransaction", ctx, tx).Return(nil)
+	ts.expectChange(audit.ActionDelete)
 	err := ts.srv.DeleteTransaction(ctx, tx)
 	ts.Require().NoError(err, "Failed to delete the transaction.")
 }
 
+func (ts *TransactionsServiceTestSuite) TestSetTransactionLabels() {
+	ctx := context.Background()
+	tx := &transactions.Transaction{}
+	lbls := labels.LabelCollection{{Name: "vacation"}}
+	ts.store.On("SetTransactionLabels", ctx, tx, lbls).Return(nil).Once()
+	ts.expectChange(audit.ActionUpdate).Run(func(args mock.Arguments) {
+		ch := args.Get(1).(*audit.Change)
+		ts.Empty(ch.Before.(*transactions.Transaction).Labels)
+		ts.Equal(lbls, ch.After.(*transactions.Transaction).Labels)
+	})
+	err := ts.srv.SetTransactionLabels(ctx, tx, lbls)
+	ts.Require().NoError(err, "Failed to set labels of the transaction.")
+	ts.Equal(lbls, tx.Labels)
+}
+
 func (ts *TransactionsServiceTestSuite) TestGetTransaction() {
 	ctx := context.Background()
 	UUID, _ := uuid.NewV4()
@@ -50,15 +164,25 @@ func (ts *TransactionsServiceTestSuite) TestGetTransaction() {
 	ts.Equal(protoTx, tx)
 }
 
-func (ts *TransactionsServiceTestSuite) TestGetUserTransactions() {
+func (ts *TransactionsServiceTestSuite) TestGetWorkspaceTransactions() {
 	ctx := context.Background()
-	u := &users.User{}
-	ts.store.On("GetUserTransactions", ctx, u, "2010-10").Return(transactions.TransactionCollection{}, nil)
-	txs, err := ts.srv.GetUserTransactions(ctx, u, "2010-10")
+	ws := &workspaces.Workspace{}
+	ts.store.On("GetWorkspaceTransactions", ctx, ws, "2010-10").Return(transactions.TransactionCollection{}, nil)
+	txs, err := ts.srv.GetWorkspaceTransactions(ctx, ws, "2010-10")
 	ts.Require().NoError(err, "Failed to get user transactions.")
 	ts.Require().NotNil(txs, "Invalid transactions response.")
 }
 
+func (ts *TransactionsServiceTestSuite) TestSearchTransactions() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	f := &transactions.Filter{FromMonth: "2010-10", Currency: "usd"}
+	ts.store.On("SearchTransactions", ctx, ws, f).Return(&transactions.Page{}, nil)
+	page, err := ts.srv.SearchTransactions(ctx, ws, f)
+	ts.Require().NoError(err, "Failed to search user transactions.")
+	ts.Require().NotNil(page, "Invalid search response.")
+}
+
 func TestTransactionService(t *testing.T) {
 	suite.Run(t, new(TransactionsServiceTestSuite))
 }
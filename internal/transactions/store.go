@@ -3,17 +3,24 @@ package transactions
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
 type Store interface {
 	SaveTransaction(ctx context.Context, tx *Transaction) error
 	DeleteTransaction(ctx context.Context, tx *Transaction) error
 	GetTransaction(ctx context.Context, uuid uuid.UUID) (*Transaction, error)
-	GetUserTransactions(ctx context.Context, u *users.User, month string) (TransactionCollection, error)
+	GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (TransactionCollection, error)
+	GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from, to string) (TransactionCollection, error)
+	SearchTransactions(ctx context.Context, ws *workspaces.Workspace, f *Filter) (*Page, error)
+	SetTransactionLabels(ctx context.Context, tx *Transaction, lbls labels.LabelCollection) error
 }
 
 type gormStore struct {
@@ -25,16 +32,16 @@ func NewGormStore(db *gorm.DB) Store {
 }
 
 func (s *gormStore) SaveTransaction(ctx context.Context, tx *Transaction) error {
-	return s.db.WithContext(ctx).Save(tx).Error
+	return datastore.Save(datastore.Conn(ctx, s.db), tx)
 }
 
 func (s *gormStore) DeleteTransaction(ctx context.Context, tx *Transaction) error {
-	return s.db.WithContext(ctx).Delete(tx).Error
+	return datastore.Delete(datastore.Conn(ctx, s.db), tx)
 }
 
 func (s *gormStore) GetTransaction(ctx context.Context, uuid uuid.UUID) (*Transaction, error) {
 	tx := &Transaction{}
-	err := s.db.WithContext(ctx).Preload("Category").First(tx, "uuid = ?", uuid).Error
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Preload("Splits.Category").Preload("Labels").Preload("Payee").First(tx, "uuid = ?", uuid).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, datastore.ErrRecordNotFound
 	}
@@ -44,11 +51,207 @@ func (s *gormStore) GetTransaction(ctx context.Context, uuid uuid.UUID) (*Transa
 	return tx, nil
 }
 
-func (s *gormStore) GetUserTransactions(ctx context.Context, u *users.User, month string) (TransactionCollection, error) {
+func (s *gormStore) GetWorkspaceTransactions(ctx context.Context, ws *workspaces.Workspace, month string) (TransactionCollection, error) {
 	txs := make(TransactionCollection, 0)
-	err := s.db.WithContext(ctx).Preload("Category").Where("user_id = ?", u.ID).Where("year_month = ?", month).Find(&txs).Error
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Preload("Splits.Category").Preload("Labels").Preload("Payee").Where("workspace_uuid = ?", ws.UUID).Where("year_month = ?", month).Order(s.dateExpr()).Order("uuid").Find(&txs).Error
 	if err != nil {
 		return nil, err
 	}
 	return txs, nil
 }
+
+func (s *gormStore) GetPeriodTransactions(ctx context.Context, ws *workspaces.Workspace, from, to string) (TransactionCollection, error) {
+	txs := make(TransactionCollection, 0)
+	err := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Preload("Splits.Category").Preload("Labels").Preload("Payee").Where("workspace_uuid = ?", ws.UUID).Where("year_month BETWEEN ? AND ?", from, to).Order("year_month").Order(s.dateExpr()).Order("uuid").Find(&txs).Error
+	if err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+func (s *gormStore) SearchTransactions(ctx context.Context, ws *workspaces.Workspace, f *Filter) (*Page, error) {
+	query := datastore.Conn(ctx, s.db).Preload("Category").Preload("Account").Preload("Splits.Category").Preload("Labels").Preload("Payee").Where("workspace_uuid = ?", ws.UUID)
+	if f.FromMonth != "" {
+		query = query.Where("year_month >= ?", f.FromMonth)
+	}
+	if f.ToMonth != "" {
+		query = query.Where("year_month <= ?", f.ToMonth)
+	}
+	if f.FromDate != nil {
+		query = query.Where(s.dateExpr()+" >= ?", s.dateValue(*f.FromDate))
+	}
+	if f.ToDate != nil {
+		query = query.Where(s.dateExpr()+" <= ?", s.dateValue(*f.ToDate))
+	}
+	if f.Uncategorized {
+		query = query.Where(
+			s.db.Where("category_uuid IS NULL AND uuid NOT IN (?)", s.splits()).
+				Or("uuid IN (?)", s.splits().Where("category_uuid IS NULL")),
+		)
+	} else if f.CategoryUUID != nil {
+		query = query.Where(
+			s.db.Where("category_uuid = ?", f.CategoryUUID).
+				Or("uuid IN (?)", s.splits().Where("category_uuid = ?", f.CategoryUUID)),
+		)
+	}
+	if f.Currency != "" {
+		query = query.Where("currency = ?", f.Currency)
+	}
+	if f.MinAmount != nil {
+		query = query.Where("amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		query = query.Where("amount <= ?", *f.MaxAmount)
+	}
+	if f.Query != "" {
+		query = s.searchDescription(query, f.Query)
+	}
+	if f.AccountUUID != nil {
+		query = query.Where("account_uuid = ?", f.AccountUUID)
+	}
+	if f.PayeeUUID != nil {
+		query = query.Where("payee_uuid = ?", f.PayeeUUID)
+	}
+	if f.LabelUUID != nil {
+		query = query.Where("uuid IN (?)", s.transactionLabels().Where("label_uuid = ?", f.LabelUUID))
+	}
+
+	column := s.sortColumn(f.GetSort())
+	direction, comparison := "ASC", ">"
+	if f.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if f.Cursor != "" {
+		cursor, err := datastore.DecodeCursor(f.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursorValue(f.GetSort(), cursor)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := value.(time.Time); ok && f.GetSort() == SortByDate {
+			value = s.dateValue(t)
+		}
+		query = query.Where(fmt.Sprintf("(%s, uuid) %s (?, ?)", column, comparison), value, cursor.UUID)
+	}
+
+	limit := f.GetLimit()
+	txs := make(TransactionCollection, 0)
+	err := query.
+		Order(fmt.Sprintf("%s %s, uuid %s", column, direction, direction)).
+		Limit(limit + 1).
+		Find(&txs).Error
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{Transactions: txs}
+	if len(txs) > limit {
+		page.Transactions = txs[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = datastore.NewCursor(sortValue(f.GetSort(), last), last.UUID).Encode()
+	}
+	return page, nil
+}
+
+func (s *gormStore) SetTransactionLabels(ctx context.Context, tx *Transaction, lbls labels.LabelCollection) error {
+	return datastore.Conn(ctx, s.db).Transaction(func(db *gorm.DB) error {
+		if err := datastore.Touch(db, tx); err != nil {
+			return err
+		}
+		return db.Model(tx).Association("Labels").Replace(lbls)
+	})
+}
+
+func (s *gormStore) transactionLabels() *gorm.DB {
+	return s.db.Table(s.db.NamingStrategy.JoinTableName("transaction_labels")).Select("transaction_uuid")
+}
+
+func (s *gormStore) splits() *gorm.DB {
+	return s.db.Model(&Split{}).Select("transaction_uuid")
+}
+
+const fmtDate = "2006-01-02"
+
+// dateExpr provides the effective date of transaction, falling back to the first day of its month.
+func (s *gormStore) dateExpr() string {
+	if datastore.Driver(s.db) == datastore.DriverPostgres {
+		return "COALESCE(date, CAST(year_month || '-01' AS date))"
+	}
+	return "COALESCE(date(date), year_month || '-01')"
+}
+
+// dateValue provides the date in a form comparable with dateExpr.
+func (s *gormStore) dateValue(t time.Time) any {
+	if datastore.Driver(s.db) == datastore.DriverPostgres {
+		return t
+	}
+	return t.Format(fmtDate)
+}
+
+// searchDescription narrows the query to transactions with description matching all the words of the search query.
+// PostgreSQL uses the full-text search, other engines fall back to case-insensitive substring matching.
+func (s *gormStore) searchDescription(query *gorm.DB, q string) *gorm.DB {
+	if datastore.Driver(s.db) == datastore.DriverPostgres {
+		return query.Where("to_tsvector('simple', description) @@ plainto_tsquery('simple', ?)", q)
+	}
+	for _, word := range strings.Fields(q) {
+		query = query.Where("LOWER(description) LIKE ?", "%"+strings.ToLower(word)+"%")
+	}
+	return query
+}
+
+func (s *gormStore) sortColumn(field SortField) string {
+	switch field {
+	case SortByDate:
+		return s.dateExpr()
+	case SortByAmount:
+		return "amount"
+	case SortByCreatedAt:
+		return "created_at"
+	}
+	return "year_month"
+}
+
+// sortValue extracts the value of sort field from transaction to be stored in the cursor.
+func sortValue(field SortField, tx *Transaction) any {
+	switch field {
+	case SortByDate:
+		if tx.Date != nil {
+			return tx.Date.Format(fmtDate)
+		}
+		return tx.YearMonth + "-01"
+	case SortByAmount:
+		return tx.Amount
+	case SortByCreatedAt:
+		return tx.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return tx.YearMonth
+}
+
+// cursorValue restores the value of sort field stored in the cursor.
+func cursorValue(field SortField, c *datastore.Cursor) (any, error) {
+	switch field {
+	case SortByDate:
+		if v, ok := c.Value.(string); ok {
+			if t, err := time.Parse(fmtDate, v); err == nil {
+				return t, nil
+			}
+		}
+	case SortByAmount:
+		if v, ok := c.Value.(float64); ok {
+			return v, nil
+		}
+	case SortByCreatedAt:
+		if v, ok := c.Value.(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t, nil
+			}
+		}
+	default:
+		if v, ok := c.Value.(string); ok {
+			return v, nil
+		}
+	}
+	return nil, datastore.ErrInvalidCursor
+}
@@ -0,0 +1,364 @@
+package transactions_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"time"
+)
+
+// StoreContractSuite verifies the behavior every Store implementation must have.
+type StoreContractSuite struct {
+	suite.Suite
+	Store transactions.Store
+	// Persist saves the records the transactions refer to, if the store requires them to exist.
+	Persist func(records ...any) error
+}
+
+func (ts *StoreContractSuite) TestSaveTransaction() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	food, home := ts.category(ws, "food"), ts.category(ws, "home")
+	lbl := ts.label(ws, "trip")
+	tx := transactions.NewTransaction(ws, "2010-10", "usd", -30, "supermarket", nil)
+	tx.Splits = transactions.SplitCollection{
+		transactions.NewSplit(food, -20, "groceries"),
+		transactions.NewSplit(home, -10, "detergent"),
+	}
+	tx.Labels = labels.LabelCollection{lbl}
+	err := ts.Store.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to save transaction.")
+	ts.Require().False(tx.UUID.IsNil(), "Transaction UUID was not generated.")
+
+	found, err := ts.Store.GetTransaction(ctx, tx.UUID)
+	ts.Require().NoError(err, "Failed to get transaction.")
+	ts.Equal(ws.UUID, found.WorkspaceUUID)
+	ts.Equal("2010-10", found.YearMonth)
+	ts.Equal(accounts.Currency("usd"), found.Currency)
+	ts.InDelta(-30, found.Amount, 0.001)
+	ts.Nil(found.CategoryUUID)
+	ts.Require().Len(found.Splits, 2)
+	splitCategories := make([]uuid.UUID, 0, 2)
+	for _, sp := range found.Splits {
+		ts.False(sp.UUID.IsNil(), "Split UUID was not generated.")
+		ts.Equal(tx.UUID, sp.TransactionUUID)
+		ts.Require().NotNil(sp.CategoryUUID)
+		splitCategories = append(splitCategories, *sp.CategoryUUID)
+	}
+	ts.ElementsMatch([]uuid.UUID{food.UUID, home.UUID}, splitCategories)
+	ts.Require().Len(found.Labels, 1)
+	ts.Equal(lbl.UUID, found.Labels[0].UUID)
+
+	found.Amount = -35
+	found.Category = food
+	found.Splits = nil
+	err = ts.Store.SaveTransaction(ctx, found)
+	ts.Require().NoError(err, "Failed to update transaction.")
+	found, err = ts.Store.GetTransaction(ctx, tx.UUID)
+	ts.Require().NoError(err, "Failed to get transaction.")
+	ts.InDelta(-35, found.Amount, 0.001)
+	ts.Require().NotNil(found.CategoryUUID)
+	ts.Equal(food.UUID, *found.CategoryUUID)
+}
+
+func (ts *StoreContractSuite) TestSaveTransaction_Stale() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	tx := ts.transaction(transactions.NewTransaction(ws, "2010-10", "usd", -10, "coffee", nil))
+	stale := *tx
+
+	tx.Amount = -12
+	err := ts.Store.SaveTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to update transaction.")
+	ts.EqualValues(2, tx.Version)
+	err = ts.Store.SetTransactionLabels(ctx, tx, labels.LabelCollection{ts.label(ws, "trip")})
+	ts.Require().NoError(err, "Failed to set transaction labels.")
+	ts.EqualValues(3, tx.Version)
+
+	stale.Amount = -15
+	err = ts.Store.SaveTransaction(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	err = ts.Store.SetTransactionLabels(ctx, &stale, nil)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	err = ts.Store.DeleteTransaction(ctx, &stale)
+	ts.ErrorIs(err, datastore.ErrStaleRecord)
+	found, err := ts.Store.GetTransaction(ctx, tx.UUID)
+	ts.Require().NoError(err, "Failed to get transaction.")
+	ts.Equal(-12.0, found.Amount)
+	ts.EqualValues(3, found.Version)
+	ts.Len(found.Labels, 1)
+}
+
+func (ts *StoreContractSuite) TestGetTransaction_NotFound() {
+	_, err := ts.Store.GetTransaction(context.Background(), uuid.Must(uuid.NewV4()))
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *StoreContractSuite) TestDeleteTransaction() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	tx := ts.transaction(transactions.NewTransaction(ws, "2010-10", "usd", -10, "coffee", nil))
+
+	err := ts.Store.DeleteTransaction(ctx, tx)
+	ts.Require().NoError(err, "Failed to delete transaction.")
+	_, err = ts.Store.GetTransaction(ctx, tx.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+	txs, err := ts.Store.GetWorkspaceTransactions(ctx, ws, "2010-10")
+	ts.Require().NoError(err, "Failed to get workspace transactions.")
+	ts.Empty(txs)
+}
+
+func (ts *StoreContractSuite) TestGetWorkspaceTransactions() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	ts.transaction(dated(ws, 20, -10, "dinner"))
+	ts.transaction(dated(ws, 5, -20, "lunch"))
+	ts.transaction(transactions.NewTransaction(ws, "2010-10", "usd", -25, "undated", nil))
+	ts.transaction(transactions.NewTransaction(ws, "2010-11", "usd", -30, "next month", nil))
+	ts.transaction(transactions.NewTransaction(ts.workspace(), "2010-10", "usd", -40, "other workspace", nil))
+
+	txs, err := ts.Store.GetWorkspaceTransactions(ctx, ws, "2010-10")
+	ts.Require().NoError(err, "Failed to get workspace transactions.")
+	ts.Require().Len(txs, 3)
+	ts.Equal("undated", txs[0].Description, "Transactions without a date should be ordered as of the first day of the month.")
+	ts.Equal("lunch", txs[1].Description)
+	ts.Equal("dinner", txs[2].Description)
+
+	txs, err = ts.Store.GetWorkspaceTransactions(ctx, ws, "2010-12")
+	ts.Require().NoError(err, "Failed to get workspace transactions.")
+	ts.NotNil(txs)
+	ts.Empty(txs)
+}
+
+func (ts *StoreContractSuite) TestGetPeriodTransactions() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	ts.transaction(transactions.NewTransaction(ws, "2010-09", "usd", -10, "before", nil))
+	ts.transaction(transactions.NewTransaction(ws, "2010-11", "usd", -20, "last month", nil))
+	ts.transaction(dated(ws, 5, -30, "first month"))
+	ts.transaction(transactions.NewTransaction(ws, "2010-12", "usd", -40, "after", nil))
+	ts.transaction(transactions.NewTransaction(ts.workspace(), "2010-10", "usd", -50, "other workspace", nil))
+
+	txs, err := ts.Store.GetPeriodTransactions(ctx, ws, "2010-10", "2010-11")
+	ts.Require().NoError(err, "Failed to get period transactions.")
+	ts.Require().Len(txs, 2)
+	ts.Equal("first month", txs[0].Description)
+	ts.Equal("last month", txs[1].Description)
+
+	txs, err = ts.Store.GetPeriodTransactions(ctx, ws, "2011-01", "2011-12")
+	ts.Require().NoError(err, "Failed to get period transactions.")
+	ts.NotNil(txs)
+	ts.Empty(txs)
+}
+
+func (ts *StoreContractSuite) TestSearchTransactions() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	food, home := ts.category(ws, "food"), ts.category(ws, "home")
+	lbl := ts.label(ws, "trip")
+	acc := &accounts.Account{Model: ts.model(), WorkspaceUUID: ws.UUID, Name: "card"}
+	payee := &payees.Payee{Model: ts.model(), WorkspaceUUID: ws.UUID, Name: "Grocer"}
+	ts.persist(acc, payee)
+
+	groceries := transactions.NewTransaction(ws, "2010-09", "usd", -10, "Weekly groceries", food)
+	groceries.Payee = payee
+	rent := transactions.NewTransaction(ws, "2010-10", "usd", -20, "monthly rent", nil)
+	rent.Account = acc
+	abroad := dated(ws, 15, -30, "groceries abroad")
+	abroad.Currency = "eur"
+	abroad.Labels = labels.LabelCollection{lbl}
+	supermarket := dated(ws, 25, -40, "supermarket")
+	supermarket.Splits = transactions.SplitCollection{
+		transactions.NewSplit(home, -25, ""),
+		transactions.NewSplit(nil, -15, ""),
+	}
+	salary := transactions.NewTransaction(ws, "2010-11", "usd", 100, "salary", nil)
+	for _, tx := range []*transactions.Transaction{groceries, rent, abroad, supermarket, salary} {
+		ts.transaction(tx)
+	}
+	ts.transaction(transactions.NewTransaction(ts.workspace(), "2010-10", "usd", -50, "other workspace", nil))
+
+	minAmount, maxAmount := -25.0, 0.0
+	from := time.Date(2010, time.October, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2010, time.October, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter *transactions.Filter
+		want   []*transactions.Transaction
+	}{
+		{name: "all", filter: &transactions.Filter{}, want: []*transactions.Transaction{groceries, rent, abroad, supermarket, salary}},
+		{name: "month range", filter: &transactions.Filter{FromMonth: "2010-10", ToMonth: "2010-10"}, want: []*transactions.Transaction{rent, abroad, supermarket}},
+		{name: "date range", filter: &transactions.Filter{FromDate: &from, ToDate: &to}, want: []*transactions.Transaction{abroad}},
+		{name: "category", filter: &transactions.Filter{CategoryUUID: &food.UUID}, want: []*transactions.Transaction{groceries}},
+		{name: "split category", filter: &transactions.Filter{CategoryUUID: &home.UUID}, want: []*transactions.Transaction{supermarket}},
+		{name: "uncategorized", filter: &transactions.Filter{Uncategorized: true}, want: []*transactions.Transaction{rent, abroad, supermarket, salary}},
+		{name: "currency", filter: &transactions.Filter{Currency: "eur"}, want: []*transactions.Transaction{abroad}},
+		{name: "amount range", filter: &transactions.Filter{MinAmount: &minAmount, MaxAmount: &maxAmount}, want: []*transactions.Transaction{groceries, rent}},
+		{name: "query", filter: &transactions.Filter{Query: "groceries"}, want: []*transactions.Transaction{groceries, abroad}},
+		{name: "query words", filter: &transactions.Filter{Query: "Abroad groceries"}, want: []*transactions.Transaction{abroad}},
+		{name: "account", filter: &transactions.Filter{AccountUUID: &acc.UUID}, want: []*transactions.Transaction{rent}},
+		{name: "payee", filter: &transactions.Filter{PayeeUUID: &payee.UUID}, want: []*transactions.Transaction{groceries}},
+		{name: "label", filter: &transactions.Filter{LabelUUID: &lbl.UUID}, want: []*transactions.Transaction{abroad}},
+	}
+	for _, tt := range tests {
+		ts.Run(tt.name, func() {
+			page, err := ts.Store.SearchTransactions(ctx, ws, tt.filter)
+			ts.Require().NoError(err, "Failed to search transactions.")
+			ts.ElementsMatch(uuids(tt.want), uuids(page.Transactions))
+			ts.Empty(page.NextCursor)
+		})
+	}
+}
+
+func (ts *StoreContractSuite) TestSearchTransactions_Pagination() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	for _, tx := range []*transactions.Transaction{
+		transactions.NewTransaction(ws, "2010-09", "usd", -10, "", nil),
+		transactions.NewTransaction(ws, "2010-10", "usd", -20, "", nil),
+		dated(ws, 1, -10, ""),
+		dated(ws, 15, 50, ""),
+		transactions.NewTransaction(ws, "2010-10", "usd", -30, "", nil),
+		transactions.NewTransaction(ws, "2010-11", "usd", 100, "", nil),
+		dated(ws, 15, -5, ""),
+	} {
+		ts.transaction(tx)
+	}
+
+	for _, sort := range []transactions.SortField{transactions.SortByMonth, transactions.SortByDate, transactions.SortByAmount, transactions.SortByCreatedAt} {
+		for _, desc := range []bool{false, true} {
+			ts.Run(string(sort), func() {
+				all, err := ts.Store.SearchTransactions(ctx, ws, &transactions.Filter{Sort: sort, Descending: desc})
+				ts.Require().NoError(err, "Failed to search transactions.")
+				ts.Require().Len(all.Transactions, 7)
+
+				var paged transactions.TransactionCollection
+				f := &transactions.Filter{Sort: sort, Descending: desc, Limit: 3}
+				for i := 0; i < 3; i++ {
+					page, err := ts.Store.SearchTransactions(ctx, ws, f)
+					ts.Require().NoError(err, "Failed to search transactions.")
+					paged = append(paged, page.Transactions...)
+					if page.NextCursor == "" {
+						break
+					}
+					f.Cursor = page.NextCursor
+				}
+				ts.Equal(uuids(all.Transactions), uuids(paged), "Pages differ from the full result.")
+			})
+		}
+	}
+
+	_, err := ts.Store.SearchTransactions(ctx, ws, &transactions.Filter{Cursor: "invalid"})
+	ts.ErrorIs(err, datastore.ErrInvalidCursor)
+}
+
+func (ts *StoreContractSuite) TestSetTransactionLabels() {
+	ctx := context.Background()
+	ws := ts.workspace()
+	trip, work := ts.label(ws, "trip"), ts.label(ws, "work")
+	tx := transactions.NewTransaction(ws, "2010-12", "usd", -10, "train tickets", nil)
+	tx.Labels = labels.LabelCollection{trip}
+	ts.transaction(tx)
+
+	err := ts.Store.SetTransactionLabels(ctx, tx, labels.LabelCollection{work})
+	ts.Require().NoError(err, "Failed to set transaction labels.")
+	found, err := ts.Store.GetTransaction(ctx, tx.UUID)
+	ts.Require().NoError(err, "Failed to get transaction.")
+	ts.Require().Len(found.Labels, 1)
+	ts.Equal(work.UUID, found.Labels[0].UUID)
+
+	page, err := ts.Store.SearchTransactions(ctx, ws, &transactions.Filter{LabelUUID: &trip.UUID})
+	ts.Require().NoError(err, "Failed to search transactions.")
+	ts.Empty(page.Transactions)
+}
+
+func (ts *StoreContractSuite) TestConcurrentWrites() {
+	ctx := context.Background()
+	ws := ts.workspace()
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ts.Store.SaveTransaction(ctx, transactions.NewTransaction(ws, "2010-10", "usd", -1, "concurrent", nil))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		ts.Require().NoError(err, "Failed to write concurrently.")
+	}
+
+	txs, err := ts.Store.GetWorkspaceTransactions(ctx, ws, "2010-10")
+	ts.Require().NoError(err, "Failed to get workspace transactions.")
+	ts.Len(txs, n)
+}
+
+func (ts *StoreContractSuite) model() datastore.Model {
+	return datastore.Model{UUID: uuid.Must(uuid.NewV4())}
+}
+
+func (ts *StoreContractSuite) workspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Model: ts.model(), Name: "test"}
+	ts.persist(ws)
+	return ws
+}
+
+func (ts *StoreContractSuite) category(ws *workspaces.Workspace, name string) *categories.Category {
+	ts.T().Helper()
+	cat := categories.NewCategory(ws, name)
+	cat.Model = ts.model()
+	ts.persist(cat)
+	return cat
+}
+
+func (ts *StoreContractSuite) label(ws *workspaces.Workspace, name string) *labels.Label {
+	ts.T().Helper()
+	lbl := labels.NewLabel(ws, name)
+	lbl.Model = ts.model()
+	ts.persist(lbl)
+	return lbl
+}
+
+func (ts *StoreContractSuite) transaction(tx *transactions.Transaction) *transactions.Transaction {
+	ts.T().Helper()
+	err := ts.Store.SaveTransaction(context.Background(), tx)
+	ts.Require().NoError(err, "Failed to save testing transaction.")
+	return tx
+}
+
+func (ts *StoreContractSuite) persist(records ...any) {
+	ts.T().Helper()
+	if ts.Persist == nil {
+		return
+	}
+	err := ts.Persist(records...)
+	ts.Require().NoError(err, "Failed to persist testing records.")
+}
+
+// dated initializes a transaction on the day of October 2010.
+func dated(ws *workspaces.Workspace, day int, amt float64, desc string) *transactions.Transaction {
+	tx := transactions.NewTransaction(ws, "", "usd", amt, desc, nil)
+	tx.SetDate(time.Date(2010, time.October, day, 0, 0, 0, 0, time.UTC))
+	return tx
+}
+
+func uuids(txs transactions.TransactionCollection) []uuid.UUID {
+	UUIDs := make([]uuid.UUID, 0, len(txs))
+	for _, tx := range txs {
+		UUIDs = append(UUIDs, tx.UUID)
+	}
+	return UUIDs
+}
@@ -1,33 +1,99 @@
 package transactions
 
 import (
+	"errors"
 	"github.com/d-ashesss/mah-moneh/internal/accounts"
 	"github.com/d-ashesss/mah-moneh/internal/categories"
 	"github.com/d-ashesss/mah-moneh/internal/datastore"
-	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
 	"github.com/gofrs/uuid"
+	"math"
+	"time"
+)
+
+var (
+	ErrSplitsAmountMismatch = errors.New("split amounts do not sum up to the transaction amount")
 )
 
 type Transaction struct {
 	datastore.Model
-	User         *users.User `gorm:"embedded;embeddedPrefix:user_;notNull;index"`
-	YearMonth    string
-	Currency     accounts.Currency
-	Amount       float64
-	Description  string
-	CategoryUUID *uuid.UUID           `gorm:"index"`
-	Category     *categories.Category `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	WorkspaceUUID uuid.UUID             `gorm:"type:uuid;index"`
+	Workspace     *workspaces.Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Date          *time.Time            `gorm:"type:date;index"`
+	YearMonth     string                `gorm:"index"`
+	Currency      accounts.Currency     `gorm:"index"`
+	Amount        float64               `gorm:"index"`
+	Description   string
+	CategoryUUID  *uuid.UUID             `gorm:"index"`
+	Category      *categories.Category   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	AccountUUID   *uuid.UUID             `gorm:"index"`
+	Account       *accounts.Account      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	PayeeUUID     *uuid.UUID             `gorm:"index"`
+	Payee         *payees.Payee          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Splits        SplitCollection        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Labels        labels.LabelCollection `gorm:"many2many:transaction_labels;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
-func NewTransaction(u *users.User, month string, currency accounts.Currency, amt float64, desc string, cat *categories.Category) *Transaction {
+func NewTransaction(ws *workspaces.Workspace, month string, currency accounts.Currency, amt float64, desc string, cat *categories.Category) *Transaction {
 	return &Transaction{
-		User:        u,
-		YearMonth:   month,
-		Currency:    currency,
-		Amount:      amt,
-		Description: desc,
-		Category:    cat,
+		WorkspaceUUID: ws.UUID,
+		YearMonth:     month,
+		Currency:      currency,
+		Amount:        amt,
+		Description:   desc,
+		Category:      cat,
 	}
 }
 
+// SetDate sets the date of the transaction along with the month it belongs to.
+func (tx *Transaction) SetDate(d time.Time) {
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	tx.Date = &d
+	tx.YearMonth = d.Format(accounts.FmtYearMonth)
+}
+
+// ValidateSplits checks that split amounts of the transaction add up to its amount.
+func (tx *Transaction) ValidateSplits() error {
+	if len(tx.Splits) == 0 {
+		return nil
+	}
+	if math.Abs(tx.Splits.GetTotal()-tx.Amount) >= 0.0001 {
+		return ErrSplitsAmountMismatch
+	}
+	return nil
+}
+
 type TransactionCollection []*Transaction
+
+// Split represents a part of transaction amount attributed to a specific category.
+type Split struct {
+	datastore.Model
+	TransactionUUID uuid.UUID            `gorm:"type:uuid;notNull;index"`
+	CategoryUUID    *uuid.UUID           `gorm:"index"`
+	Category        *categories.Category `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Amount          float64
+	Note            string
+}
+
+// NewSplit initializes a new transaction split.
+func NewSplit(cat *categories.Category, amt float64, note string) *Split {
+	return &Split{
+		Category: cat,
+		Amount:   amt,
+		Note:     note,
+	}
+}
+
+// SplitCollection represents a collection of transaction splits.
+type SplitCollection []*Split
+
+// GetTotal calculates the sum of all split amounts.
+func (c SplitCollection) GetTotal() float64 {
+	var total float64
+	for _, s := range c {
+		total += s.Amount
+	}
+	return total
+}
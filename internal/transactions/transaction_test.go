@@ -0,0 +1,39 @@
+package transactions_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type TransactionTestSuite struct {
+	suite.Suite
+}
+
+func (ts *TransactionTestSuite) TestSetDate() {
+	tx := transactions.NewTransaction(&workspaces.Workspace{}, "2010-01", "usd", 10, "", nil)
+	tx.SetDate(time.Date(2010, time.October, 15, 13, 45, 0, 0, time.Local))
+	ts.Equal("2010-10", tx.YearMonth)
+	ts.Require().NotNil(tx.Date)
+	ts.Equal("2010-10-15", tx.Date.Format(time.DateOnly))
+}
+
+func (ts *TransactionTestSuite) TestValidateSplits() {
+	tx := transactions.NewTransaction(&workspaces.Workspace{}, "2010-01", "usd", -10, "", nil)
+	ts.NoError(tx.ValidateSplits())
+
+	tx.Splits = transactions.SplitCollection{
+		transactions.NewSplit(nil, -7, ""),
+		transactions.NewSplit(nil, -3, ""),
+	}
+	ts.NoError(tx.ValidateSplits())
+
+	tx.Splits = append(tx.Splits, transactions.NewSplit(nil, -1, ""))
+	ts.ErrorIs(tx.ValidateSplits(), transactions.ErrSplitsAmountMismatch)
+}
+
+func TestTransaction(t *testing.T) {
+	suite.Run(t, new(TransactionTestSuite))
+}
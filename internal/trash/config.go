@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"github.com/joeshaw/envdecode"
+	"time"
+)
+
+type Config struct {
+	// RetentionDays is how many days deleted records are kept before purging, zero disables purging.
+	RetentionDays int           `env:"TRASH_RETENTION_DAYS,default=30"`
+	PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL,default=24h"`
+}
+
+func NewConfig() *Config {
+	cfg := Config{}
+	_ = envdecode.Decode(&cfg)
+	return &cfg
+}
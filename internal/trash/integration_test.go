@@ -0,0 +1,206 @@
+//go:build integration
+
+package trash_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/labels"
+	"github.com/d-ashesss/mah-moneh/internal/payees"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+	"time"
+)
+
+type TrashIntegrationTestSuite struct {
+	suite.Suite
+	db    *gorm.DB
+	blobs blobs.Storage
+	srv   *trash.Service
+}
+
+func (ts *TrashIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "trash_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := trash.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.blobs = blobs.NewLocalStorage(ts.T().TempDir())
+	ts.srv = trash.NewService(store, datastore.NewGormTxManager(db.Session(&gorm.Session{NewDB: true})), audit.NewService(audit.NewGormStore(db.Session(&gorm.Session{NewDB: true}))), ts.blobs)
+
+	err = db.Migrator().AutoMigrate(
+		&accounts.Account{},
+		&accounts.Amount{},
+		&categories.Category{},
+		&labels.Label{},
+		&payees.Payee{},
+		&payees.Alias{},
+		&transactions.Transaction{},
+		&transactions.Split{},
+		&attachments.Attachment{},
+		&recurring.Template{},
+		&recurring.Occurrence{},
+		&audit.Event{},
+	)
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *TrashIntegrationTestSuite) TestGetWorkspaceTrash() {
+	ws1 := ts.createTestingWorkspace()
+	ws2 := ts.createTestingWorkspace()
+	acc := ts.createDeleted(accounts.NewAccount(ws1, "wallet"), time.Now().Add(-2*time.Hour))
+	cat := ts.createDeleted(categories.NewCategory(ws1, "food"), time.Now().Add(-time.Hour))
+	tx := ts.createDeleted(transactions.NewTransaction(ws1, "2010-01", "USD", -100, "groceries", nil), time.Now())
+	ts.createDeleted(categories.NewCategory(ws2, "food"), time.Now())
+	ts.Require().NoError(ts.db.Create(categories.NewCategory(ws1, "home")).Error, "Failed to create testing category.")
+
+	items, err := ts.srv.GetWorkspaceTrash(context.Background(), ws1)
+	ts.Require().NoError(err, "Failed to get workspace trash.")
+	ts.Require().Len(items, 3)
+	ts.Equal(trash.TypeTransaction, items[0].Type)
+	ts.Equal(tx.(*transactions.Transaction).UUID, items[0].UUID)
+	ts.Equal("groceries", items[0].Name)
+	ts.Equal(trash.TypeCategory, items[1].Type)
+	ts.Equal(cat.(*categories.Category).UUID, items[1].UUID)
+	ts.Equal(trash.TypeAccount, items[2].Type)
+	ts.Equal(acc.(*accounts.Account).UUID, items[2].UUID)
+	ts.Equal(ws1.UUID, items[2].WorkspaceUUID)
+}
+
+func (ts *TrashIntegrationTestSuite) TestRestoreItem() {
+	ws := ts.createTestingWorkspace()
+	cat := categories.NewCategory(ws, "food")
+	ts.createDeleted(cat, time.Now())
+
+	item, err := ts.srv.GetItem(context.Background(), trash.TypeCategory, cat.UUID)
+	ts.Require().NoError(err, "Failed to find deleted category.")
+	ts.Equal("food", item.Name)
+
+	err = ts.srv.RestoreItem(context.Background(), item)
+	ts.Require().NoError(err, "Failed to restore the category.")
+
+	found := &categories.Category{}
+	err = ts.db.First(found, "uuid = ?", cat.UUID).Error
+	ts.Require().NoError(err, "Failed to find restored category.")
+	ts.EqualValues(2, found.Version)
+	event := &audit.Event{}
+	err = ts.db.First(event, "entity = ? AND entity_id = ?", audit.EntityCategory, cat.UUID.String()).Error
+	ts.Require().NoError(err, "Restoration is not recorded to the audit log.")
+	ts.Equal(audit.ActionRestore, event.Action)
+	ts.Equal(ws.UUID, *event.WorkspaceUUID)
+	ts.Contains(event.After, `"Name":"food"`)
+	_, err = ts.srv.GetItem(context.Background(), trash.TypeCategory, cat.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *TrashIntegrationTestSuite) TestGetItem_NotDeleted() {
+	ws := ts.createTestingWorkspace()
+	acc := accounts.NewAccount(ws, "wallet")
+	ts.Require().NoError(ts.db.Create(acc).Error, "Failed to create testing account.")
+
+	_, err := ts.srv.GetItem(context.Background(), trash.TypeAccount, acc.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+	_, err = ts.srv.GetItem(context.Background(), trash.TypeCategory, acc.UUID)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *TrashIntegrationTestSuite) TestPurge() {
+	ws := ts.createTestingWorkspace()
+	old := time.Now().AddDate(0, 0, -60)
+	cat := categories.NewCategory(ws, "food")
+	ts.createDeleted(cat, old)
+	recent := categories.NewCategory(ws, "home")
+	ts.createDeleted(recent, time.Now())
+	acc := accounts.NewAccount(ws, "wallet")
+	ts.createDeleted(acc, old)
+	ts.Require().NoError(ts.db.Create(&accounts.Amount{Account: acc, YearMonth: "2010-01", CurrencyCode: "USD", Amount: 100}).Error)
+
+	live := transactions.NewTransaction(ws, "2010-01", "USD", -100, "groceries", cat)
+	live.AccountUUID = &acc.UUID
+	ts.Require().NoError(ts.db.Create(live).Error, "Failed to create testing transaction.")
+	split := transactions.NewTransaction(ws, "2010-01", "USD", -100, "market", nil)
+	split.Splits = transactions.SplitCollection{{CategoryUUID: &cat.UUID, Amount: -100}}
+	ts.Require().NoError(ts.db.Create(split).Error, "Failed to create testing transaction.")
+	deleted := transactions.NewTransaction(ws, "2010-01", "USD", -50, "snacks", recent)
+	deleted.Splits = transactions.SplitCollection{{CategoryUUID: &recent.UUID, Amount: -50}}
+	ts.createDeleted(deleted, old)
+	att := attachments.NewAttachment(deleted, "receipt.png", "image/png", 4)
+	ts.Require().NoError(ts.db.Create(att).Error, "Failed to create testing attachment.")
+	ts.Require().NoError(ts.blobs.Put(context.Background(), att.BlobKey(), strings.NewReader("data")))
+
+	n, err := ts.srv.Purge(context.Background(), time.Now().AddDate(0, 0, -30))
+	ts.Require().NoError(err, "Failed to purge the trash.")
+	ts.EqualValues(3, n)
+
+	for _, model := range []any{&categories.Category{}, &accounts.Account{}, &transactions.Transaction{}} {
+		err = ts.db.Unscoped().First(model, "uuid IN ?", []any{cat.UUID, acc.UUID, deleted.UUID}).Error
+		ts.ErrorIs(err, gorm.ErrRecordNotFound, "Record %T is not purged", model)
+	}
+	err = ts.db.Unscoped().First(&categories.Category{}, "uuid = ?", recent.UUID).Error
+	ts.NoError(err, "Recently deleted category must not be purged.")
+
+	var amounts int64
+	ts.Require().NoError(ts.db.Model(&accounts.Amount{}).Where("account_uuid = ?", acc.UUID).Count(&amounts).Error)
+	ts.Zero(amounts)
+	var splits int64
+	ts.Require().NoError(ts.db.Model(&transactions.Split{}).Where("transaction_uuid = ?", deleted.UUID).Count(&splits).Error)
+	ts.Zero(splits)
+	err = ts.db.First(&attachments.Attachment{}, "uuid = ?", att.UUID).Error
+	ts.ErrorIs(err, gorm.ErrRecordNotFound, "Attachment of purged transaction must be purged.")
+	_, err = ts.blobs.Get(context.Background(), att.BlobKey())
+	ts.ErrorIs(err, blobs.ErrBlobNotFound, "Content of purged attachment must be deleted.")
+
+	found := &transactions.Transaction{}
+	ts.Require().NoError(ts.db.Preload("Splits").First(found, "uuid = ?", live.UUID).Error, "Live transaction must be kept.")
+	ts.Nil(found.CategoryUUID)
+	ts.Nil(found.AccountUUID)
+	found = &transactions.Transaction{}
+	ts.Require().NoError(ts.db.Preload("Splits").First(found, "uuid = ?", split.UUID).Error, "Live transaction must be kept.")
+	ts.Require().Len(found.Splits, 1)
+	ts.Nil(found.Splits[0].CategoryUUID)
+}
+
+func (ts *TrashIntegrationTestSuite) createTestingWorkspace() *workspaces.Workspace {
+	ts.T().Helper()
+	ws := &workspaces.Workspace{Name: "test"}
+	if err := ts.db.Create(ws).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing workspace: %s", err)
+	}
+	return ws
+}
+
+// createDeleted creates a record deleted at the specified time.
+func (ts *TrashIntegrationTestSuite) createDeleted(record any, deletedAt time.Time) any {
+	ts.T().Helper()
+	if err := ts.db.Create(record).Error; err != nil {
+		ts.T().Fatalf("Failed to create testing record: %s", err)
+	}
+	if err := ts.db.Model(record).Update("deleted_at", deletedAt).Error; err != nil {
+		ts.T().Fatalf("Failed to delete testing record: %s", err)
+	}
+	return record
+}
+
+func TestTrashIntegration(t *testing.T) {
+	suite.Run(t, new(TrashIntegrationTestSuite))
+}
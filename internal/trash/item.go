@@ -0,0 +1,38 @@
+package trash
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// Type is a type of the records kept in the trash.
+type Type string
+
+const (
+	TypeAccount     Type = "account"
+	TypeCategory    Type = "category"
+	TypeTransaction Type = "transaction"
+)
+
+// Types lists all the types of the records kept in the trash.
+var Types = []Type{TypeAccount, TypeCategory, TypeTransaction}
+
+// entities maps the types to the kinds of the records in the audit log.
+var entities = map[Type]audit.Entity{
+	TypeAccount:     audit.EntityAccount,
+	TypeCategory:    audit.EntityCategory,
+	TypeTransaction: audit.EntityTransaction,
+}
+
+// Item represents a deleted record kept in the trash.
+type Item struct {
+	Type          Type
+	UUID          uuid.UUID
+	WorkspaceUUID uuid.UUID
+	Name          string
+	DeletedAt     time.Time
+}
+
+// ItemCollection represents a collection of trash items.
+type ItemCollection []*Item
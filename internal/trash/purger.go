@@ -0,0 +1,55 @@
+package trash
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/log"
+	"time"
+)
+
+// Purger periodically purges records which were deleted longer than the retention period ago.
+type Purger struct {
+	srv       *Service
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewPurger initializes a new trash purger.
+func NewPurger(cfg *Config, srv *Service) *Purger {
+	interval := cfg.PurgeInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	retention := time.Duration(cfg.RetentionDays) * 24 * time.Hour
+	return &Purger{srv: srv, retention: retention, interval: interval}
+}
+
+// Run runs the purger until the context is cancelled.
+func (p *Purger) Run(ctx context.Context) error {
+	if p.retention <= 0 {
+		log.Infof("[TRASH] Purging is disabled")
+		return nil
+	}
+	log.Infof("[TRASH] Starting purger with %s interval", p.interval)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			log.Infof("[TRASH] Stopping purger")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+	n, err := p.srv.Purge(ctx, before)
+	if err != nil {
+		log.Errorf("[TRASH] Failed to purge records deleted before %s: %s", before.Format(time.DateTime), err)
+	}
+	if n > 0 {
+		log.Infof("[TRASH] Purged %d records deleted before %s", n, before.Format(time.DateTime))
+	}
+}
@@ -0,0 +1,80 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// Service is a service responsible for managing deleted records.
+type Service struct {
+	db    Store
+	txm   datastore.TxManager
+	audit audit.Recorder
+	blobs blobs.Storage
+}
+
+// NewService initializes a new trash service.
+func NewService(db Store, txm datastore.TxManager, rec audit.Recorder, blobStorage blobs.Storage) *Service {
+	return &Service{db: db, txm: txm, audit: rec, blobs: blobStorage}
+}
+
+// GetWorkspaceTrash lists deleted records of the workspace, most recently deleted first.
+func (s *Service) GetWorkspaceTrash(ctx context.Context, ws *workspaces.Workspace) (ItemCollection, error) {
+	return s.db.GetWorkspaceItems(ctx, ws)
+}
+
+func (s *Service) GetItem(ctx context.Context, t Type, UUID uuid.UUID) (*Item, error) {
+	return s.db.GetItem(ctx, t, UUID)
+}
+
+// RestoreItem brings the deleted record back, recording the restoration to the audit log.
+func (s *Service) RestoreItem(ctx context.Context, item *Item) error {
+	return s.txm.InTx(ctx, func(ctx context.Context) error {
+		record, err := s.db.RestoreItem(ctx, item)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, audit.Restored(entities[item.Type], item.UUID.String(), &item.WorkspaceUUID, record))
+	})
+}
+
+// Purge permanently deletes records deleted before the specified time.
+// Transactions are purged first, so records they refer to are purged in the same run.
+// Content of the attachments of purged transactions is deleted once the records are,
+// failures to delete it are reported along with the number of purged records.
+func (s *Service) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	var atts attachments.AttachmentCollection
+	err := s.txm.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if atts, err = s.db.GetPurgedAttachments(ctx, before); err != nil {
+			return fmt.Errorf("failed to list purged attachments: %w", err)
+		}
+		for _, t := range []Type{TypeTransaction, TypeCategory, TypeAccount} {
+			n, err := s.db.PurgeItems(ctx, t, before)
+			if err != nil {
+				return fmt.Errorf("failed to purge %s records: %w", t, err)
+			}
+			total += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, a := range atts {
+		if err := s.blobs.Delete(ctx, a.BlobKey()); err != nil && !errors.Is(err, blobs.ErrBlobNotFound) {
+			errs = append(errs, fmt.Errorf("failed to delete content of attachment %s: %w", a.UUID, err))
+		}
+	}
+	return total, errors.Join(errs...)
+}
@@ -0,0 +1,121 @@
+package trash_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/audit"
+	"github.com/d-ashesss/mah-moneh/internal/blobs"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	auditmocks "github.com/d-ashesss/mah-moneh/internal/mocks/audit"
+	blobsmocks "github.com/d-ashesss/mah-moneh/internal/mocks/blobs"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/trash"
+	"github.com/d-ashesss/mah-moneh/internal/trash"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	audit *auditmocks.Recorder
+	blobs *blobsmocks.Storage
+	srv   *trash.Service
+}
+
+func (ts *TrashServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.audit = auditmocks.NewRecorder(ts.T())
+	ts.blobs = blobsmocks.NewStorage(ts.T())
+	ts.srv = trash.NewService(ts.store, datastore.NewNopTxManager(), ts.audit, ts.blobs)
+}
+
+func (ts *TrashServiceTestSuite) TestGetWorkspaceTrash() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	items := trash.ItemCollection{{Type: trash.TypeCategory, Name: "food"}}
+	ts.store.On("GetWorkspaceItems", ctx, ws).Return(items, nil).Once()
+
+	got, err := ts.srv.GetWorkspaceTrash(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace trash.")
+	ts.Equal(items, got)
+}
+
+func (ts *TrashServiceTestSuite) TestRestoreItem() {
+	ctx := context.Background()
+	item := &trash.Item{Type: trash.TypeAccount, UUID: uuid.Must(uuid.NewV4()), WorkspaceUUID: uuid.Must(uuid.NewV4())}
+	acc := &accounts.Account{Name: "wallet"}
+	ts.store.On("RestoreItem", ctx, item).Return(acc, nil).Once()
+	ts.audit.On("Record", ctx, mock.MatchedBy(func(ch *audit.Change) bool {
+		return ch.Action == audit.ActionRestore && ch.Entity == audit.EntityAccount && ch.EntityID == item.UUID.String() &&
+			*ch.WorkspaceUUID == item.WorkspaceUUID && ch.Before == nil && ch.After == acc
+	})).Return(nil).Once()
+
+	err := ts.srv.RestoreItem(ctx, item)
+	ts.Require().NoError(err, "Failed to restore the item.")
+}
+
+func (ts *TrashServiceTestSuite) TestRestoreItem_Error() {
+	ctx := context.Background()
+	item := &trash.Item{Type: trash.TypeAccount, UUID: uuid.Must(uuid.NewV4())}
+	ts.store.On("RestoreItem", ctx, item).Return(nil, datastore.ErrRecordNotFound).Once()
+
+	err := ts.srv.RestoreItem(ctx, item)
+	ts.ErrorIs(err, datastore.ErrRecordNotFound)
+}
+
+func (ts *TrashServiceTestSuite) TestPurge() {
+	ctx := context.Background()
+	before := time.Now()
+	a1 := &attachments.Attachment{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	a2 := &attachments.Attachment{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	ts.store.On("GetPurgedAttachments", ctx, before).Return(attachments.AttachmentCollection{a1, a2}, nil).Once()
+	ts.blobs.On("Delete", ctx, a1.BlobKey()).Return(nil).Once()
+	ts.blobs.On("Delete", ctx, a2.BlobKey()).Return(blobs.ErrBlobNotFound).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeTransaction, before).Return(int64(3), nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeCategory, before).Return(int64(2), nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeAccount, before).Return(int64(1), nil).Once()
+
+	n, err := ts.srv.Purge(ctx, before)
+	ts.Require().NoError(err, "Failed to purge the trash.")
+	ts.EqualValues(6, n)
+}
+
+func (ts *TrashServiceTestSuite) TestPurge_Error() {
+	ctx := context.Background()
+	before := time.Now()
+	errFailed := fmt.Errorf("failed")
+	a := &attachments.Attachment{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	ts.store.On("GetPurgedAttachments", ctx, before).Return(attachments.AttachmentCollection{a}, nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeTransaction, before).Return(int64(3), nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeCategory, before).Return(int64(0), errFailed).Once()
+
+	n, err := ts.srv.Purge(ctx, before)
+	ts.ErrorIs(err, errFailed)
+	ts.Zero(n)
+}
+
+func (ts *TrashServiceTestSuite) TestPurge_ContentError() {
+	ctx := context.Background()
+	before := time.Now()
+	errFailed := fmt.Errorf("failed")
+	a := &attachments.Attachment{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	ts.store.On("GetPurgedAttachments", ctx, before).Return(attachments.AttachmentCollection{a}, nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeTransaction, before).Return(int64(1), nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeCategory, before).Return(int64(0), nil).Once()
+	ts.store.On("PurgeItems", ctx, trash.TypeAccount, before).Return(int64(0), nil).Once()
+	ts.blobs.On("Delete", ctx, a.BlobKey()).Return(errFailed).Once()
+
+	n, err := ts.srv.Purge(ctx, before)
+	ts.ErrorIs(err, errFailed)
+	ts.EqualValues(1, n, "Purged records must be reported.")
+}
+
+func TestTrashService(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
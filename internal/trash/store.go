@@ -0,0 +1,207 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/accounts"
+	"github.com/d-ashesss/mah-moneh/internal/attachments"
+	"github.com/d-ashesss/mah-moneh/internal/categories"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/recurring"
+	"github.com/d-ashesss/mah-moneh/internal/transactions"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
+)
+
+// Store is an interface for trash DB API.
+type Store interface {
+	// GetWorkspaceItems retrieves all deleted records of the workspace.
+	GetWorkspaceItems(ctx context.Context, ws *workspaces.Workspace) (ItemCollection, error)
+	// GetItem retrieves deleted record of the type by its UUID.
+	GetItem(ctx context.Context, t Type, UUID uuid.UUID) (*Item, error)
+	// RestoreItem brings deleted record back and provides the restored record.
+	RestoreItem(ctx context.Context, item *Item) (any, error)
+	// PurgeItems permanently deletes records of the type deleted before the specified time.
+	// Live records referring to the purged ones are detached from them.
+	PurgeItems(ctx context.Context, t Type, before time.Time) (int64, error)
+	// GetPurgedAttachments retrieves attachments of the transactions deleted before the specified time,
+	// which are purged along with the transactions.
+	GetPurgedAttachments(ctx context.Context, before time.Time) (attachments.AttachmentCollection, error)
+}
+
+// gormStore is GORM implementation of Store.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore initializes GORM implementation of Store.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// item is a row of the deleted record.
+type item struct {
+	UUID          uuid.UUID
+	WorkspaceUUID uuid.UUID
+	Name          string
+	DeletedAt     time.Time
+}
+
+// model provides the model of the records of the type and the column naming them.
+func model(t Type) (any, string, error) {
+	switch t {
+	case TypeAccount:
+		return &accounts.Account{}, "name", nil
+	case TypeCategory:
+		return &categories.Category{}, "name", nil
+	case TypeTransaction:
+		return &transactions.Transaction{}, "description", nil
+	}
+	return nil, "", datastore.ErrRecordNotFound
+}
+
+// deleted provides the query of deleted records of the type.
+func (s *gormStore) deleted(ctx context.Context, t Type) *gorm.DB {
+	db := datastore.Conn(ctx, s.db).Unscoped()
+	m, _, err := model(t)
+	if err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+	return db.Model(m).Where("deleted_at IS NOT NULL")
+}
+
+// items provides the query of deleted records of the type as trash items.
+func (s *gormStore) items(ctx context.Context, t Type) *gorm.DB {
+	_, name, _ := model(t)
+	return s.deleted(ctx, t).Select("uuid, workspace_uuid, deleted_at, ? AS name", clause.Column{Name: name})
+}
+
+func (s *gormStore) GetWorkspaceItems(ctx context.Context, ws *workspaces.Workspace) (ItemCollection, error) {
+	items := make(ItemCollection, 0)
+	for _, t := range Types {
+		var rows []*item
+		if err := s.items(ctx, t).Where("workspace_uuid = ?", ws.UUID).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			items = append(items, row.toItem(t))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+func (s *gormStore) GetItem(ctx context.Context, t Type, UUID uuid.UUID) (*Item, error) {
+	row := &item{}
+	err := s.items(ctx, t).Where("uuid = ?", UUID).Take(row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.toItem(t), nil
+}
+
+func (s *gormStore) RestoreItem(ctx context.Context, item *Item) (any, error) {
+	res := s.deleted(ctx, item.Type).Where("uuid = ?", item.UUID).Updates(map[string]any{
+		"deleted_at": nil,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, datastore.ErrRecordNotFound
+	}
+	record, _, _ := model(item.Type)
+	if err := datastore.Conn(ctx, s.db).Where("uuid = ?", item.UUID).Take(record).Error; err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *gormStore) PurgeItems(ctx context.Context, t Type, before time.Time) (int64, error) {
+	db := datastore.Conn(ctx, s.db).Unscoped().Session(&gorm.Session{})
+	m, _, err := model(t)
+	if err != nil {
+		return 0, err
+	}
+	purged := s.deleted(ctx, t).Select("uuid").Where("deleted_at < ?", before)
+	var detach []func() error
+	switch t {
+	case TypeAccount:
+		detach = []func() error{
+			func() error {
+				return db.Model(&transactions.Transaction{}).Where("account_uuid IN (?)", purged).Update("account_uuid", nil).Error
+			},
+			func() error {
+				return db.Model(&recurring.Template{}).Where("account_uuid IN (?)", purged).Update("account_uuid", nil).Error
+			},
+			func() error {
+				return db.Where("account_uuid IN (?)", purged).Delete(&accounts.Amount{}).Error
+			},
+		}
+	case TypeCategory:
+		detach = []func() error{
+			func() error {
+				return db.Model(&transactions.Transaction{}).Where("category_uuid IN (?)", purged).Update("category_uuid", nil).Error
+			},
+			func() error {
+				return db.Model(&transactions.Split{}).Where("category_uuid IN (?)", purged).Update("category_uuid", nil).Error
+			},
+			func() error {
+				return db.Model(&recurring.Template{}).Where("category_uuid IN (?)", purged).Update("category_uuid", nil).Error
+			},
+		}
+	case TypeTransaction:
+		detach = []func() error{
+			func() error {
+				return db.Where("transaction_uuid IN (?)", purged).Delete(&transactions.Split{}).Error
+			},
+			func() error {
+				return db.Exec(
+					"DELETE FROM ? WHERE transaction_uuid IN (?)",
+					clause.Table{Name: db.NamingStrategy.JoinTableName("transaction_labels")},
+					purged,
+				).Error
+			},
+			func() error {
+				return db.Where("transaction_uuid IN (?)", purged).Delete(&attachments.Attachment{}).Error
+			},
+		}
+	}
+	for _, fn := range detach {
+		if err := fn(); err != nil {
+			return 0, err
+		}
+	}
+	res := db.Where("deleted_at < ?", before).Delete(m)
+	return res.RowsAffected, res.Error
+}
+
+func (s *gormStore) GetPurgedAttachments(ctx context.Context, before time.Time) (attachments.AttachmentCollection, error) {
+	purged := s.deleted(ctx, TypeTransaction).Select("uuid").Where("deleted_at < ?", before)
+	atts := make(attachments.AttachmentCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Where("transaction_uuid IN (?)", purged).Find(&atts).Error; err != nil {
+		return nil, err
+	}
+	return atts, nil
+}
+
+func (row *item) toItem(t Type) *Item {
+	return &Item{
+		Type:          t,
+		UUID:          row.UUID,
+		WorkspaceUUID: row.WorkspaceUUID,
+		Name:          row.Name,
+		DeletedAt:     row.DeletedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package users
+
+import "context"
+
+type userKey struct{}
+
+// NewContext provides a copy of the context carrying the user acting in it.
+func NewContext(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// FromContext provides the user acting in the context, nil if there is none.
+func FromContext(ctx context.Context) *User {
+	u, _ := ctx.Value(userKey{}).(*User)
+	return u
+}
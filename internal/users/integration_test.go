@@ -0,0 +1,174 @@
+//go:build integration
+
+package users_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"sync"
+	"testing"
+)
+
+type UsersIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *users.Service
+}
+
+func (ts *UsersIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "usr_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := users.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = users.NewService(store)
+
+	err = db.Migrator().AutoMigrate(&users.Profile{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser() {
+	claims := &users.Claims{
+		Subject: uuid.Must(uuid.NewV4()).String(),
+		Issuer:  "https://auth.example.com/",
+		Email:   "user@example.com",
+		Name:    "User",
+	}
+	u, err := ts.srv.EnsureUser(context.Background(), claims)
+	ts.Require().NoError(err, "Failed to register the user.")
+	ts.Equal(claims.UserID(), u.ID)
+
+	p, err := ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	ts.Equal(claims.Issuer, p.Issuer)
+	ts.Equal(claims.Email, p.Email)
+	ts.Equal(users.DefaultSettings(), p.Settings)
+
+	claims.Email = "changed@example.com"
+	u, err = ts.srv.EnsureUser(context.Background(), claims)
+	ts.Require().NoError(err, "Failed to authenticate the user again.")
+	ts.Equal(claims.UserID(), u.ID)
+
+	p, err = ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	ts.Equal("changed@example.com", p.Email)
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser_Concurrent() {
+	claims := &users.Claims{Subject: uuid.Must(uuid.NewV4()).String(), Issuer: "https://auth.example.com/"}
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = ts.srv.EnsureUser(context.Background(), claims)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		ts.NoError(err, "Concurrent first authentications must all succeed.")
+	}
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser_KeepsSettings() {
+	claims := &users.Claims{Subject: uuid.Must(uuid.NewV4()).String(), Issuer: "https://auth.example.com/", Email: "old@example.com"}
+	u, err := ts.srv.EnsureUser(context.Background(), claims)
+	ts.Require().NoError(err, "Failed to register the user.")
+	stale, err := ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	p, err := ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	p.Settings.DefaultCurrency = "EUR"
+	ts.Require().NoError(ts.srv.UpdateProfile(context.Background(), p), "Failed to update user profile.")
+
+	stale.Email = "new@example.com"
+	err = users.NewGormStore(ts.db).UpdateProfileClaims(context.Background(), stale)
+	ts.Require().NoError(err, "Failed to update profile claims.")
+
+	p, err = ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	ts.Equal("new@example.com", p.Email)
+	ts.Equal("EUR", p.Settings.DefaultCurrency, "Updating claims must not overwrite the settings.")
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser_SameSubject() {
+	subject := uuid.Must(uuid.NewV4()).String()
+	u1, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: subject, Issuer: "https://accounts.google.com"})
+	ts.Require().NoError(err, "Failed to register the user.")
+	u2, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: subject, Issuer: "https://auth.example.com/"})
+	ts.Require().NoError(err, "Failed to register the user of another issuer.")
+	ts.NotEqual(u1.ID, u2.ID)
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser_Legacy() {
+	subject := uuid.Must(uuid.NewV4()).String()
+	err := ts.db.Create(&users.Profile{ID: subject, Subject: subject, Issuer: "https://auth.example.com/"}).Error
+	ts.Require().NoError(err, "Failed to create legacy profile.")
+
+	u, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: subject, Issuer: "https://auth.example.com/"})
+	ts.Require().NoError(err, "Failed to authenticate legacy user.")
+	ts.Equal(subject, u.ID, "Users registered before must keep their IDs.")
+}
+
+func (ts *UsersIntegrationTestSuite) TestEnsureUser_LegacyWithoutProfile() {
+	subject := uuid.Must(uuid.NewV4()).String()
+	u, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: subject, Issuer: "https://auth.example.com/", Legacy: true})
+	ts.Require().NoError(err, "Failed to register legacy user.")
+	ts.Equal(subject, u.ID, "Users of the original identity provider must keep their subjects as IDs.")
+
+	p, err := ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+	ts.Equal(subject, p.Subject)
+}
+
+func (ts *UsersIntegrationTestSuite) TestUpdateProfile() {
+	u, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: uuid.Must(uuid.NewV4()).String()})
+	ts.Require().NoError(err, "Failed to register the user.")
+	p, err := ts.srv.GetProfile(context.Background(), u)
+	ts.Require().NoError(err, "Failed to find user profile.")
+
+	p.Settings.DefaultCurrency = "EUR"
+	p.Settings.FiscalYearStart = "04-01"
+	err = ts.srv.UpdateProfile(context.Background(), p)
+	ts.Require().NoError(err, "Failed to update user profile.")
+
+	foundProfile := &users.Profile{}
+	err = ts.db.First(foundProfile, "id = ?", u.ID).Error
+	ts.Require().NoError(err, "Failed to find updated profile.")
+	ts.Equal("EUR", foundProfile.Settings.DefaultCurrency)
+	ts.Equal("04-01", foundProfile.Settings.FiscalYearStart)
+}
+
+func (ts *UsersIntegrationTestSuite) TestGetProfiles() {
+	u1, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: uuid.Must(uuid.NewV4()).String()})
+	ts.Require().NoError(err, "Failed to register the user.")
+	u2, err := ts.srv.EnsureUser(context.Background(), &users.Claims{Subject: uuid.Must(uuid.NewV4()).String()})
+	ts.Require().NoError(err, "Failed to register the user.")
+
+	profiles, err := ts.srv.GetProfiles(context.Background())
+	ts.Require().NoError(err, "Failed to get profiles.")
+	var ids []string
+	for _, p := range profiles {
+		ids = append(ids, p.ID)
+	}
+	ts.Contains(ids, u1.ID)
+	ts.Contains(ids, u2.ID)
+}
+
+func TestUsersIntegration(t *testing.T) {
+	suite.Run(t, new(UsersIntegrationTestSuite))
+}
@@ -2,15 +2,56 @@ package users
 
 import (
 	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
 )
 
 type Service struct {
+	db Store
 }
 
-func NewService() *Service {
-	return &Service{}
+func NewService(db Store) *Service {
+	return &Service{db: db}
 }
 
-func (s *Service) GetUser(_ context.Context, ID string) (*User, error) {
-	return &User{ID: ID}, nil
+// EnsureUser provides the user identified by the issuer and the subject of the claims,
+// registering them on the first authentication.
+// Concurrent first authentications register the user once, the others use the registered profile.
+func (s *Service) EnsureUser(ctx context.Context, claims *Claims) (*User, error) {
+	p, err := s.db.FindProfile(ctx, claims.Issuer, claims.Subject)
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		p = NewProfile(claims)
+		created, err := s.db.CreateProfile(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return p.User(), nil
+		}
+		p, err = s.db.GetProfile(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if p.UpdateClaims(claims) {
+		if err := s.db.UpdateProfileClaims(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+	return p.User(), nil
+}
+
+func (s *Service) GetProfile(ctx context.Context, u *User) (*Profile, error) {
+	return s.db.GetProfile(ctx, u.ID)
+}
+
+// GetProfiles lists profiles of all the registered users in the order of registration.
+func (s *Service) GetProfiles(ctx context.Context) ([]*Profile, error) {
+	return s.db.GetProfiles(ctx)
+}
+
+func (s *Service) UpdateProfile(ctx context.Context, p *Profile) error {
+	return s.db.SaveProfile(ctx, p)
 }
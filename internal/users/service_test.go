@@ -0,0 +1,122 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/users"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type UsersServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *users.Service
+}
+
+func (ts *UsersServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = users.NewService(ts.store)
+}
+
+func (ts *UsersServiceTestSuite) TestEnsureUser_New() {
+	ctx := context.Background()
+	claims := &users.Claims{Subject: "user1", Issuer: "https://auth.example.com/", Email: "user1@example.com", Name: "User"}
+	ts.store.On("FindProfile", ctx, claims.Issuer, "user1").Return(nil, datastore.ErrRecordNotFound)
+	ts.store.On("CreateProfile", ctx, mock.MatchedBy(func(p *users.Profile) bool {
+		return p.ID == claims.UserID() && p.Subject == "user1" && p.Issuer == claims.Issuer && p.Email == claims.Email && p.Name == claims.Name &&
+			p.Settings == users.DefaultSettings()
+	})).Return(true, nil)
+	u, err := ts.srv.EnsureUser(ctx, claims)
+	ts.Require().NoError(err, "Failed to ensure user.")
+	ts.Equal(claims.UserID(), u.ID)
+}
+
+func (ts *UsersServiceTestSuite) TestEnsureUser_RegisteredConcurrently() {
+	ctx := context.Background()
+	claims := &users.Claims{Subject: "user1", Issuer: "https://auth.example.com/", Email: "new@example.com"}
+	p := &users.Profile{ID: claims.UserID(), Email: "old@example.com", Settings: users.Settings{DefaultCurrency: "EUR"}}
+	ts.store.On("FindProfile", ctx, claims.Issuer, "user1").Return(nil, datastore.ErrRecordNotFound)
+	ts.store.On("CreateProfile", ctx, mock.AnythingOfType("*users.Profile")).Return(false, nil)
+	ts.store.On("GetProfile", ctx, claims.UserID()).Return(p, nil)
+	ts.store.On("UpdateProfileClaims", ctx, p).Return(nil)
+	u, err := ts.srv.EnsureUser(ctx, claims)
+	ts.Require().NoError(err, "Failed to ensure user.")
+	ts.Equal(claims.UserID(), u.ID)
+	ts.Equal("new@example.com", p.Email)
+	ts.Equal("EUR", p.Settings.DefaultCurrency, "Settings of the registered profile must be kept.")
+}
+
+func (ts *UsersServiceTestSuite) TestEnsureUser_Existing() {
+	ctx := context.Background()
+	claims := &users.Claims{Subject: "user1", Email: "user1@example.com"}
+	p := &users.Profile{ID: "user1", Email: "user1@example.com", Name: "User"}
+	ts.store.On("FindProfile", ctx, "", "user1").Return(p, nil)
+	u, err := ts.srv.EnsureUser(ctx, claims)
+	ts.Require().NoError(err, "Failed to ensure user.")
+	ts.Equal("user1", u.ID)
+	ts.Equal("User", p.Name)
+}
+
+func (ts *UsersServiceTestSuite) TestEnsureUser_ClaimsChanged() {
+	ctx := context.Background()
+	claims := &users.Claims{Subject: "user1", Email: "new@example.com"}
+	p := &users.Profile{ID: "user1", Email: "old@example.com"}
+	ts.store.On("FindProfile", ctx, "", "user1").Return(p, nil)
+	ts.store.On("UpdateProfileClaims", ctx, p).Return(nil)
+	u, err := ts.srv.EnsureUser(ctx, claims)
+	ts.Require().NoError(err, "Failed to ensure user.")
+	ts.Equal("user1", u.ID)
+	ts.Equal("new@example.com", p.Email)
+}
+
+func (ts *UsersServiceTestSuite) TestEnsureUser_Error() {
+	ctx := context.Background()
+	ts.store.On("FindProfile", ctx, "", "user1").Return(nil, errors.New("test error"))
+	u, err := ts.srv.EnsureUser(ctx, &users.Claims{Subject: "user1"})
+	ts.Error(err)
+	ts.Nil(u)
+}
+
+func (ts *UsersServiceTestSuite) TestUserID() {
+	google := &users.Claims{Subject: "user1", Issuer: "https://accounts.google.com"}
+	internal := &users.Claims{Subject: "user1", Issuer: "https://auth.example.com/"}
+	ts.Equal(google.UserID(), (&users.Claims{Subject: "user1", Issuer: "https://accounts.google.com"}).UserID())
+	ts.NotEqual(google.UserID(), internal.UserID(), "Same subjects of different issuers must not collide.")
+
+	legacy := &users.Claims{Subject: "user1", Issuer: "https://auth.example.com/", Legacy: true}
+	ts.Equal("user1", legacy.UserID(), "Users of the original identity provider must keep their subjects as IDs.")
+}
+
+func (ts *UsersServiceTestSuite) TestGetProfile() {
+	ctx := context.Background()
+	protoProfile := &users.Profile{ID: "user1"}
+	ts.store.On("GetProfile", ctx, "user1").Return(protoProfile, nil)
+	p, err := ts.srv.GetProfile(ctx, &users.User{ID: "user1"})
+	ts.Require().NoError(err, "Failed to get profile.")
+	ts.Equal(protoProfile, p)
+}
+
+func (ts *UsersServiceTestSuite) TestUpdateProfile() {
+	ctx := context.Background()
+	p := &users.Profile{ID: "user1"}
+	ts.store.On("SaveProfile", ctx, p).Return(nil)
+	err := ts.srv.UpdateProfile(ctx, p)
+	ts.Require().NoError(err, "Failed to update profile.")
+}
+
+func (ts *UsersServiceTestSuite) TestGetProfiles() {
+	ctx := context.Background()
+	protoProfiles := []*users.Profile{{ID: "user1"}, {ID: "user2"}}
+	ts.store.On("GetProfiles", ctx).Return(protoProfiles, nil)
+	profiles, err := ts.srv.GetProfiles(ctx)
+	ts.Require().NoError(err, "Failed to get profiles.")
+	ts.Equal(protoProfiles, profiles)
+}
+
+func TestUsersService(t *testing.T) {
+	suite.Run(t, new(UsersServiceTestSuite))
+}
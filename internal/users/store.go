@@ -0,0 +1,74 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Store interface {
+	CreateProfile(ctx context.Context, p *Profile) (bool, error)
+	SaveProfile(ctx context.Context, p *Profile) error
+	UpdateProfileClaims(ctx context.Context, p *Profile) error
+	GetProfile(ctx context.Context, ID string) (*Profile, error)
+	FindProfile(ctx context.Context, issuer, subject string) (*Profile, error)
+	GetProfiles(ctx context.Context) ([]*Profile, error)
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// CreateProfile inserts the profile unless the profile with the same ID exists, reporting whether it was inserted.
+func (s *gormStore) CreateProfile(ctx context.Context, p *Profile) (bool, error) {
+	res := datastore.Conn(ctx, s.db).Clauses(clause.OnConflict{DoNothing: true}).Create(p)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (s *gormStore) SaveProfile(ctx context.Context, p *Profile) error {
+	return datastore.Conn(ctx, s.db).Save(p).Error
+}
+
+// UpdateProfileClaims updates only the columns of the profile provided by the identity claims,
+// so that the settings changed meanwhile are kept.
+func (s *gormStore) UpdateProfileClaims(ctx context.Context, p *Profile) error {
+	return datastore.Conn(ctx, s.db).Model(p).Select("email", "name", "updated_at").Updates(p).Error
+}
+
+func (s *gormStore) GetProfile(ctx context.Context, ID string) (*Profile, error) {
+	return s.getProfile(ctx, "id = ?", ID)
+}
+
+func (s *gormStore) FindProfile(ctx context.Context, issuer, subject string) (*Profile, error) {
+	return s.getProfile(ctx, "issuer = ? AND subject = ?", issuer, subject)
+}
+
+func (s *gormStore) GetProfiles(ctx context.Context) ([]*Profile, error) {
+	profiles := make([]*Profile, 0)
+	err := datastore.Conn(ctx, s.db).Order("created_at").Order("id").Find(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (s *gormStore) getProfile(ctx context.Context, query string, args ...any) (*Profile, error) {
+	var p Profile
+	err := datastore.Conn(ctx, s.db).Where(query, args...).First(&p).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
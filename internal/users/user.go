@@ -1,6 +1,98 @@
 package users
 
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// identityNamespace is the namespace of user IDs derived from the identity at the identity provider.
+var identityNamespace = uuid.Must(uuid.FromString("5b0b6b0e-8c8e-4a52-9d0c-2f7d6a1e3c44"))
+
 // User represents a user entity.
 type User struct {
 	ID string `gorm:"notNull"`
 }
+
+// Claims represents identity information about the user provided by the identity provider.
+type Claims struct {
+	Subject string
+	Issuer  string
+	Email   string
+	Name    string
+	// Legacy marks the identity of the original identity provider,
+	// whose users were identified by their subjects before several providers were supported.
+	Legacy bool
+}
+
+// UserID derives the ID of the user from the issuer and the subject,
+// so that users of different identity providers never share an ID even if their subjects are the same.
+// Users of the original identity provider keep their subjects as IDs, so they keep access to the data they have created.
+func (c *Claims) UserID() string {
+	if c.Legacy {
+		return c.Subject
+	}
+	return uuid.NewV5(identityNamespace, c.Issuer+" "+c.Subject).String()
+}
+
+// Profile represents persisted information about the user along with their settings.
+type Profile struct {
+	ID        string   `gorm:"primaryKey"`
+	Subject   string   `gorm:"notNull;index"`
+	Issuer    string   `gorm:"notNull"`
+	Email     string   `gorm:"notNull"`
+	Name      string   `gorm:"notNull"`
+	Settings  Settings `gorm:"embedded;embeddedPrefix:settings_"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewProfile initializes a new user profile from identity claims with default settings.
+func NewProfile(claims *Claims) *Profile {
+	return &Profile{
+		ID:       claims.UserID(),
+		Subject:  claims.Subject,
+		Issuer:   claims.Issuer,
+		Email:    claims.Email,
+		Name:     claims.Name,
+		Settings: DefaultSettings(),
+	}
+}
+
+// User provides the user entity the profile belongs to.
+func (p *Profile) User() *User {
+	return &User{ID: p.ID}
+}
+
+// UpdateClaims refreshes the profile with the identity claims, reporting whether anything has changed.
+// Empty claims do not overwrite known values.
+func (p *Profile) UpdateClaims(claims *Claims) bool {
+	changed := false
+	if claims.Email != "" && claims.Email != p.Email {
+		p.Email = claims.Email
+		changed = true
+	}
+	if claims.Name != "" && claims.Name != p.Name {
+		p.Name = claims.Name
+		changed = true
+	}
+	return changed
+}
+
+// Settings represents user preferences.
+type Settings struct {
+	// DefaultCurrency is the currency preselected for new entries.
+	DefaultCurrency string `gorm:"notNull"`
+	// Locale is a BCP 47 language tag used to format numbers and dates.
+	Locale string `gorm:"notNull"`
+	// FiscalYearStart is the first day of the fiscal year in MM-DD format.
+	FiscalYearStart string `gorm:"notNull"`
+}
+
+// DefaultSettings provides settings of a newly registered user.
+func DefaultSettings() Settings {
+	return Settings{
+		DefaultCurrency: "USD",
+		Locale:          "en",
+		FiscalYearStart: "01-01",
+	}
+}
@@ -0,0 +1,16 @@
+package workspaces
+
+import (
+	"github.com/joeshaw/envdecode"
+	"time"
+)
+
+type Config struct {
+	InvitationTTL time.Duration `env:"WORKSPACES_INVITATION_TTL,default=168h"`
+}
+
+func NewConfig() *Config {
+	cfg := Config{}
+	_ = envdecode.Decode(&cfg)
+	return &cfg
+}
@@ -0,0 +1,146 @@
+//go:build integration
+
+package workspaces_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type WorkspacesIntegrationTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	srv *workspaces.Service
+}
+
+func (ts *WorkspacesIntegrationTestSuite) SetupSuite() {
+	dbCfg, err := datastore.NewConfig()
+	if err != nil {
+		ts.T().Fatalf("Invalid database config: %s", err)
+	}
+	dbCfg.TablePrefix = "ws_test_"
+	db, err := datastore.Open(dbCfg)
+	if err != nil {
+		ts.T().Fatalf("Failed to connect to the DB: %s", err)
+	}
+
+	ts.db = db.Session(&gorm.Session{NewDB: true})
+	store := workspaces.NewGormStore(db.Session(&gorm.Session{NewDB: true}))
+	ts.srv = workspaces.NewService(&workspaces.Config{InvitationTTL: time.Hour}, store)
+
+	err = db.Migrator().AutoMigrate(&workspaces.Workspace{}, &workspaces.Member{}, &workspaces.Invitation{})
+	if err != nil {
+		ts.T().Fatalf("Failed to migrate required tables: %s", err)
+	}
+}
+
+func (ts *WorkspacesIntegrationTestSuite) newUser() *users.User {
+	return &users.User{ID: uuid.Must(uuid.NewV4()).String()}
+}
+
+func (ts *WorkspacesIntegrationTestSuite) TestGetPersonalWorkspace() {
+	ctx := context.Background()
+	u := ts.newUser()
+	ws, err := ts.srv.GetPersonalWorkspace(ctx, u)
+	ts.Require().NoError(err, "Failed to create personal workspace.")
+	ts.True(ws.IsPersonalOf(u.ID))
+
+	foundWs, err := ts.srv.GetPersonalWorkspace(ctx, u)
+	ts.Require().NoError(err, "Failed to get personal workspace.")
+	ts.Equal(ws.UUID, foundWs.UUID)
+
+	m, err := ts.srv.Authorize(ctx, u, ws.UUID, workspaces.ActionManage)
+	ts.Require().NoError(err, "Owner must be able to manage personal workspace.")
+	ts.Equal(workspaces.RoleOwner, m.Role)
+}
+
+func (ts *WorkspacesIntegrationTestSuite) TestInvitation() {
+	ctx := context.Background()
+	owner := ts.newUser()
+	guest := ts.newUser()
+	ws, err := ts.srv.CreateWorkspace(ctx, owner, "Shared")
+	ts.Require().NoError(err, "Failed to create workspace.")
+
+	_, err = ts.srv.Authorize(ctx, guest, ws.UUID, workspaces.ActionRead)
+	ts.ErrorIs(err, workspaces.ErrNotMember)
+
+	_, token, err := ts.srv.CreateInvitation(ctx, ws, owner, workspaces.RoleViewer)
+	ts.Require().NoError(err, "Failed to create invitation.")
+	_, err = ts.srv.AcceptInvitation(ctx, guest, token)
+	ts.Require().NoError(err, "Failed to accept invitation.")
+
+	_, err = ts.srv.Authorize(ctx, guest, ws.UUID, workspaces.ActionRead)
+	ts.NoError(err, "Viewer must be able to read.")
+	_, err = ts.srv.Authorize(ctx, guest, ws.UUID, workspaces.ActionWrite)
+	ts.ErrorIs(err, workspaces.ErrForbidden)
+
+	_, err = ts.srv.AcceptInvitation(ctx, ts.newUser(), token)
+	ts.ErrorIs(err, workspaces.ErrInvitationNotFound)
+
+	members, err := ts.srv.GetWorkspaceMembers(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace members.")
+	ts.Len(members, 2)
+
+	memberships, err := ts.srv.GetUserMemberships(ctx, guest)
+	ts.Require().NoError(err, "Failed to get user memberships.")
+	ts.Require().Len(memberships, 1)
+	ts.Equal(ws.UUID, memberships[0].WorkspaceUUID)
+}
+
+func (ts *WorkspacesIntegrationTestSuite) TestAcceptInvitation_Concurrent() {
+	ctx := context.Background()
+	owner := ts.newUser()
+	ws, err := ts.srv.CreateWorkspace(ctx, owner, "Shared")
+	ts.Require().NoError(err, "Failed to create workspace.")
+	_, token, err := ts.srv.CreateInvitation(ctx, ws, owner, workspaces.RoleEditor)
+	ts.Require().NoError(err, "Failed to create invitation.")
+
+	store := workspaces.NewGormStore(ts.db)
+	first, err := store.GetInvitationByToken(ctx, workspaces.HashToken(token))
+	ts.Require().NoError(err, "Failed to get invitation.")
+	second, err := store.GetInvitationByToken(ctx, workspaces.HashToken(token))
+	ts.Require().NoError(err, "Failed to get invitation.")
+
+	now := time.Now()
+	guest1, guest2 := ts.newUser(), ts.newUser()
+	first.Accept(guest1, now)
+	m1 := workspaces.NewMember(guest1, first.Role)
+	m1.WorkspaceUUID = ws.UUID
+	ts.Require().NoError(store.AcceptInvitation(ctx, first, m1), "Failed to accept invitation.")
+
+	second.Accept(guest2, now)
+	m2 := workspaces.NewMember(guest2, second.Role)
+	m2.WorkspaceUUID = ws.UUID
+	err = store.AcceptInvitation(ctx, second, m2)
+	ts.ErrorIs(err, workspaces.ErrInvitationNotFound)
+
+	members, err := ts.srv.GetWorkspaceMembers(ctx, ws)
+	ts.Require().NoError(err, "Failed to get workspace members.")
+	ts.Len(members, 2)
+}
+
+func (ts *WorkspacesIntegrationTestSuite) TestRemoveMember() {
+	ctx := context.Background()
+	owner := ts.newUser()
+	ws, err := ts.srv.CreateWorkspace(ctx, owner, "Shared")
+	ts.Require().NoError(err, "Failed to create workspace.")
+
+	m, err := ts.srv.GetMember(ctx, ws.UUID, owner)
+	ts.Require().NoError(err, "Failed to get workspace owner.")
+	err = ts.srv.RemoveMember(ctx, ws, m)
+	ts.ErrorIs(err, workspaces.ErrLastOwner)
+
+	_, err = ts.srv.GetMember(ctx, ws.UUID, owner)
+	ts.NoError(err, "Owner must not be removed.")
+}
+
+func TestWorkspacesIntegration(t *testing.T) {
+	suite.Run(t, new(WorkspacesIntegrationTestSuite))
+}
@@ -0,0 +1,180 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// Service is a service responsible for managing workspaces and access to them.
+type Service struct {
+	cfg *Config
+	db  Store
+}
+
+// NewService initializes a new workspaces service.
+func NewService(cfg *Config, db Store) *Service {
+	return &Service{cfg: cfg, db: db}
+}
+
+// CreateWorkspace creates a new workspace owned by the user.
+func (s *Service) CreateWorkspace(ctx context.Context, u *users.User, name string) (*Workspace, error) {
+	ws := NewWorkspace(u, name)
+	if err := s.db.SaveWorkspace(ctx, ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (s *Service) UpdateWorkspace(ctx context.Context, ws *Workspace) error {
+	return s.db.SaveWorkspace(ctx, ws)
+}
+
+func (s *Service) GetWorkspace(ctx context.Context, UUID uuid.UUID) (*Workspace, error) {
+	return s.db.GetWorkspace(ctx, UUID)
+}
+
+// GetPersonalWorkspace provides the personal workspace of the user, creating it on the first use.
+func (s *Service) GetPersonalWorkspace(ctx context.Context, u *users.User) (*Workspace, error) {
+	ws, err := s.db.GetPersonalWorkspace(ctx, u)
+	if !errors.Is(err, datastore.ErrRecordNotFound) {
+		return ws, err
+	}
+	ws = NewPersonalWorkspace(u)
+	if err := s.db.SaveWorkspace(ctx, ws); err != nil {
+		// The workspace might have been just created by a concurrent request.
+		if ws, err := s.db.GetPersonalWorkspace(ctx, u); err == nil {
+			return ws, nil
+		}
+		return nil, err
+	}
+	return ws, nil
+}
+
+// GetUserMemberships lists workspaces the user has access to along with their roles.
+func (s *Service) GetUserMemberships(ctx context.Context, u *users.User) (MemberCollection, error) {
+	return s.db.GetUserMemberships(ctx, u)
+}
+
+// Authorize checks that the user is allowed to perform the action in the workspace.
+func (s *Service) Authorize(ctx context.Context, u *users.User, wsUUID uuid.UUID, a Action) (*Member, error) {
+	m, err := s.GetMember(ctx, wsUUID, u)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Role.Can(a) {
+		return nil, ErrForbidden
+	}
+	return m, nil
+}
+
+// GetMember provides membership of the user in the workspace.
+func (s *Service) GetMember(ctx context.Context, wsUUID uuid.UUID, u *users.User) (*Member, error) {
+	m, err := s.db.GetMember(ctx, wsUUID, u)
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		return nil, ErrNotMember
+	}
+	return m, err
+}
+
+func (s *Service) GetWorkspaceMembers(ctx context.Context, ws *Workspace) (MemberCollection, error) {
+	return s.db.GetWorkspaceMembers(ctx, ws)
+}
+
+// SetMemberRole changes the role of the member, making sure the workspace keeps an owner.
+func (s *Service) SetMemberRole(ctx context.Context, ws *Workspace, m *Member, role Role) error {
+	if !role.Valid() {
+		return ErrInvalidRole
+	}
+	if m.Role == role {
+		return nil
+	}
+	if err := s.checkOwnerRemoval(ctx, ws, m); err != nil {
+		return err
+	}
+	m.Role = role
+	return s.db.SaveMember(ctx, m)
+}
+
+// RemoveMember revokes access of the member to the workspace, making sure the workspace keeps an owner.
+func (s *Service) RemoveMember(ctx context.Context, ws *Workspace, m *Member) error {
+	if err := s.checkOwnerRemoval(ctx, ws, m); err != nil {
+		return err
+	}
+	return s.db.DeleteMember(ctx, m)
+}
+
+// checkOwnerRemoval checks that the member may stop being an owner of the workspace.
+func (s *Service) checkOwnerRemoval(ctx context.Context, ws *Workspace, m *Member) error {
+	if m.Role != RoleOwner {
+		return nil
+	}
+	if ws.IsPersonalOf(m.UserID) {
+		return ErrPersonalOwner
+	}
+	ms, err := s.db.GetWorkspaceMembers(ctx, ws)
+	if err != nil {
+		return err
+	}
+	if ms.CountOwners() <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// CreateInvitation creates an invitation to the workspace, providing the token to be passed to the invitee.
+func (s *Service) CreateInvitation(ctx context.Context, ws *Workspace, u *users.User, role Role) (*Invitation, string, error) {
+	if !role.Valid() {
+		return nil, "", ErrInvalidRole
+	}
+	inv, token, err := NewInvitation(ws, u, role, s.cfg.InvitationTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.db.SaveInvitation(ctx, inv); err != nil {
+		return nil, "", err
+	}
+	return inv, token, nil
+}
+
+func (s *Service) GetInvitation(ctx context.Context, UUID uuid.UUID) (*Invitation, error) {
+	return s.db.GetInvitation(ctx, UUID)
+}
+
+func (s *Service) GetWorkspaceInvitations(ctx context.Context, ws *Workspace) (InvitationCollection, error) {
+	return s.db.GetWorkspaceInvitations(ctx, ws)
+}
+
+func (s *Service) DeleteInvitation(ctx context.Context, inv *Invitation) error {
+	return s.db.DeleteInvitation(ctx, inv)
+}
+
+// AcceptInvitation makes the user a member of the workspace the invitation was issued for.
+func (s *Service) AcceptInvitation(ctx context.Context, u *users.User, token string) (*Member, error) {
+	inv, err := s.db.GetInvitationByToken(ctx, HashToken(token))
+	if errors.Is(err, datastore.ErrRecordNotFound) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !inv.Pending(now) {
+		return nil, ErrInvitationNotFound
+	}
+	if _, err := s.GetMember(ctx, inv.WorkspaceUUID, u); err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, ErrNotMember) {
+		return nil, err
+	}
+	m := NewMember(u, inv.Role)
+	m.WorkspaceUUID = inv.WorkspaceUUID
+	inv.Accept(u, now)
+	if err := s.db.AcceptInvitation(ctx, inv, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
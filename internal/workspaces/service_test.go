@@ -0,0 +1,175 @@
+package workspaces_test
+
+import (
+	"context"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	mocks "github.com/d-ashesss/mah-moneh/internal/mocks/workspaces"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type WorkspacesServiceTestSuite struct {
+	suite.Suite
+	store *mocks.Store
+	srv   *workspaces.Service
+}
+
+func (ts *WorkspacesServiceTestSuite) SetupTest() {
+	ts.store = mocks.NewStore(ts.T())
+	ts.srv = workspaces.NewService(&workspaces.Config{InvitationTTL: time.Hour}, ts.store)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestCreateWorkspace() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	ts.store.On("SaveWorkspace", ctx, mock.AnythingOfType("*workspaces.Workspace")).Return(nil)
+	ws, err := ts.srv.CreateWorkspace(ctx, u, "Household")
+	ts.Require().NoError(err, "Failed to create workspace.")
+	ts.Equal("Household", ws.Name)
+	ts.Nil(ws.PersonalUserID)
+	ts.Require().Len(ws.Members, 1)
+	ts.Equal(workspaces.RoleOwner, ws.Members[0].Role)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestGetPersonalWorkspace_Existing() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	protoWs := workspaces.NewPersonalWorkspace(u)
+	ts.store.On("GetPersonalWorkspace", ctx, u).Return(protoWs, nil)
+	ws, err := ts.srv.GetPersonalWorkspace(ctx, u)
+	ts.Require().NoError(err, "Failed to get personal workspace.")
+	ts.Equal(protoWs, ws)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestGetPersonalWorkspace_New() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	ts.store.On("GetPersonalWorkspace", ctx, u).Return(nil, datastore.ErrRecordNotFound)
+	ts.store.On("SaveWorkspace", ctx, mock.MatchedBy(func(ws *workspaces.Workspace) bool {
+		return ws.IsPersonalOf("user1")
+	})).Return(nil)
+	ws, err := ts.srv.GetPersonalWorkspace(ctx, u)
+	ts.Require().NoError(err, "Failed to create personal workspace.")
+	ts.True(ws.IsPersonalOf("user1"))
+}
+
+func (ts *WorkspacesServiceTestSuite) TestAuthorize() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	wsUUID := uuid.Must(uuid.NewV4())
+	ts.store.On("GetMember", ctx, wsUUID, u).Return(&workspaces.Member{WorkspaceUUID: wsUUID, UserID: u.ID, Role: workspaces.RoleViewer}, nil)
+
+	m, err := ts.srv.Authorize(ctx, u, wsUUID, workspaces.ActionRead)
+	ts.Require().NoError(err, "Viewer must be able to read.")
+	ts.Equal(workspaces.RoleViewer, m.Role)
+
+	_, err = ts.srv.Authorize(ctx, u, wsUUID, workspaces.ActionWrite)
+	ts.ErrorIs(err, workspaces.ErrForbidden)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestAuthorize_NotMember() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	wsUUID := uuid.Must(uuid.NewV4())
+	ts.store.On("GetMember", ctx, wsUUID, u).Return(nil, datastore.ErrRecordNotFound)
+	_, err := ts.srv.Authorize(ctx, u, wsUUID, workspaces.ActionRead)
+	ts.ErrorIs(err, workspaces.ErrNotMember)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestSetMemberRole() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	m := &workspaces.Member{UserID: "user2", Role: workspaces.RoleViewer}
+	ts.store.On("SaveMember", ctx, m).Return(nil)
+	err := ts.srv.SetMemberRole(ctx, ws, m, workspaces.RoleEditor)
+	ts.Require().NoError(err, "Failed to set member role.")
+	ts.Equal(workspaces.RoleEditor, m.Role)
+
+	err = ts.srv.SetMemberRole(ctx, ws, m, "admin")
+	ts.ErrorIs(err, workspaces.ErrInvalidRole)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestSetMemberRole_LastOwner() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	owner := &workspaces.Member{UserID: "user1", Role: workspaces.RoleOwner}
+	ts.store.On("GetWorkspaceMembers", ctx, ws).Return(workspaces.MemberCollection{
+		owner,
+		{UserID: "user2", Role: workspaces.RoleEditor},
+	}, nil)
+	err := ts.srv.SetMemberRole(ctx, ws, owner, workspaces.RoleEditor)
+	ts.ErrorIs(err, workspaces.ErrLastOwner)
+	ts.Equal(workspaces.RoleOwner, owner.Role)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestRemoveMember_PersonalOwner() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	ws := workspaces.NewPersonalWorkspace(u)
+	err := ts.srv.RemoveMember(ctx, ws, ws.Members[0])
+	ts.ErrorIs(err, workspaces.ErrPersonalOwner)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestRemoveMember() {
+	ctx := context.Background()
+	ws := &workspaces.Workspace{}
+	owner := &workspaces.Member{UserID: "user1", Role: workspaces.RoleOwner}
+	ts.store.On("GetWorkspaceMembers", ctx, ws).Return(workspaces.MemberCollection{
+		owner,
+		{UserID: "user2", Role: workspaces.RoleOwner},
+	}, nil)
+	ts.store.On("DeleteMember", ctx, owner).Return(nil)
+	err := ts.srv.RemoveMember(ctx, ws, owner)
+	ts.Require().NoError(err, "Failed to remove member.")
+}
+
+func (ts *WorkspacesServiceTestSuite) TestAcceptInvitation() {
+	ctx := context.Background()
+	owner := &users.User{ID: "user1"}
+	u := &users.User{ID: "user2"}
+	ws := &workspaces.Workspace{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	ts.store.On("SaveInvitation", ctx, mock.AnythingOfType("*workspaces.Invitation")).Return(nil)
+	inv, token, err := ts.srv.CreateInvitation(ctx, ws, owner, workspaces.RoleEditor)
+	ts.Require().NoError(err, "Failed to create invitation.")
+
+	ts.store.On("GetInvitationByToken", ctx, workspaces.HashToken(token)).Return(inv, nil)
+	ts.store.On("GetMember", ctx, ws.UUID, u).Return(nil, datastore.ErrRecordNotFound)
+	ts.store.On("AcceptInvitation", ctx, inv, mock.AnythingOfType("*workspaces.Member")).Return(nil)
+	m, err := ts.srv.AcceptInvitation(ctx, u, token)
+	ts.Require().NoError(err, "Failed to accept invitation.")
+	ts.Equal(ws.UUID, m.WorkspaceUUID)
+	ts.Equal("user2", m.UserID)
+	ts.Equal(workspaces.RoleEditor, m.Role)
+	ts.NotNil(inv.AcceptedAt)
+
+	_, err = ts.srv.AcceptInvitation(ctx, &users.User{ID: "user3"}, token)
+	ts.ErrorIs(err, workspaces.ErrInvitationNotFound)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestAcceptInvitation_AlreadyMember() {
+	ctx := context.Background()
+	u := &users.User{ID: "user1"}
+	ws := &workspaces.Workspace{Model: datastore.Model{UUID: uuid.Must(uuid.NewV4())}}
+	inv, token, err := workspaces.NewInvitation(ws, u, workspaces.RoleViewer, time.Hour)
+	ts.Require().NoError(err)
+	ts.store.On("GetInvitationByToken", ctx, workspaces.HashToken(token)).Return(inv, nil)
+	ts.store.On("GetMember", ctx, ws.UUID, u).Return(&workspaces.Member{}, nil)
+	_, err = ts.srv.AcceptInvitation(ctx, u, token)
+	ts.ErrorIs(err, workspaces.ErrAlreadyMember)
+}
+
+func (ts *WorkspacesServiceTestSuite) TestAcceptInvitation_UnknownToken() {
+	ctx := context.Background()
+	ts.store.On("GetInvitationByToken", ctx, workspaces.HashToken("forged")).Return(nil, datastore.ErrRecordNotFound)
+	_, err := ts.srv.AcceptInvitation(ctx, &users.User{ID: "user1"}, "forged")
+	ts.ErrorIs(err, workspaces.ErrInvitationNotFound)
+}
+
+func TestWorkspacesService(t *testing.T) {
+	suite.Run(t, new(WorkspacesServiceTestSuite))
+}
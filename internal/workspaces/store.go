@@ -0,0 +1,225 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store is an interface for workspaces DB API.
+type Store interface {
+	// SaveWorkspace saves workspace entity along with its new members into the DB.
+	SaveWorkspace(ctx context.Context, ws *Workspace) error
+	// GetWorkspace retrieves workspace by its UUID.
+	GetWorkspace(ctx context.Context, UUID uuid.UUID) (*Workspace, error)
+	// GetPersonalWorkspace retrieves the personal workspace of the user.
+	GetPersonalWorkspace(ctx context.Context, u *users.User) (*Workspace, error)
+	// SaveMember saves workspace member into the DB.
+	SaveMember(ctx context.Context, m *Member) error
+	// DeleteMember revokes user's access to the workspace.
+	DeleteMember(ctx context.Context, m *Member) error
+	// GetMember retrieves membership of the user in the workspace.
+	GetMember(ctx context.Context, wsUUID uuid.UUID, u *users.User) (*Member, error)
+	// GetWorkspaceMembers retrieves all members of the workspace.
+	GetWorkspaceMembers(ctx context.Context, ws *Workspace) (MemberCollection, error)
+	// GetUserMemberships retrieves memberships of the user along with their workspaces.
+	GetUserMemberships(ctx context.Context, u *users.User) (MemberCollection, error)
+	// SaveInvitation saves invitation entity into the DB.
+	SaveInvitation(ctx context.Context, inv *Invitation) error
+	// DeleteInvitation deletes invitation entity from the DB.
+	DeleteInvitation(ctx context.Context, inv *Invitation) error
+	// GetInvitation retrieves invitation by its UUID.
+	GetInvitation(ctx context.Context, UUID uuid.UUID) (*Invitation, error)
+	// GetInvitationByToken retrieves invitation by the hash of its token.
+	GetInvitationByToken(ctx context.Context, tokenHash string) (*Invitation, error)
+	// GetWorkspaceInvitations retrieves all invitations to the workspace.
+	GetWorkspaceInvitations(ctx context.Context, ws *Workspace) (InvitationCollection, error)
+	// AcceptInvitation saves accepted invitation together with the member it has created.
+	// It fails with ErrInvitationNotFound when the invitation has been accepted since it was read.
+	AcceptInvitation(ctx context.Context, inv *Invitation, m *Member) error
+}
+
+// gormStore is GORM implementation of Store.
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore initializes GORM implementation of Store.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) SaveWorkspace(ctx context.Context, ws *Workspace) error {
+	return datastore.Conn(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		if err := datastore.Save(tx.Omit("Members"), ws); err != nil {
+			return err
+		}
+		if len(ws.Members) == 0 {
+			return nil
+		}
+		for _, m := range ws.Members {
+			m.WorkspaceUUID = ws.UUID
+		}
+		return tx.Omit("Workspace").Clauses(clause.OnConflict{DoNothing: true}).Create(&ws.Members).Error
+	})
+}
+
+func (s *gormStore) GetWorkspace(ctx context.Context, UUID uuid.UUID) (*Workspace, error) {
+	ws := &Workspace{}
+	err := datastore.Conn(ctx, s.db).First(ws, "uuid = ?", UUID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (s *gormStore) GetPersonalWorkspace(ctx context.Context, u *users.User) (*Workspace, error) {
+	ws := &Workspace{}
+	err := datastore.Conn(ctx, s.db).First(ws, "personal_user_id = ?", u.ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// SaveMember updates the role of the member unless the membership has been changed since it was read.
+func (s *gormStore) SaveMember(ctx context.Context, m *Member) error {
+	version := m.Version
+	res := datastore.Conn(ctx, s.db).Model(m).Omit("Workspace").
+		Where("version = ?", version).
+		Updates(map[string]any{"role": m.Role, "version": version + 1})
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = s.staleMember(ctx, m)
+	}
+	if res.Error != nil {
+		m.Version = version
+		return res.Error
+	}
+	m.Version = version + 1
+	return nil
+}
+
+// DeleteMember revokes access of the member unless the membership has been changed since it was read.
+func (s *gormStore) DeleteMember(ctx context.Context, m *Member) error {
+	res := datastore.Conn(ctx, s.db).Where("version = ?", m.Version).Delete(m)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		if err := s.staleMember(ctx, m); !errors.Is(err, datastore.ErrRecordNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// staleMember explains why the membership has not been changed, it is either stale or missing.
+func (s *gormStore) staleMember(ctx context.Context, m *Member) error {
+	var count int64
+	err := datastore.Conn(ctx, s.db).Model(&Member{}).
+		Where("workspace_uuid = ? AND user_id = ?", m.WorkspaceUUID, m.UserID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return datastore.ErrStaleRecord
+	}
+	return datastore.ErrRecordNotFound
+}
+
+func (s *gormStore) GetMember(ctx context.Context, wsUUID uuid.UUID, u *users.User) (*Member, error) {
+	m := &Member{}
+	err := datastore.Conn(ctx, s.db).
+		Joins("Workspace").
+		Where(`"Workspace"."deleted_at" IS NULL`).
+		First(m, "workspace_uuid = ? AND user_id = ?", wsUUID, u.ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *gormStore) GetWorkspaceMembers(ctx context.Context, ws *Workspace) (MemberCollection, error) {
+	ms := make(MemberCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID).Order("created_at").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (s *gormStore) GetUserMemberships(ctx context.Context, u *users.User) (MemberCollection, error) {
+	ms := make(MemberCollection, 0)
+	err := datastore.Conn(ctx, s.db).
+		Joins("Workspace").
+		Where(`"Workspace"."deleted_at" IS NULL`).
+		Where("user_id = ?", u.ID).
+		Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: "created_at"}}).
+		Find(&ms).Error
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (s *gormStore) SaveInvitation(ctx context.Context, inv *Invitation) error {
+	return datastore.Conn(ctx, s.db).Omit("Workspace").Save(inv).Error
+}
+
+func (s *gormStore) DeleteInvitation(ctx context.Context, inv *Invitation) error {
+	return datastore.Delete(datastore.Conn(ctx, s.db), inv)
+}
+
+func (s *gormStore) GetInvitation(ctx context.Context, UUID uuid.UUID) (*Invitation, error) {
+	return s.getInvitation(ctx, "uuid = ?", UUID)
+}
+
+func (s *gormStore) GetInvitationByToken(ctx context.Context, tokenHash string) (*Invitation, error) {
+	return s.getInvitation(ctx, "token_hash = ?", tokenHash)
+}
+
+func (s *gormStore) getInvitation(ctx context.Context, query string, args ...any) (*Invitation, error) {
+	inv := &Invitation{}
+	err := datastore.Conn(ctx, s.db).Where(query, args...).First(inv).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, datastore.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (s *gormStore) GetWorkspaceInvitations(ctx context.Context, ws *Workspace) (InvitationCollection, error) {
+	invs := make(InvitationCollection, 0)
+	if err := datastore.Conn(ctx, s.db).Where("workspace_uuid = ?", ws.UUID).Order("created_at").Find(&invs).Error; err != nil {
+		return nil, err
+	}
+	return invs, nil
+}
+
+func (s *gormStore) AcceptInvitation(ctx context.Context, inv *Invitation, m *Member) error {
+	return datastore.Conn(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		err := datastore.Update(tx.Omit("Workspace").Where("accepted_at IS NULL"), inv)
+		if errors.Is(err, datastore.ErrStaleRecord) {
+			return ErrInvitationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Omit("Workspace").Create(m).Error
+	})
+}
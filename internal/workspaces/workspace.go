@@ -0,0 +1,199 @@
+package workspaces
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/d-ashesss/mah-moneh/internal/datastore"
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	ErrNotMember          = errors.New("user is not a member of the workspace")
+	ErrForbidden          = errors.New("action is not permitted for the member role")
+	ErrInvalidRole        = errors.New("invalid member role")
+	ErrLastOwner          = errors.New("workspace must have at least one owner")
+	ErrPersonalOwner      = errors.New("owner of a personal workspace cannot be changed")
+	ErrAlreadyMember      = errors.New("user is already a member of the workspace")
+	ErrInvitationNotFound = errors.New("invitation does not exist or has expired")
+)
+
+// Role defines what a member is allowed to do within the workspace.
+type Role string
+
+const (
+	// RoleOwner can do anything, including managing the workspace and its members.
+	RoleOwner Role = "owner"
+	// RoleEditor can read and modify the workspace data.
+	RoleEditor Role = "editor"
+	// RoleViewer can only read the workspace data.
+	RoleViewer Role = "viewer"
+)
+
+// Valid checks that the role is a known one.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleOwner, RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Action is a kind of operation to be authorized.
+type Action int
+
+const (
+	// ActionRead is reading the workspace data.
+	ActionRead Action = iota
+	// ActionWrite is creating, changing or deleting the workspace data.
+	ActionWrite
+	// ActionManage is changing the workspace itself, its members and invitations.
+	ActionManage
+)
+
+// Can checks whether the role permits the action.
+func (r Role) Can(a Action) bool {
+	switch a {
+	case ActionRead:
+		return r.Valid()
+	case ActionWrite:
+		return r == RoleOwner || r == RoleEditor
+	case ActionManage:
+		return r == RoleOwner
+	}
+	return false
+}
+
+// Workspace represents a household sharing the same accounts, categories and transactions.
+type Workspace struct {
+	datastore.Model
+	Name string `gorm:"notNull"`
+	// PersonalUserID is set for the workspace created automatically for the user.
+	PersonalUserID *string          `gorm:"uniqueIndex"`
+	Members        MemberCollection `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// NewWorkspace initializes a new workspace owned by the user.
+func NewWorkspace(u *users.User, name string) *Workspace {
+	return &Workspace{
+		Name:    name,
+		Members: MemberCollection{NewMember(u, RoleOwner)},
+	}
+}
+
+// NewPersonalWorkspace initializes the default workspace of the user.
+func NewPersonalWorkspace(u *users.User) *Workspace {
+	ws := NewWorkspace(u, "Personal")
+	ws.PersonalUserID = &u.ID
+	return ws
+}
+
+// Ref provides a reference to the workspace identified by UUID without loading it.
+func Ref(UUID uuid.UUID) *Workspace {
+	return &Workspace{Model: datastore.Model{UUID: UUID}}
+}
+
+// IsPersonalOf checks whether it is the personal workspace of the user.
+func (ws *Workspace) IsPersonalOf(userID string) bool {
+	return ws.PersonalUserID != nil && *ws.PersonalUserID == userID
+}
+
+// Member represents the user's access to the workspace.
+type Member struct {
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID        string     `gorm:"primaryKey"`
+	Role          Role       `gorm:"notNull"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// Version is incremented with every change of the membership, the same way as of the other records.
+	Version uint `gorm:"notNull;default:1"`
+}
+
+// NewMember initializes a new workspace member.
+func NewMember(u *users.User, role Role) *Member {
+	return &Member{UserID: u.ID, Role: role}
+}
+
+// BeforeCreate starts a new membership with the first version.
+func (m *Member) BeforeCreate(*gorm.DB) error {
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	return nil
+}
+
+// User provides the user entity of the member.
+func (m *Member) User() *users.User {
+	return &users.User{ID: m.UserID}
+}
+
+// MemberCollection represents a collection of workspace members.
+type MemberCollection []*Member
+
+// CountOwners counts members with the owner role.
+func (c MemberCollection) CountOwners() int {
+	n := 0
+	for _, m := range c {
+		if m.Role == RoleOwner {
+			n++
+		}
+	}
+	return n
+}
+
+// Invitation allows anyone holding its token to join the workspace with the specified role.
+type Invitation struct {
+	datastore.Model
+	WorkspaceUUID uuid.UUID  `gorm:"type:uuid;notNull;index"`
+	Workspace     *Workspace `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Role          Role       `gorm:"notNull"`
+	// TokenHash is a hash of the invitation token, the token itself is never stored.
+	TokenHash  string      `gorm:"notNull;uniqueIndex"`
+	InvitedBy  *users.User `gorm:"embedded;embeddedPrefix:invited_by_;notNull"`
+	ExpiresAt  time.Time   `gorm:"notNull"`
+	AcceptedBy *string
+	AcceptedAt *time.Time
+}
+
+// NewInvitation initializes a new invitation along with its secret token.
+func NewInvitation(ws *Workspace, u *users.User, role Role, ttl time.Duration) (*Invitation, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	inv := &Invitation{
+		WorkspaceUUID: ws.UUID,
+		Role:          role,
+		TokenHash:     HashToken(token),
+		InvitedBy:     u,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+	return inv, token, nil
+}
+
+// HashToken provides the hash under which the invitation token is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Pending checks that the invitation can still be accepted.
+func (inv *Invitation) Pending(now time.Time) bool {
+	return inv.AcceptedAt == nil && now.Before(inv.ExpiresAt)
+}
+
+// Accept marks the invitation as accepted by the user.
+func (inv *Invitation) Accept(u *users.User, now time.Time) {
+	inv.AcceptedBy = &u.ID
+	inv.AcceptedAt = &now
+}
+
+// InvitationCollection represents a collection of invitations.
+type InvitationCollection []*Invitation
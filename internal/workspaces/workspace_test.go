@@ -0,0 +1,63 @@
+package workspaces_test
+
+import (
+	"github.com/d-ashesss/mah-moneh/internal/users"
+	"github.com/d-ashesss/mah-moneh/internal/workspaces"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type WorkspaceTestSuite struct {
+	suite.Suite
+}
+
+func (ts *WorkspaceTestSuite) TestRoleCan() {
+	tests := []struct {
+		role   workspaces.Role
+		read   bool
+		write  bool
+		manage bool
+	}{
+		{role: workspaces.RoleOwner, read: true, write: true, manage: true},
+		{role: workspaces.RoleEditor, read: true, write: true, manage: false},
+		{role: workspaces.RoleViewer, read: true, write: false, manage: false},
+		{role: "admin", read: false, write: false, manage: false},
+	}
+	for _, tt := range tests {
+		ts.Run(string(tt.role), func() {
+			ts.Equal(tt.read, tt.role.Can(workspaces.ActionRead))
+			ts.Equal(tt.write, tt.role.Can(workspaces.ActionWrite))
+			ts.Equal(tt.manage, tt.role.Can(workspaces.ActionManage))
+		})
+	}
+}
+
+func (ts *WorkspaceTestSuite) TestNewPersonalWorkspace() {
+	u := &users.User{ID: "user1"}
+	ws := workspaces.NewPersonalWorkspace(u)
+	ts.True(ws.IsPersonalOf("user1"))
+	ts.False(ws.IsPersonalOf("user2"))
+	ts.Require().Len(ws.Members, 1)
+	ts.Equal(workspaces.RoleOwner, ws.Members[0].Role)
+	ts.Equal("user1", ws.Members[0].UserID)
+}
+
+func (ts *WorkspaceTestSuite) TestNewInvitation() {
+	ws := &workspaces.Workspace{}
+	inv, token, err := workspaces.NewInvitation(ws, &users.User{ID: "user1"}, workspaces.RoleViewer, time.Hour)
+	ts.Require().NoError(err)
+	ts.NotEmpty(token)
+	ts.Equal(workspaces.HashToken(token), inv.TokenHash)
+	ts.NotEqual(token, inv.TokenHash)
+
+	now := time.Now()
+	ts.True(inv.Pending(now))
+	ts.False(inv.Pending(now.Add(2 * time.Hour)))
+	inv.Accept(&users.User{ID: "user2"}, now)
+	ts.False(inv.Pending(now))
+}
+
+func TestWorkspace(t *testing.T) {
+	suite.Run(t, new(WorkspaceTestSuite))
+}